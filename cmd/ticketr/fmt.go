@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/karolswdev/ticktr/internal/adapters/filesystem"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Fmt command flags
+	fmtCheck bool
+
+	fmtCmd = &cobra.Command{
+		Use:   "fmt [file...]",
+		Short: "Rewrite Markdown files into canonical form",
+		Long: `Sort the ## Fields block of every ticket and task into canonical order:
+the fields listed in markdown.field_order in .ticketr.yaml first (Status by
+default), then the rest alphabetically. The rest of each file is left as it is.
+
+With --check nothing is written; the files that are not in canonical form are
+listed and the command exits 1, for use in CI.`,
+		Args: cobra.MinimumNArgs(1),
+		Run:  runFmt,
+	}
+)
+
+// runFmt handles the fmt command
+func runFmt(cmd *cobra.Command, args []string) {
+	ctx, cancel := commandContext(cmd)
+	defer cancel()
+
+	changed, err := formatFiles(ctx, fileRepositoryFromConfig(), os.Stdout, args, fmtCheck)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if fmtCheck && changed > 0 {
+		os.Exit(1)
+	}
+}
+
+// formatFiles formats each file, or with check only reports it, and lists the
+// files that were not in canonical form. It returns how many there were.
+func formatFiles(ctx context.Context, repo *filesystem.FileRepository, w io.Writer, files []string, check bool) (int, error) {
+	changed := 0
+	for _, file := range files {
+		notCanonical, err := repo.FormatFile(ctx, file, check)
+		if err != nil {
+			return changed, fmt.Errorf("%s: %w", file, err)
+		}
+		if !notCanonical {
+			continue
+		}
+		changed++
+		if check {
+			fmt.Fprintf(w, "%s is not formatted\n", file)
+		} else {
+			fmt.Fprintf(w, "Formatted %s\n", file)
+		}
+	}
+	return changed, nil
+}
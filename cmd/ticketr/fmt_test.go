@@ -0,0 +1,38 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/karolswdev/ticktr/internal/adapters/filesystem"
+)
+
+// TestFormatFiles_Check verifies --check lists unformatted files without writing them
+func TestFormatFiles_Check(t *testing.T) {
+	dir := t.TempDir()
+	formatted := filepath.Join(dir, "formatted.md")
+	unformatted := filepath.Join(dir, "unformatted.md")
+	os.WriteFile(formatted, []byte("# TICKET: A\n\n## Fields\nStatus: Done\nPriority: High\n"), 0644)
+	os.WriteFile(unformatted, []byte("# TICKET: B\n\n## Fields\nPriority: High\nStatus: Done\n"), 0644)
+
+	repo := filesystem.NewFileRepository()
+	var out bytes.Buffer
+	changed, err := formatFiles(context.Background(), repo, &out, []string{formatted, unformatted}, true)
+	if err != nil {
+		t.Fatalf("formatFiles returned error: %v", err)
+	}
+	if changed != 1 || out.String() != unformatted+" is not formatted\n" {
+		t.Errorf("Expected only %s to be listed, got %d:\n%s", unformatted, changed, out.String())
+	}
+
+	out.Reset()
+	if _, err := formatFiles(context.Background(), repo, &out, []string{unformatted}, false); err != nil {
+		t.Fatalf("formatFiles returned error: %v", err)
+	}
+	if saved, _ := os.ReadFile(unformatted); string(saved) != "# TICKET: B\n\n## Fields\nStatus: Done\nPriority: High\n" {
+		t.Errorf("Expected Status to be moved first, got:\n%s", saved)
+	}
+}
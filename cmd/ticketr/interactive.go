@@ -0,0 +1,156 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/karolswdev/ticktr/internal/core/services"
+)
+
+// errResolveAborted is returned when the user quits an interactive pull
+var errResolveAborted = errors.New("aborted by user")
+
+// terminalResolver asks on a terminal how to settle each field a pull finds
+// changed on both sides, showing the base, local and Jira values side by side
+type terminalResolver struct {
+	in     *bufio.Reader
+	out    io.Writer
+	width  int    // Terminal columns
+	ticket string // Ticket whose conflicts are being shown
+}
+
+// newTerminalResolver creates a resolver reading answers from in. The table
+// fills $COLUMNS, or 120 columns.
+func newTerminalResolver(in io.Reader, out io.Writer) *terminalResolver {
+	width, err := strconv.Atoi(os.Getenv("COLUMNS"))
+	if err != nil || width < 40 {
+		width = 120
+	}
+	return &terminalResolver{in: bufio.NewReader(in), out: out, width: width}
+}
+
+// ResolveConflict shows one conflict and asks which value to keep
+func (r *terminalResolver) ResolveConflict(conflict services.FieldConflict) (string, error) {
+	if conflict.JiraID != r.ticket {
+		r.ticket = conflict.JiraID
+		fmt.Fprintf(r.out, "\n=== %s: changed locally and in JIRA ===\n", conflict.JiraID)
+	}
+
+	field := conflict.Field
+	if conflict.TaskID != "" {
+		field = fmt.Sprintf("task %s %s", conflict.TaskID, conflict.Field)
+	}
+	fmt.Fprintf(r.out, "\n%s\n", field)
+	r.printSideBySide(conflict)
+
+	for {
+		fmt.Fprint(r.out, "Keep [o]urs, [t]heirs, [b]ase, [e]dit, or [q]uit? ")
+		answer, err := r.readLine()
+		if err != nil {
+			return "", errResolveAborted
+		}
+		switch strings.ToLower(strings.TrimSpace(answer)) {
+		case "o", "ours":
+			return conflict.Local, nil
+		case "t", "theirs":
+			return conflict.Remote, nil
+		case "b", "base":
+			return conflict.Base, nil
+		case "e", "edit":
+			return r.edit(conflict)
+		case "q", "quit":
+			return "", errResolveAborted
+		}
+		fmt.Fprintln(r.out, "Please answer o, t, b, e or q.")
+	}
+}
+
+// edit reads a new value: one line, or for multi-line fields lines up to one
+// holding only "."
+func (r *terminalResolver) edit(conflict services.FieldConflict) (string, error) {
+	multiline := conflict.Field == "Description" || conflict.Field == "Acceptance Criteria" ||
+		strings.Contains(conflict.Local+conflict.Remote+conflict.Base, "\n")
+	if !multiline {
+		fmt.Fprint(r.out, "New value: ")
+		line, err := r.readLine()
+		if err != nil {
+			return "", errResolveAborted
+		}
+		return strings.TrimSpace(line), nil
+	}
+
+	fmt.Fprintln(r.out, "New value, ending with a line holding only '.':")
+	var lines []string
+	for {
+		line, err := r.readLine()
+		if err != nil {
+			return "", errResolveAborted
+		}
+		if line == "." {
+			return strings.Join(lines, "\n"), nil
+		}
+		lines = append(lines, line)
+	}
+}
+
+// readLine reads one line of input without its line ending
+func (r *terminalResolver) readLine() (string, error) {
+	line, err := r.in.ReadString('\n')
+	if err != nil && (err != io.EOF || line == "") {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
+
+// printSideBySide prints the base, local and Jira values in three columns,
+// wrapping long lines
+func (r *terminalResolver) printSideBySide(conflict services.FieldConflict) {
+	column := (r.width - 6) / 3
+	columns := [][]string{
+		wrapValue(conflict.Base, column),
+		wrapValue(conflict.Local, column),
+		wrapValue(conflict.Remote, column),
+	}
+	rows := max(len(columns[0]), len(columns[1]), len(columns[2]))
+
+	fmt.Fprintf(r.out, "%s | %s | %s\n", pad("BASE", column), pad("LOCAL (ours)", column), "JIRA (theirs)")
+	fmt.Fprintf(r.out, "%s-+-%s-+-%s\n", strings.Repeat("-", column), strings.Repeat("-", column), strings.Repeat("-", column))
+	for i := 0; i < rows; i++ {
+		var cells [3]string
+		for c := range columns {
+			if i < len(columns[c]) {
+				cells[c] = columns[c][i]
+			}
+		}
+		fmt.Fprintf(r.out, "%s | %s | %s\n", pad(cells[0], column), pad(cells[1], column), cells[2])
+	}
+}
+
+// wrapValue splits a value into lines of at most width characters; an empty
+// value shows as "(empty)"
+func wrapValue(value string, width int) []string {
+	if value == "" {
+		return []string{"(empty)"}
+	}
+	var lines []string
+	for _, line := range strings.Split(value, "\n") {
+		runes := []rune(line)
+		for len(runes) > width {
+			lines = append(lines, string(runes[:width]))
+			runes = runes[width:]
+		}
+		lines = append(lines, string(runes))
+	}
+	return lines
+}
+
+// pad fills a cell to width characters
+func pad(cell string, width int) string {
+	return cell + strings.Repeat(" ", max(0, width-utf8.RuneCountInString(cell)))
+}
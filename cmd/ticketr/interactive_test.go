@@ -0,0 +1,65 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/karolswdev/ticktr/internal/core/services"
+)
+
+// TestTerminalResolver verifies each answer picks the right value and the table shows all three sides
+func TestTerminalResolver(t *testing.T) {
+	priority := services.FieldConflict{JiraID: "PROJ-1", Field: "Priority", Base: "Low", Local: "Medium", Remote: "High"}
+	description := services.FieldConflict{JiraID: "PROJ-1", Field: "Description", Base: "Log in", Local: "Log in fast", Remote: "Log in with SSO"}
+
+	tests := []struct {
+		name     string
+		input    string
+		conflict services.FieldConflict
+		want     string
+	}{
+		{"ours", "o\n", priority, "Medium"},
+		{"theirs", "t\n", priority, "High"},
+		{"base", "b\n", priority, "Low"},
+		{"retry after an unknown answer", "x\ntheirs\n", priority, "High"},
+		{"edit one line", "e\n  Critical \n", priority, "Critical"},
+		{"edit several lines", "e\nLog in fast\nwith SSO\n.\n", description, "Log in fast\nwith SSO"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var out bytes.Buffer
+			resolver := newTerminalResolver(strings.NewReader(tt.input), &out)
+			got, err := resolver.ResolveConflict(tt.conflict)
+			if err != nil {
+				t.Fatalf("ResolveConflict returned error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+
+	var out bytes.Buffer
+	resolver := newTerminalResolver(strings.NewReader("o\n"), &out)
+	resolver.width = 60
+	resolver.ResolveConflict(priority)
+	shown := out.String()
+	for _, want := range []string{"=== PROJ-1", "BASE", "LOCAL (ours)", "JIRA (theirs)", "Low                | Medium             | High"} {
+		if !strings.Contains(shown, want) {
+			t.Errorf("Expected %q in:\n%s", want, shown)
+		}
+	}
+}
+
+// TestTerminalResolver_Quit verifies quitting or running out of input aborts
+func TestTerminalResolver_Quit(t *testing.T) {
+	conflict := services.FieldConflict{JiraID: "PROJ-1", Field: "Title", Local: "A", Remote: "B"}
+	for _, input := range []string{"q\n", ""} {
+		resolver := newTerminalResolver(strings.NewReader(input), &bytes.Buffer{})
+		if _, err := resolver.ResolveConflict(conflict); !errors.Is(err, errResolveAborted) {
+			t.Errorf("Input %q: expected errResolveAborted, got %v", input, err)
+		}
+	}
+}
@@ -1,17 +1,25 @@
 package main
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"os/signal"
+	"sort"
 	"strings"
+	"syscall"
+	"time"
 
 	"github.com/karolswdev/ticktr/internal/adapters/filesystem"
 	"github.com/karolswdev/ticktr/internal/adapters/jira"
+	"github.com/karolswdev/ticktr/internal/core/domain"
 	"github.com/karolswdev/ticktr/internal/core/services"
 	"github.com/karolswdev/ticktr/internal/core/validation"
 	"github.com/karolswdev/ticktr/internal/logging"
+	"github.com/karolswdev/ticktr/internal/renderer"
 	"github.com/karolswdev/ticktr/internal/state"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -21,14 +29,18 @@ var (
 	cfgFile            string
 	verbose            bool
 	forcePartialUpload bool
+	pushConcurrency    int
+	commandTimeout     time.Duration
 	logger             logging.Logger
 
 	// Pull command flags
-	pullProject string
-	pullEpic    string
-	pullJQL     string
-	pullOutput  string
-	pullForce   bool
+	pullProject     string
+	pullEpic        string
+	pullJQL         string
+	pullOutput      string
+	pullForce       bool
+	pullMarkers     bool
+	pullInteractive bool
 
 	rootCmd = &cobra.Command{
 		Use:   "ticketr",
@@ -38,11 +50,16 @@ using Markdown files stored in version control.`,
 	}
 
 	pushCmd = &cobra.Command{
-		Use:   "push [file]",
+		Use:   "push [file|dir|glob...]",
 		Short: "Push tickets from Markdown to JIRA",
-		Long:  `Read tickets from a Markdown file and create or update them in JIRA.`,
-		Args:  cobra.ExactArgs(1),
-		Run:   runPush,
+		Long: `Read tickets from Markdown files and create or update them in JIRA.
+
+Directories stand for the *.md files below them and globs may use **
+(backlog/**/*.md). Files are pushed concurrently against one state file, after
+checking that no Jira key is used by two tickets or tasks, and a summary is
+printed for each file.`,
+		Args: cobra.MinimumNArgs(1),
+		Run:  runPush,
 	}
 
 	pullCmd = &cobra.Command{
@@ -50,8 +67,19 @@ using Markdown files stored in version control.`,
 		Short: "Pull tickets from JIRA to Markdown",
 		Long: `Fetch tickets from JIRA and intelligently merge them with your local file.
 
-Detects conflicts when both local and remote tickets have changed. Use --force
-to overwrite local changes with remote changes when conflicts occur.`,
+With pull.routing rules in .ticketr.yaml, tickets are split across files by
+epic, component, label or issue type instead: tickets already in one of the
+files the rules name stay where they are, new ones go to the file their rule's
+template names, and tickets no rule applies to go to --output.
+
+Tickets changed both locally and in Jira are merged field by field. Fields both
+sides changed differently are conflicts: they keep the local value, or Jira's
+with --force. With --conflict-markers (or pull.conflict_markers in
+.ticketr.yaml) both values are written into the file between
+<<<<<<< local / ======= / >>>>>>> jira markers instead; the file cannot be
+pushed until they are removed and 'ticketr resolve' is run. With --interactive
+each conflicting field is shown next to its base and JIRA values, and you
+choose which to keep or type a new one; quitting writes nothing.`,
 		Run: runPull,
 	}
 
@@ -76,9 +104,11 @@ func init() {
 	// Global flags
 	rootCmd.PersistentFlags().StringVar(&cfgFile, "config", "", "config file (default is .ticketr.yaml)")
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "enable verbose logging")
+	rootCmd.PersistentFlags().DurationVar(&commandTimeout, "timeout", 0, "overall deadline for JIRA operations, e.g. 10m (default no limit)")
 
 	// Push command flags
 	pushCmd.Flags().BoolVar(&forcePartialUpload, "force-partial-upload", false, "continue processing even if some items fail")
+	pushCmd.Flags().IntVar(&pushConcurrency, "concurrency", 4, "number of files pushed at the same time")
 
 	// Pull command flags
 	pullCmd.Flags().StringVar(&pullProject, "project", "", "JIRA project key to pull from")
@@ -86,10 +116,29 @@ func init() {
 	pullCmd.Flags().StringVar(&pullJQL, "jql", "", "JQL query to filter tickets")
 	pullCmd.Flags().StringVarP(&pullOutput, "output", "o", "pulled_tickets.md", "output file path")
 	pullCmd.Flags().BoolVar(&pullForce, "force", false, "Force overwrite local changes with remote changes when conflicts are detected")
+	pullCmd.Flags().BoolVar(&pullMarkers, "conflict-markers", false, "write conflicts into the file between <<<<<<< local and >>>>>>> jira markers")
+	pullCmd.Flags().BoolVarP(&pullInteractive, "interactive", "i", false, "choose local, JIRA or edited values for each conflicting field")
+
+	// Plan command flags
+	planCmd.Flags().StringVar(&planOutput, "output", "text", "plan output format: text or json")
+
+	// Validate command flags
+	validateCmd.Flags().BoolVar(&validateJira, "jira", false, "also check fields against JIRA's create metadata (read-only)")
+	validateCmd.Flags().StringVar(&validateOutput, "output", "text", "validation output format: text, json or sarif")
+
+	// Fmt command flags
+	fmtCmd.Flags().BoolVar(&fmtCheck, "check", false, "list files that are not in canonical form and exit 1 instead of rewriting them")
+
+	// Resolve command flags
+	resolveCmd.Flags().StringSliceVar(&resolveTickets, "ticket", nil, "resolve only these Jira keys (repeatable or comma-separated)")
 
 	// Add commands to root
 	rootCmd.AddCommand(pushCmd)
 	rootCmd.AddCommand(pullCmd)
+	rootCmd.AddCommand(planCmd)
+	rootCmd.AddCommand(fmtCmd)
+	rootCmd.AddCommand(validateCmd)
+	rootCmd.AddCommand(resolveCmd)
 	rootCmd.AddCommand(schemaCmd)
 	rootCmd.AddCommand(legacyCmd)
 
@@ -133,55 +182,60 @@ func initConfig() {
 }
 
 func runPush(cmd *cobra.Command, args []string) {
-	inputFile := args[0]
+	files, err := filesystem.ExpandPaths(args)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
 
 	if logger != nil {
 		logger.Section("PUSH COMMAND")
-		logger.Info("Input file: %s", inputFile)
+		logger.Info("Input files: %s", strings.Join(files, ", "))
 		logger.Info("Force partial upload: %v", forcePartialUpload)
 	}
 
+	ctx, cancel := commandContext(cmd)
+	defer cancel()
+
 	// Initialize repository
-	repo := filesystem.NewFileRepository()
+	repo := fileRepositoryFromConfig()
 
-	// Pre-flight validation: Parse tickets first for validation
-	tickets, err := repo.GetTickets(inputFile)
+	// Initialize validator and run pre-flight validation of every file
+	validator, err := validatorFromConfig()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	validationErrors, validationWarnings, err := preflightFiles(ctx, repo, validator, files)
 	if err != nil {
 		fmt.Printf("Error reading tickets from file: %v\n", err)
 		os.Exit(1)
 	}
-
-	// Initialize validator and run pre-flight validation
-	validator := validation.NewValidator()
-	validationErrors := validator.ValidateTickets(tickets)
-	if len(validationErrors) > 0 {
+	printValidationWarnings(validationWarnings)
+	if count := problemCount(validationErrors); count > 0 {
 		if forcePartialUpload {
 			// Downgrade to warnings
 			fmt.Println("Warning: Validation warnings (processing will continue with --force-partial-upload):")
-			for _, vErr := range validationErrors {
-				fmt.Printf("  - %s\n", vErr.Error())
-			}
-			fmt.Printf("\n%d validation warning(s) found. Some items may fail during upload.\n", len(validationErrors))
+			printProblems(os.Stdout, validationErrors)
+			fmt.Printf("\n%d validation warning(s) found. Some items may fail during upload.\n", count)
 		} else {
 			// Hard fail without force flag
 			fmt.Println("Validation errors found:")
-			for _, vErr := range validationErrors {
-				fmt.Printf("  - %s\n", vErr.Error())
-			}
-			fmt.Printf("\n%d validation error(s) found. Fix these issues before pushing to JIRA.\n", len(validationErrors))
+			printProblems(os.Stdout, validationErrors)
+			fmt.Printf("\n%d validation error(s) found. Fix these issues before pushing to JIRA.\n", count)
 			fmt.Println("Tip: Use --force-partial-upload to continue despite validation errors.")
 			os.Exit(1)
 		}
 	}
 
 	// Initialize Jira adapter
-	jiraAdapter, err := jira.NewJiraAdapter()
+	jiraAdapter, err := jira.NewJiraAdapterWithOptions(jiraOptionsFromConfig())
 	if err != nil {
 		fmt.Printf("Error initializing Jira adapter: %v\n", err)
 		fmt.Println("\nMake sure the following environment variables are set:")
 		fmt.Println("  - JIRA_URL")
-		fmt.Println("  - JIRA_EMAIL")
-		fmt.Println("  - JIRA_API_KEY")
+		fmt.Println("  - JIRA_EMAIL and JIRA_API_KEY (jira.auth.type: basic, the default)")
+		fmt.Println("    or JIRA_PAT (pat), or JIRA_OAUTH_TOKEN and JIRA_CLOUD_ID (oauth2)")
 		fmt.Println("  - JIRA_PROJECT_KEY")
 		fmt.Println("\nOptional environment variables:")
 		fmt.Println("  - JIRA_STORY_TYPE (defaults to 'Task')")
@@ -189,8 +243,9 @@ func runPush(cmd *cobra.Command, args []string) {
 		os.Exit(1)
 	}
 
-	// Initialize state manager
+	// Initialize state manager, shared by all files
 	stateManager := state.NewStateManager(".ticketr.state")
+	defer lockState(ctx, stateManager)()
 
 	// Initialize push service with state management
 	service := services.NewPushService(repo, jiraAdapter, stateManager)
@@ -200,56 +255,142 @@ func runPush(cmd *cobra.Command, args []string) {
 		ForcePartialUpload: forcePartialUpload,
 	}
 
-	result, err := service.PushTickets(inputFile, options)
-	if err != nil {
-		fmt.Printf("Error processing file: %v\n", err)
+	results := service.PushFiles(ctx, files, options, pushConcurrency)
+	total, failed := writePushSummary(os.Stdout, results)
+	if ctx.Err() != nil {
+		// Work finished before the interruption has been saved to the files and state
+		fmt.Printf("\nPush interrupted: %v\n", ctx.Err())
+		fmt.Printf("Saved progress: %d ticket(s) created, %d updated, %d task(s) created, %d updated. Run push again to continue.\n",
+			total.TicketsCreated, total.TicketsUpdated, total.TasksCreated, total.TasksUpdated)
+		os.Exit(1)
+	}
+	if failed {
 		os.Exit(1)
 	}
 
-	// Print summary
-	fmt.Println("\n=== Summary ===")
-	if result.TicketsCreated > 0 {
-		fmt.Printf("Tickets created: %d\n", result.TicketsCreated)
+	// Log execution summary
+	if logger != nil {
+		logger.Section("EXECUTION SUMMARY")
+		logger.Info("Tickets created: %d", total.TicketsCreated)
+		logger.Info("Tickets updated: %d", total.TicketsUpdated)
+		logger.Info("Tasks created: %d", total.TasksCreated)
+		logger.Info("Tasks updated: %d", total.TasksUpdated)
+
+		if len(total.Errors) > 0 {
+			logger.Section("ERRORS")
+			for _, err := range total.Errors {
+				logger.Error("%s", err)
+			}
+		}
 	}
-	if result.TicketsUpdated > 0 {
-		fmt.Printf("Tickets updated: %d\n", result.TicketsUpdated)
+
+	if len(total.Errors) > 0 && !forcePartialUpload {
+		os.Exit(2)
 	}
-	if result.TasksCreated > 0 {
-		fmt.Printf("Tasks created: %d\n", result.TasksCreated)
+
+	fmt.Println("\nProcessing complete!")
+}
+
+// readTickets reads the tickets of a file for push and plan. Parser errors
+// fail it; parser warnings are returned as problems of the syntax rule.
+func readTickets(ctx context.Context, repo *filesystem.FileRepository, validator *validation.Validator, file string) ([]domain.Ticket, []validation.ValidationError, error) {
+	tickets, diagnostics, err := repo.GetTicketsWithDiagnostics(ctx, file)
+	if err != nil {
+		return nil, nil, err
 	}
-	if result.TasksUpdated > 0 {
-		fmt.Printf("Tasks updated: %d\n", result.TasksUpdated)
+	if errors := diagnostics.Errors(); len(errors) > 0 {
+		return nil, nil, errors
 	}
+	return tickets, syntaxProblems(validator, diagnostics.Warnings()), nil
+}
 
-	// Print errors if any
-	if len(result.Errors) > 0 {
-		fmt.Printf("\n=== Errors (%d) ===\n", len(result.Errors))
-		for _, err := range result.Errors {
-			fmt.Printf("  - %s\n", err)
+// preflightFiles reads every file and validates its tickets, and checks that
+// no Jira key is used twice across the files. Problems are returned by file,
+// split into errors and warnings.
+func preflightFiles(ctx context.Context, repo *filesystem.FileRepository, validator *validation.Validator, files []string) (errors, warnings []fileProblems, err error) {
+	parsed := make([]validation.FileTickets, len(files))
+	syntax := make([][]validation.ValidationError, len(files))
+	for i, file := range files {
+		tickets, problems, err := readTickets(ctx, repo, validator, file)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%s: %w", file, err)
 		}
+		parsed[i] = validation.FileTickets{File: file, Tickets: tickets}
+		syntax[i] = problems
+	}
 
-		if !forcePartialUpload {
-			os.Exit(2)
+	duplicates := validator.ValidateKeys(parsed)
+	for i, file := range parsed {
+		problems := append(syntax[i], validator.ValidateTickets(file.Tickets)...)
+		problems = append(problems, duplicates[file.File]...)
+		sort.SliceStable(problems, func(a, b int) bool { return problems[a].Line < problems[b].Line })
+		fileErrors, fileWarnings := validation.SplitBySeverity(problems)
+		if len(fileErrors) > 0 {
+			errors = append(errors, fileProblems{File: file.File, Problems: fileErrors})
+		}
+		if len(fileWarnings) > 0 {
+			warnings = append(warnings, fileProblems{File: file.File, Problems: fileWarnings})
 		}
 	}
+	return errors, warnings, nil
+}
 
-	// Log execution summary
-	if logger != nil {
-		logger.Section("EXECUTION SUMMARY")
-		logger.Info("Tickets created: %d", result.TicketsCreated)
-		logger.Info("Tickets updated: %d", result.TicketsUpdated)
-		logger.Info("Tasks created: %d", result.TasksCreated)
-		logger.Info("Tasks updated: %d", result.TasksUpdated)
+// writePushSummary prints what was pushed, per file when there are several,
+// and the errors. It returns the totals and whether a file could not be
+// pushed at all.
+func writePushSummary(w io.Writer, results []services.FileResult) (*services.ProcessResult, bool) {
+	total := &services.ProcessResult{Errors: []string{}}
+	failed := false
+
+	fmt.Fprintln(w, "\n=== Summary ===")
+	for _, fileResult := range results {
+		result := fileResult.Result
+		if result == nil {
+			failed = true
+			fmt.Fprintf(w, "%s: failed: %v\n", fileResult.File, fileResult.Err)
+			continue
+		}
+		if len(results) > 1 {
+			fmt.Fprintf(w, "%s: %d ticket(s) created, %d updated, %d task(s) created, %d updated, %d error(s)\n",
+				fileResult.File, result.TicketsCreated, result.TicketsUpdated, result.TasksCreated, result.TasksUpdated, len(result.Errors))
+		}
 
-		if len(result.Errors) > 0 {
-			logger.Section("ERRORS")
-			for _, err := range result.Errors {
-				logger.Error("%s", err)
+		total.TicketsCreated += result.TicketsCreated
+		total.TicketsUpdated += result.TicketsUpdated
+		total.TasksCreated += result.TasksCreated
+		total.TasksUpdated += result.TasksUpdated
+		for _, err := range result.Errors {
+			if len(results) > 1 {
+				err = fmt.Sprintf("%s: %s", fileResult.File, strings.TrimLeft(err, " "))
 			}
+			total.Errors = append(total.Errors, err)
 		}
 	}
+	if len(results) > 1 {
+		fmt.Fprintln(w)
+	}
 
-	fmt.Println("\nProcessing complete!")
+	if total.TicketsCreated > 0 {
+		fmt.Fprintf(w, "Tickets created: %d\n", total.TicketsCreated)
+	}
+	if total.TicketsUpdated > 0 {
+		fmt.Fprintf(w, "Tickets updated: %d\n", total.TicketsUpdated)
+	}
+	if total.TasksCreated > 0 {
+		fmt.Fprintf(w, "Tasks created: %d\n", total.TasksCreated)
+	}
+	if total.TasksUpdated > 0 {
+		fmt.Fprintf(w, "Tasks updated: %d\n", total.TasksUpdated)
+	}
+
+	// Print errors if any
+	if len(total.Errors) > 0 {
+		fmt.Fprintf(w, "\n=== Errors (%d) ===\n", len(total.Errors))
+		for _, err := range total.Errors {
+			fmt.Fprintf(w, "  - %s\n", err)
+		}
+	}
+	return total, failed
 }
 
 // runPull handles the pull command
@@ -261,24 +402,21 @@ func runPull(cmd *cobra.Command, args []string) {
 		logger.Info("JQL: %s", pullJQL)
 		logger.Info("Output: %s", pullOutput)
 		logger.Info("Force: %v", pullForce)
+		logger.Info("Conflict markers: %v", pullMarkers || viper.GetBool("pull.conflict_markers"))
+		logger.Info("Interactive: %v", pullInteractive)
 	}
 
-	// Initialize JIRA adapter with field mappings from config
-	fieldMappings := viper.GetStringMap("field_mappings")
-
-	// Convert to proper format for adapter
-	mappings := make(map[string]interface{})
-	for key, value := range fieldMappings {
-		mappings[key] = value
-	}
+	ctx, cancel := commandContext(cmd)
+	defer cancel()
 
-	jiraAdapter, err := jira.NewJiraAdapterWithConfig(mappings)
+	// Initialize JIRA adapter with field mappings and search settings from config
+	jiraAdapter, err := jira.NewJiraAdapterWithOptions(jiraOptionsFromConfig())
 	if err != nil {
 		fmt.Printf("Error initializing JIRA adapter: %v\n", err)
 		fmt.Println("\nMake sure the following environment variables are set:")
 		fmt.Println("  - JIRA_URL")
-		fmt.Println("  - JIRA_EMAIL")
-		fmt.Println("  - JIRA_API_KEY")
+		fmt.Println("  - JIRA_EMAIL and JIRA_API_KEY (jira.auth.type: basic, the default)")
+		fmt.Println("    or JIRA_PAT (pat), or JIRA_OAUTH_TOKEN and JIRA_CLOUD_ID (oauth2)")
 		fmt.Println("  - JIRA_PROJECT_KEY")
 		os.Exit(1)
 	}
@@ -314,19 +452,39 @@ func runPull(cmd *cobra.Command, args []string) {
 
 	// Initialize state manager
 	stateManager := state.NewStateManager(".ticketr.state")
+	defer lockState(ctx, stateManager)()
 
 	// Initialize file repository
-	fileRepo := filesystem.NewFileRepository()
+	fileRepo := fileRepositoryFromConfig()
+
+	// Route tickets to files by the pull.routing rules
+	router, err := routerFromConfig(pullOutput)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	localFiles, err := routedFiles(router)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
 
 	// Create pull service
 	pullService := services.NewPullService(jiraAdapter, fileRepo, stateManager)
 
-	// Execute pull
-	result, err := pullService.Pull(pullOutput, services.PullOptions{
-		ProjectKey: projectKey,
-		JQL:        jql,
-		EpicKey:    pullEpic,
-		Force:      pullForce,
+	// Execute pull; conflict markers only matter when conflicts are kept
+	conflictMarkers := (pullMarkers || viper.GetBool("pull.conflict_markers")) && !pullForce && !pullInteractive
+	var resolver services.ConflictResolver
+	if pullInteractive {
+		resolver = newTerminalResolver(os.Stdin, os.Stdout)
+	}
+	result, err := pullService.PullRouted(ctx, router, localFiles, services.PullOptions{
+		ProjectKey:      projectKey,
+		JQL:             jql,
+		EpicKey:         pullEpic,
+		Force:           pullForce,
+		ConflictMarkers: conflictMarkers,
+		Resolver:        resolver,
 	})
 
 	// Handle errors and conflicts
@@ -336,7 +494,22 @@ func runPull(cmd *cobra.Command, args []string) {
 			for _, ticketID := range result.Conflicts {
 				fmt.Printf("  - %s\n", ticketID)
 			}
-			fmt.Println("\nTo force overwrite local changes with remote changes, use --force flag")
+			if conflictMarkers {
+				fmt.Printf("\nBoth versions are written between <<<<<<< local and >>>>>>> jira markers in %s.\n", strings.Join(result.Files, ", "))
+				fmt.Println("Keep the version you want, delete the markers, then run 'ticketr resolve' and push")
+			} else if len(result.FieldConflicts) > 0 {
+				fmt.Println("\nThese fields were changed on both sides and kept their local value:")
+				for _, conflict := range result.FieldConflicts {
+					fmt.Printf("  - %s\n", conflict)
+				}
+				fmt.Println("\nEvery other change was merged. Settle the fields, run 'ticketr resolve' and push, or take Jira's values with --force")
+			} else {
+				fmt.Println("\nTo force overwrite local changes with remote changes, use --force flag")
+			}
+			os.Exit(1)
+		}
+		if errors.Is(err, errResolveAborted) {
+			fmt.Println("\nPull aborted; no files or state were written")
 			os.Exit(1)
 		}
 		fmt.Printf("Error pulling tickets: %v\n", err)
@@ -344,41 +517,219 @@ func runPull(cmd *cobra.Command, args []string) {
 	}
 
 	// Print summary
-	fmt.Printf("Successfully updated %s\n", pullOutput)
+	fmt.Printf("Successfully updated %s\n", strings.Join(result.Files, ", "))
+	fmt.Printf("  - %d issue(s) fetched from JIRA\n", result.IssuesFetched)
 	if result.TicketsPulled > 0 {
 		fmt.Printf("  - %d new ticket(s) pulled from JIRA\n", result.TicketsPulled)
 	}
 	if result.TicketsUpdated > 0 {
 		fmt.Printf("  - %d ticket(s) updated with remote changes\n", result.TicketsUpdated)
 	}
+	if result.TicketsMerged > 0 {
+		fmt.Printf("  - %d ticket(s) merged with local changes (push to send them)\n", result.TicketsMerged)
+	}
 	if result.TicketsSkipped > 0 {
 		fmt.Printf("  - %d ticket(s) skipped (no changes or local changes preserved)\n", result.TicketsSkipped)
 	}
 	if len(result.Conflicts) > 0 {
 		fmt.Printf("  - %d conflict(s) detected\n", len(result.Conflicts))
 	}
+	if len(result.Errors) > 0 {
+		fmt.Printf("\n⚠️  %d error(s) while pulling (affected tickets kept their local version):\n", len(result.Errors))
+		for _, pullErr := range result.Errors {
+			fmt.Printf("  - %v\n", pullErr)
+		}
+	}
 
 	// Log execution summary
 	if logger != nil {
 		logger.Section("EXECUTION SUMMARY")
+		logger.Info("Issues fetched: %d", result.IssuesFetched)
 		logger.Info("Tickets pulled: %d", result.TicketsPulled)
 		logger.Info("Tickets updated: %d", result.TicketsUpdated)
+		logger.Info("Tickets merged: %d", result.TicketsMerged)
 		logger.Info("Tickets skipped: %d", result.TicketsSkipped)
 		logger.Info("Conflicts: %d", len(result.Conflicts))
+		for _, pullErr := range result.Errors {
+			logger.Error("%v", pullErr)
+		}
+	}
+}
+
+// jiraOptionsFromConfig builds JIRA adapter options from .ticketr.yaml
+func jiraOptionsFromConfig() jira.Options {
+	var mappings map[string]interface{}
+	if fieldMappings := viper.GetStringMap("field_mappings"); len(fieldMappings) > 0 {
+		// Convert to proper format for adapter
+		mappings = make(map[string]interface{})
+		for key, value := range fieldMappings {
+			mappings[key] = value
+		}
+	}
+
+	// Unset retry keys keep the adapter's defaults
+	var retry *jira.RetryPolicy
+	if viper.IsSet("jira.retry") {
+		policy := jira.DefaultRetryPolicy()
+		if viper.IsSet("jira.retry.max_retries") {
+			policy.MaxRetries = viper.GetInt("jira.retry.max_retries")
+		}
+		if viper.IsSet("jira.retry.initial_backoff") {
+			policy.InitialBackoff = viper.GetDuration("jira.retry.initial_backoff")
+		}
+		if viper.IsSet("jira.retry.max_backoff") {
+			policy.MaxBackoff = viper.GetDuration("jira.retry.max_backoff")
+		}
+		retry = &policy
+	}
+
+	return jira.Options{
+		FieldMappings:  mappings,
+		PageSize:       viper.GetInt("jira.search.page_size"),
+		MaxResults:     viper.GetInt("jira.search.max_results"),
+		SearchEndpoint: viper.GetString("jira.search.endpoint"),
+		APIVersion:     viper.GetString("jira.api_version"),
+		Deployment:     viper.GetString("jira.deployment"),
+		AuthType:       viper.GetString("jira.auth.type"),
+		CloudID:        viper.GetString("jira.auth.cloud_id"),
+		EpicLinkField:  viper.GetString("jira.epic_link_field"),
+		Retry:          retry,
+		RequestTimeout: viper.GetDuration("jira.request_timeout"),
+	}
+}
+
+// pullRoutingRule is an entry of pull.routing in .ticketr.yaml
+type pullRoutingRule struct {
+	By   string `mapstructure:"by"`
+	File string `mapstructure:"file"`
+}
+
+// routerFromConfig builds the pull router from pull.routing in .ticketr.yaml,
+// sending tickets no rule applies to to fallback
+func routerFromConfig(fallback string) (*services.Router, error) {
+	var entries []pullRoutingRule
+	if err := viper.UnmarshalKey("pull.routing", &entries); err != nil {
+		return nil, fmt.Errorf("invalid pull.routing: %w", err)
+	}
+	rules := make([]services.RoutingRule, len(entries))
+	for i, entry := range entries {
+		rules[i] = services.RoutingRule{By: entry.By, File: entry.File}
+	}
+
+	router, err := services.NewRouter(rules, fallback)
+	if err != nil {
+		return nil, fmt.Errorf("invalid pull.routing: %w", err)
 	}
+	return router, nil
+}
+
+// routedFiles returns the existing files the router can send tickets to, and
+// the fallback file whether it exists or not
+func routedFiles(router *services.Router) ([]string, error) {
+	patterns := router.Patterns()
+	files := []string{patterns[0]}
+	for _, pattern := range patterns[1:] {
+		matches, err := filesystem.Glob(pattern)
+		if err != nil {
+			return nil, err
+		}
+		files = append(files, matches...)
+	}
+	return files, nil
+}
+
+// validationFieldRule is an entry of validation.fields in .ticketr.yaml
+type validationFieldRule struct {
+	Field      string   `mapstructure:"field"`
+	IssueTypes []string `mapstructure:"issue_types"`
+	Pattern    string   `mapstructure:"pattern"`
+	MaxLength  int      `mapstructure:"max_length"`
+	Message    string   `mapstructure:"message"`
+	Severity   string   `mapstructure:"severity"`
+}
+
+// validatorFromConfig builds the validator from the validation block of
+// .ticketr.yaml. A missing hierarchy keeps the built-in one.
+func validatorFromConfig() (*validation.Validator, error) {
+	rules := validation.DefaultRules()
+	if viper.IsSet("validation.hierarchy") {
+		rules.Hierarchy = viper.GetStringMapStringSlice("validation.hierarchy")
+	}
+	rules.RequiredFields = viper.GetStringMapStringSlice("validation.required_fields")
+
+	rules.Disabled = viper.GetStringSlice("validation.disable")
+	rules.Severity = make(map[string]validation.Severity)
+	for check, severity := range viper.GetStringMapString("validation.severity") {
+		rules.Severity[check] = validation.Severity(severity)
+	}
+
+	var fieldRules []validationFieldRule
+	if err := viper.UnmarshalKey("validation.fields", &fieldRules); err != nil {
+		return nil, fmt.Errorf("invalid validation.fields: %w", err)
+	}
+	for _, rule := range fieldRules {
+		rules.Fields = append(rules.Fields, validation.FieldRule{
+			Field:      rule.Field,
+			IssueTypes: rule.IssueTypes,
+			Pattern:    rule.Pattern,
+			MaxLength:  rule.MaxLength,
+			Message:    rule.Message,
+			Severity:   validation.Severity(rule.Severity),
+		})
+	}
+
+	validator, err := validation.NewValidatorWithRules(rules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid validation settings: %w", err)
+	}
+	return validator, nil
+}
+
+// fileRepositoryFromConfig builds the Markdown file repository, writing
+// ## Fields blocks in the markdown.field_order from .ticketr.yaml
+func fileRepositoryFromConfig() *filesystem.FileRepository {
+	return filesystem.NewFileRepositoryWithFieldOrder(renderer.NewFieldOrder(viper.GetStringSlice("markdown.field_order")))
+}
+
+// commandContext returns the command's context bounded by the --timeout flag.
+// The root context is cancelled on SIGINT/SIGTERM.
+func commandContext(cmd *cobra.Command) (context.Context, context.CancelFunc) {
+	ctx := cmd.Context()
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	if commandTimeout > 0 {
+		return context.WithTimeout(ctx, commandTimeout)
+	}
+	return context.WithCancel(ctx)
+}
+
+// lockState locks the state file for the rest of the command, so concurrent
+// runs take turns, and exits when the lock cannot be had. It returns the
+// function releasing the lock.
+func lockState(ctx context.Context, stateManager *state.StateManager) func() {
+	unlock, err := stateManager.Lock(ctx)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	return unlock
 }
 
 // runSchema handles the schema discovery command
 func runSchema(cmd *cobra.Command, args []string) {
+	ctx, cancel := commandContext(cmd)
+	defer cancel()
+
 	// Initialize JIRA adapter
-	jiraAdapter, err := jira.NewJiraAdapter()
+	jiraAdapter, err := jira.NewJiraAdapterWithOptions(jiraOptionsFromConfig())
 	if err != nil {
 		fmt.Printf("Error initializing JIRA adapter: %v\n", err)
 		os.Exit(1)
 	}
 
 	// Get project issue types
-	issueTypes, err := jiraAdapter.GetProjectIssueTypes()
+	issueTypes, err := jiraAdapter.GetProjectIssueTypes(ctx)
 	if err != nil {
 		fmt.Printf("Error fetching project issue types: %v\n", err)
 		os.Exit(1)
@@ -413,7 +764,7 @@ func runSchema(cmd *cobra.Command, args []string) {
 				fmt.Fprintf(os.Stderr, "  Fetching fields for issue type: %s\n", issueType)
 			}
 
-			fields, err := jiraAdapter.GetIssueTypeFields(issueType)
+			fields, err := jiraAdapter.GetIssueTypeFields(ctx, issueType)
 			if err != nil {
 				fmt.Fprintf(os.Stderr, "Warning: Could not fetch fields for %s: %v\n", issueType, err)
 				continue
@@ -511,8 +862,11 @@ func runLegacy(cmd *cobra.Command, args []string) {
 	listIssueTypes, _ := cmd.Flags().GetBool("list-issue-types")
 	checkFields, _ := cmd.Flags().GetString("check-fields")
 
+	ctx, cancel := commandContext(cmd)
+	defer cancel()
+
 	// Initialize Jira adapter for legacy commands
-	jiraAdapter, err := jira.NewJiraAdapter()
+	jiraAdapter, err := jira.NewJiraAdapterWithOptions(jiraOptionsFromConfig())
 	if err != nil {
 		fmt.Printf("Error initializing Jira adapter: %v\n", err)
 		os.Exit(1)
@@ -521,7 +875,7 @@ func runLegacy(cmd *cobra.Command, args []string) {
 	// Handle list-issue-types
 	if listIssueTypes {
 		fmt.Println("Fetching issue types from JIRA...")
-		issueTypesInfo, err := jiraAdapter.GetProjectIssueTypes()
+		issueTypesInfo, err := jiraAdapter.GetProjectIssueTypes(ctx)
 		if err != nil {
 			fmt.Printf("Error fetching issue types: %v\n", err)
 			os.Exit(1)
@@ -555,7 +909,7 @@ func runLegacy(cmd *cobra.Command, args []string) {
 	// Handle check-fields
 	if checkFields != "" {
 		fmt.Printf("Checking fields for issue type: %s\n", checkFields)
-		fields, err := jiraAdapter.GetIssueTypeFields(checkFields)
+		fields, err := jiraAdapter.GetIssueTypeFields(ctx, checkFields)
 		if err != nil {
 			fmt.Printf("Error fetching fields: %v\n", err)
 			os.Exit(1)
@@ -654,7 +1008,7 @@ func main() {
 	// Check for legacy usage (no subcommand)
 	if len(os.Args) > 1 && !strings.HasPrefix(os.Args[1], "-") {
 		// If first arg is not a flag and not a known command, assume it's a file (legacy)
-		knownCommands := []string{"push", "pull", "schema", "help", "completion"}
+		knownCommands := []string{"push", "pull", "plan", "schema", "fmt", "validate", "resolve", "help", "completion"}
 		isKnownCommand := false
 		for _, cmd := range knownCommands {
 			if os.Args[1] == cmd {
@@ -689,7 +1043,15 @@ func main() {
 		}
 	}
 
-	if err := rootCmd.Execute(); err != nil {
+	// Cancel running commands on Ctrl-C so they can save completed work; a
+	// second signal falls through to the default handler and exits immediately
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	go func() {
+		<-ctx.Done()
+		stop()
+	}()
+
+	if err := rootCmd.ExecuteContext(ctx); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
@@ -1,10 +1,16 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"errors"
 	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/karolswdev/ticktr/internal/core/services"
+	"github.com/karolswdev/ticktr/internal/core/validation"
 	"github.com/spf13/viper"
 )
 
@@ -190,3 +196,109 @@ field_mappings:
 		t.Errorf("Expected Type mapping to be 'issuetype', got '%s'", typeMapping)
 	}
 }
+
+// TestPreflightFiles verifies push checks every file and stops on a Jira key used in two files
+func TestPreflightFiles(t *testing.T) {
+	dir := t.TempDir()
+	auth := filepath.Join(dir, "auth.md")
+	billing := filepath.Join(dir, "billing.md")
+	os.WriteFile(auth, []byte("# TICKET: [PROJ-1] Login\n"), 0644)
+	os.WriteFile(billing, []byte("# TICKET: [PROJ-1] Invoices\n"), 0644)
+
+	failures, warnings, err := preflightFiles(context.Background(), fileRepositoryFromConfig(), validation.NewValidator(), []string{auth, billing})
+	if err != nil {
+		t.Fatalf("preflightFiles returned error: %v", err)
+	}
+	if len(warnings) != 0 || problemCount(failures) != 2 || failures[0].File != auth || failures[1].File != billing {
+		t.Errorf("Expected the shared key in both files, got %+v", failures)
+	}
+
+	if _, _, err := preflightFiles(context.Background(), fileRepositoryFromConfig(), validation.NewValidator(), []string{filepath.Join(dir, "missing.md")}); err == nil {
+		t.Error("Expected an error for a missing file")
+	}
+}
+
+// TestPreflightFiles_ParserDiagnostics verifies push reports parser warnings with their position and stops on parser errors
+func TestPreflightFiles_ParserDiagnostics(t *testing.T) {
+	dir := t.TempDir()
+	typo := filepath.Join(dir, "typo.md")
+	conflict := filepath.Join(dir, "conflict.md")
+	os.WriteFile(typo, []byte("# TICKET: Login\n\n## Acceptence Criteria\n- Works\n"), 0644)
+	os.WriteFile(conflict, []byte("# TICKET: Login\n\n## Description\n<<<<<<< local\nMine\n=======\nTheirs\n>>>>>>> jira\n"), 0644)
+
+	failures, warnings, err := preflightFiles(context.Background(), fileRepositoryFromConfig(), validation.NewValidator(), []string{typo})
+	if err != nil {
+		t.Fatalf("preflightFiles returned error: %v", err)
+	}
+	if problemCount(failures) != 0 || problemCount(warnings) != 1 {
+		t.Fatalf("Expected one warning, got errors %+v and warnings %+v", failures, warnings)
+	}
+	var out bytes.Buffer
+	printProblems(&out, warnings)
+	want := "  - " + typo + ":3:1: Markdown: Unknown section '## Acceptence Criteria' is ignored (did you mean '## Acceptance Criteria'?)\n"
+	if out.String() != want {
+		t.Errorf("Expected:\n%s\nGot:\n%s", want, out.String())
+	}
+
+	if _, _, err := preflightFiles(context.Background(), fileRepositoryFromConfig(), validation.NewValidator(), []string{conflict}); err == nil || !strings.Contains(err.Error(), conflict) {
+		t.Errorf("Expected an error naming the file with conflict markers, got %v", err)
+	}
+}
+
+// TestWritePushSummary verifies several files are summarised one per line, with totals and prefixed errors
+func TestWritePushSummary(t *testing.T) {
+	var out bytes.Buffer
+	total, failed := writePushSummary(&out, []services.FileResult{
+		{File: "backlog/auth.md", Result: &services.ProcessResult{TicketsCreated: 2, TasksUpdated: 1, Errors: []string{"  Failed to update task 'Form' (PROJ-2): boom"}}},
+		{File: "backlog/billing.md", Result: &services.ProcessResult{TicketsUpdated: 1, Errors: []string{}}},
+		{File: "backlog/missing.md", Err: errors.New("file not found")},
+	})
+
+	want := "\n=== Summary ===\n" +
+		"backlog/auth.md: 2 ticket(s) created, 0 updated, 0 task(s) created, 1 updated, 1 error(s)\n" +
+		"backlog/billing.md: 0 ticket(s) created, 1 updated, 0 task(s) created, 0 updated, 0 error(s)\n" +
+		"backlog/missing.md: failed: file not found\n" +
+		"\n" +
+		"Tickets created: 2\n" +
+		"Tickets updated: 1\n" +
+		"Tasks updated: 1\n" +
+		"\n=== Errors (1) ===\n" +
+		"  - backlog/auth.md: Failed to update task 'Form' (PROJ-2): boom\n"
+	if out.String() != want {
+		t.Errorf("Expected:\n%s\nGot:\n%s", want, out.String())
+	}
+	if !failed || total.TicketsCreated != 2 || len(total.Errors) != 1 {
+		t.Errorf("Unexpected totals: %+v, failed %v", total, failed)
+	}
+}
+
+// TestRouterFromConfig verifies pull.routing is read and existing routed files are found
+func TestRouterFromConfig(t *testing.T) {
+	dir := t.TempDir()
+	os.MkdirAll(filepath.Join(dir, "backlog"), 0755)
+	os.WriteFile(filepath.Join(dir, "backlog", "PROJ-1.md"), []byte("# TICKET: [PROJ-2] Login\n"), 0644)
+
+	config := `
+pull:
+  routing:
+    - by: epic
+      file: "` + filepath.ToSlash(dir) + `/backlog/{{.Epic}}.md"
+`
+	viper.SetConfigType("yaml")
+	if err := viper.ReadConfig(strings.NewReader(config)); err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	t.Cleanup(viper.Reset)
+
+	router, err := routerFromConfig("pulled.md")
+	if err != nil {
+		t.Fatalf("routerFromConfig returned error: %v", err)
+	}
+	files, err := routedFiles(router)
+	if err != nil {
+		t.Fatalf("routedFiles returned error: %v", err)
+	}
+	if len(files) != 2 || files[0] != "pulled.md" || files[1] != filepath.Join(dir, "backlog", "PROJ-1.md") {
+		t.Errorf("Expected the fallback and PROJ-1.md, got %v", files)
+	}
+}
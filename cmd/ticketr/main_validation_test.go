@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"os"
 	"path/filepath"
 	"testing"
@@ -36,7 +37,7 @@ This is an epic
 
 	// Parse the file to get tickets
 	repo := filesystem.NewFileRepository()
-	tickets, err := repo.GetTickets(testFile)
+	tickets, err := repo.GetTickets(context.Background(), testFile)
 	if err != nil {
 		t.Fatalf("Failed to parse tickets: %v", err)
 	}
@@ -73,42 +74,74 @@ type MockJiraPortNeverCalled struct {
 	t *testing.T
 }
 
-func (m *MockJiraPortNeverCalled) Authenticate() error {
+func (m *MockJiraPortNeverCalled) Authenticate(ctx context.Context) error {
 	m.t.Fatal("JiraAdapter.Authenticate should not be called on validation error")
 	return nil
 }
 
-func (m *MockJiraPortNeverCalled) CreateTask(task domain.Task, parentID string) (string, error) {
+func (m *MockJiraPortNeverCalled) CreateTask(ctx context.Context, task domain.Task, parentID string) (string, error) {
 	m.t.Fatal("JiraAdapter.CreateTask should not be called on validation error")
 	return "", nil
 }
 
-func (m *MockJiraPortNeverCalled) UpdateTask(task domain.Task) error {
+func (m *MockJiraPortNeverCalled) UpdateTask(ctx context.Context, task domain.Task) error {
 	m.t.Fatal("JiraAdapter.UpdateTask should not be called on validation error")
 	return nil
 }
 
-func (m *MockJiraPortNeverCalled) GetProjectIssueTypes() (map[string][]string, error) {
+func (m *MockJiraPortNeverCalled) GetProjectIssueTypes(ctx context.Context) (map[string][]string, error) {
 	m.t.Fatal("JiraAdapter.GetProjectIssueTypes should not be called on validation error")
 	return nil, nil
 }
 
-func (m *MockJiraPortNeverCalled) GetIssueTypeFields(issueTypeName string) (map[string]interface{}, error) {
+func (m *MockJiraPortNeverCalled) GetIssueTypeFields(ctx context.Context, issueTypeName string) (map[string]interface{}, error) {
 	m.t.Fatal("JiraAdapter.GetIssueTypeFields should not be called on validation error")
 	return nil, nil
 }
 
-func (m *MockJiraPortNeverCalled) CreateTicket(ticket domain.Ticket) (string, error) {
+func (m *MockJiraPortNeverCalled) CreateTicket(ctx context.Context, ticket domain.Ticket) (string, error) {
 	m.t.Fatal("JiraAdapter.CreateTicket should not be called on validation error")
 	return "", nil
 }
 
-func (m *MockJiraPortNeverCalled) UpdateTicket(ticket domain.Ticket) error {
+func (m *MockJiraPortNeverCalled) UpdateTicket(ctx context.Context, ticket domain.Ticket) error {
 	m.t.Fatal("JiraAdapter.UpdateTicket should not be called on validation error")
 	return nil
 }
 
-func (m *MockJiraPortNeverCalled) SearchTickets(projectKey string, jql string) ([]domain.Ticket, error) {
+func (m *MockJiraPortNeverCalled) SearchTickets(ctx context.Context, projectKey string, jql string) ([]domain.Ticket, error) {
 	m.t.Fatal("JiraAdapter.SearchTickets should not be called on validation error")
 	return nil, nil
 }
+
+func (m *MockJiraPortNeverCalled) DiffTicket(ctx context.Context, ticket domain.Ticket) ([]domain.FieldChange, error) {
+	m.t.Fatal("JiraAdapter.DiffTicket should not be called on validation error")
+	return nil, nil
+}
+
+func (m *MockJiraPortNeverCalled) LinkIssues(ctx context.Context, issueKey string, links []domain.Link) error {
+	return nil
+}
+
+func (m *MockJiraPortNeverCalled) TransitionIssue(ctx context.Context, issueKey string, status string) error {
+	return nil
+}
+
+func (m *MockJiraPortNeverCalled) AddComment(ctx context.Context, issueKey string, comment domain.Comment) (domain.Comment, error) {
+	return comment, nil
+}
+
+func (m *MockJiraPortNeverCalled) DiffTask(ctx context.Context, task domain.Task, parentID string) ([]domain.FieldChange, error) {
+	m.t.Fatal("JiraAdapter.DiffTask should not be called on validation error")
+	return nil, nil
+}
+
+func (m *MockJiraPortNeverCalled) DiffLinks(ctx context.Context, issueKey string, links []domain.Link) ([]domain.Link, error) {
+	m.t.Fatal("JiraAdapter.DiffLinks should not be called on validation error")
+	return nil, nil
+}
+
+func (m *MockJiraPortNeverCalled) TransitionPath(ctx context.Context, issueKey string, status string) ([]string, error) {
+	m.t.Fatal("JiraAdapter.TransitionPath should not be called on validation error")
+	return nil, nil
+}
@@ -0,0 +1,190 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/karolswdev/ticktr/internal/adapters/jira"
+	"github.com/karolswdev/ticktr/internal/core/domain"
+	"github.com/karolswdev/ticktr/internal/core/services"
+	"github.com/karolswdev/ticktr/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Plan command flags
+	planOutput string
+
+	planCmd = &cobra.Command{
+		Use:   "plan [file]",
+		Short: "Show what push would change in JIRA without writing anything",
+		Long: `Compare a Markdown file with JIRA and the state file and print the tickets
+and tasks push would create, update or skip, with a field-level diff of each
+change. Nothing is written to JIRA, the Markdown file or .ticketr.state.`,
+		Args: cobra.ExactArgs(1),
+		Run:  runPlan,
+	}
+)
+
+// runPlan handles the plan command
+func runPlan(cmd *cobra.Command, args []string) {
+	inputFile := args[0]
+
+	if planOutput != "text" && planOutput != "json" {
+		fmt.Printf("Error: unsupported output format %q (use text or json)\n", planOutput)
+		os.Exit(1)
+	}
+
+	if logger != nil {
+		logger.Section("PLAN COMMAND")
+		logger.Info("Input file: %s", inputFile)
+	}
+
+	ctx, cancel := commandContext(cmd)
+	defer cancel()
+
+	repo := fileRepositoryFromConfig()
+
+	// Run the same pre-flight validation push would run
+	validator, err := validatorFromConfig()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	validationErrors, validationWarnings, err := preflightFiles(ctx, repo, validator, []string{inputFile})
+	if err != nil {
+		fmt.Printf("Error reading tickets from file: %v\n", err)
+		os.Exit(1)
+	}
+	printValidationWarnings(validationWarnings)
+	if problemCount(validationErrors) > 0 {
+		fmt.Println("Validation errors found (push would stop here):")
+		printProblems(os.Stdout, validationErrors)
+		os.Exit(1)
+	}
+
+	jiraAdapter, err := jira.NewJiraAdapterWithOptions(jiraOptionsFromConfig())
+	if err != nil {
+		fmt.Printf("Error initializing Jira adapter: %v\n", err)
+		os.Exit(1)
+	}
+
+	stateManager := state.NewStateManager(".ticketr.state")
+	service := services.NewPushService(repo, jiraAdapter, stateManager)
+
+	plan, err := service.Plan(ctx, inputFile)
+	if err != nil {
+		fmt.Printf("Error computing plan: %v\n", err)
+		os.Exit(1)
+	}
+
+	if planOutput == "json" {
+		err = writePlanJSON(os.Stdout, plan)
+	} else {
+		err = writePlanText(os.Stdout, plan)
+	}
+	if err != nil {
+		fmt.Printf("Error writing plan: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+// writePlanJSON writes the plan as indented JSON
+func writePlanJSON(w io.Writer, plan *services.Plan) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(plan)
+}
+
+// writePlanText writes the plan in a Terraform-style human readable format
+func writePlanText(w io.Writer, plan *services.Plan) error {
+	fmt.Fprintf(w, "Ticketr plan for %s:\n\n", plan.File)
+
+	for _, ticket := range plan.Tickets {
+		writePlanItem(w, "ticket", ticket, "  ")
+		for _, task := range ticket.Tasks {
+			writePlanItem(w, "task", task, "      ")
+		}
+	}
+
+	if len(plan.Tickets) == 0 {
+		fmt.Fprintln(w, "  No tickets found.")
+	}
+
+	summary := fmt.Sprintf("Plan: %d to create, %d to update, %d unchanged",
+		plan.Summary.Create, plan.Summary.Update, plan.Summary.Skip)
+	if plan.Summary.Transitions > 0 {
+		summary += fmt.Sprintf(", %d status change(s)", plan.Summary.Transitions)
+	}
+	if plan.Summary.Links > 0 {
+		summary += fmt.Sprintf(", %d link(s) to create", plan.Summary.Links)
+	}
+	if plan.Summary.Comments > 0 {
+		summary += fmt.Sprintf(", %d comment(s) to post", plan.Summary.Comments)
+	}
+	_, err := fmt.Fprintf(w, "\n%s.\n", summary)
+	return err
+}
+
+// writePlanItem writes a single ticket or task line followed by its field
+// changes, status change, links and comments
+func writePlanItem(w io.Writer, kind string, item services.PlanItem, indent string) {
+	symbol := map[services.PlanAction]string{
+		services.PlanCreate: "+",
+		services.PlanUpdate: "~",
+		services.PlanSkip:   "=",
+	}[item.Action]
+
+	name := fmt.Sprintf("%q", item.Title)
+	if item.JiraID != "" {
+		name = fmt.Sprintf("[%s] %s", item.JiraID, name)
+	}
+
+	suffix := ""
+	if item.Line > 0 {
+		suffix = fmt.Sprintf(" (line %d)", item.Line)
+	}
+	switch {
+	case item.Action == services.PlanSkip:
+		suffix += " - unchanged since last push"
+	case item.Action == services.PlanUpdate && len(item.Changes) == 0:
+		suffix += " - no field changes"
+	}
+
+	fmt.Fprintf(w, "%s%s %s %s %s%s\n", indent, symbol, item.Action, kind, name, suffix)
+	for _, change := range item.Changes {
+		fmt.Fprintf(w, "%s    %s\n", indent, formatFieldChange(change))
+	}
+	if t := item.Transition; t != nil {
+		if len(t.Path) > 0 {
+			fmt.Fprintf(w, "%s    > transition: %s\n", indent, strings.Join(t.Path, " -> "))
+		} else {
+			fmt.Fprintf(w, "%s    > transition: to %s (path known once created)\n", indent, t.To)
+		}
+	}
+	for _, link := range item.Links {
+		if link.Key != "" {
+			fmt.Fprintf(w, "%s    + link: %s %s\n", indent, link.Type, link.Key)
+		} else {
+			fmt.Fprintf(w, "%s    + link: %s %q (created by this push)\n", indent, link.Type, link.Title)
+		}
+	}
+	for _, comment := range item.Comments {
+		fmt.Fprintf(w, "%s    + comment: %q\n", indent, comment)
+	}
+}
+
+// formatFieldChange renders a field change as an added, removed or modified value
+func formatFieldChange(change domain.FieldChange) string {
+	switch {
+	case change.Current == "":
+		return fmt.Sprintf("+ %s: %q", change.Field, change.Proposed)
+	case change.Proposed == "":
+		return fmt.Sprintf("- %s: %q", change.Field, change.Current)
+	default:
+		return fmt.Sprintf("~ %s: %q => %q", change.Field, change.Current, change.Proposed)
+	}
+}
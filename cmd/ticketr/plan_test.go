@@ -0,0 +1,88 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+	"github.com/karolswdev/ticktr/internal/core/services"
+)
+
+func samplePlan() *services.Plan {
+	return &services.Plan{
+		File: "backlog.md",
+		Tickets: []services.PlanItem{
+			{
+				Action: services.PlanUpdate,
+				Title:  "Payments",
+				JiraID: "PROJ-1",
+				Line:   1,
+				Changes: []domain.FieldChange{
+					{Field: "Priority (priority)", Current: "Low", Proposed: "High"},
+				},
+				Transition: &services.PlanTransition{To: "Done", Path: []string{"In Review", "Done"}},
+				Links:      []services.PlanLink{{Type: "blocks", Key: "PROJ-9"}, {Type: "relates to", Title: "Checkout"}},
+				Comments:   []string{"Ready for review"},
+				Tasks: []services.PlanItem{
+					{Action: services.PlanCreate, Title: "Write docs", ParentID: "PROJ-1", Line: 12,
+						Changes:    []domain.FieldChange{{Field: "summary", Proposed: "Write docs"}},
+						Transition: &services.PlanTransition{To: "In Progress"}},
+				},
+			},
+			{Action: services.PlanSkip, Title: "Old", JiraID: "PROJ-2", Line: 20},
+		},
+		Summary: services.PlanSummary{Create: 1, Update: 1, Skip: 1, Transitions: 2, Links: 2, Comments: 1},
+	}
+}
+
+// TestWritePlanText verifies the human readable plan output
+func TestWritePlanText(t *testing.T) {
+	var out bytes.Buffer
+	if err := writePlanText(&out, samplePlan()); err != nil {
+		t.Fatalf("writePlanText returned error: %v", err)
+	}
+
+	expected := []string{
+		`~ update ticket [PROJ-1] "Payments" (line 1)`,
+		`~ Priority (priority): "Low" => "High"`,
+		`+ create task "Write docs" (line 12)`,
+		`+ summary: "Write docs"`,
+		`> transition: In Review -> Done`,
+		`+ link: blocks PROJ-9`,
+		`+ link: relates to "Checkout" (created by this push)`,
+		`+ comment: "Ready for review"`,
+		`> transition: to In Progress (path known once created)`,
+		`= skip ticket [PROJ-2] "Old" (line 20) - unchanged since last push`,
+		`Plan: 1 to create, 1 to update, 1 unchanged, 2 status change(s), 2 link(s) to create, 1 comment(s) to post.`,
+	}
+	for _, line := range expected {
+		if !strings.Contains(out.String(), line) {
+			t.Errorf("Expected plan output to contain %q, got:\n%s", line, out.String())
+		}
+	}
+}
+
+// TestWritePlanJSON verifies the plan round-trips through JSON for CI consumers
+func TestWritePlanJSON(t *testing.T) {
+	var out bytes.Buffer
+	if err := writePlanJSON(&out, samplePlan()); err != nil {
+		t.Fatalf("writePlanJSON returned error: %v", err)
+	}
+
+	var decoded services.Plan
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil {
+		t.Fatalf("Plan JSON is not valid: %v", err)
+	}
+
+	if decoded.Summary.Create != 1 || len(decoded.Tickets) != 2 {
+		t.Errorf("Unexpected decoded plan: %+v", decoded)
+	}
+	if decoded.Tickets[0].Transition.To != "Done" || decoded.Tickets[0].Links[1].Title != "Checkout" || decoded.Summary.Comments != 1 {
+		t.Errorf("Expected transition, links and comments to survive JSON encoding, got %+v", decoded.Tickets[0])
+	}
+	if decoded.Tickets[0].Changes[0].Current != "Low" {
+		t.Errorf("Expected field change to survive JSON encoding, got %+v", decoded.Tickets[0].Changes)
+	}
+}
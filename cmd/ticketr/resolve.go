@@ -0,0 +1,110 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/karolswdev/ticktr/internal/adapters/filesystem"
+	"github.com/karolswdev/ticktr/internal/state"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Resolve command flags
+	resolveTickets []string
+
+	resolveCmd = &cobra.Command{
+		Use:   "resolve [file|dir|glob...]",
+		Short: "Mark pull conflicts as resolved",
+		Long: `Mark the conflicts a pull left in Markdown files as resolved.
+
+Once you have settled each conflict in the file, keeping the version you want
+and deleting any <<<<<<< local / ======= / >>>>>>> jira markers, resolve
+records the Jira version the ticket conflicted with as its last synced
+version. The next pull then only brings newer Jira changes, and the next push
+sends the resolved ticket.
+
+Every conflicted ticket in the files is resolved, or only those named with
+--ticket. Files that still hold markers are refused.`,
+		Args: cobra.MinimumNArgs(1),
+		Run:  runResolve,
+	}
+)
+
+// runResolve handles the resolve command
+func runResolve(cmd *cobra.Command, args []string) {
+	ctx, cancel := commandContext(cmd)
+	defer cancel()
+
+	files, err := filesystem.ExpandPaths(args)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	stateManager := state.NewStateManager(".ticketr.state")
+	defer lockState(ctx, stateManager)()
+	resolved, err := resolveConflicts(ctx, fileRepositoryFromConfig(), stateManager, os.Stdout, files, resolveTickets)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+	if resolved == 0 {
+		fmt.Println("No conflicts to resolve")
+		return
+	}
+	fmt.Println("\nPush to send the resolved tickets to JIRA")
+}
+
+// resolveConflicts marks the conflicted tickets in files as resolved, or only
+// those in keys when it is not empty, and saves the state. It fails without
+// resolving anything when a file cannot be read, which includes files still
+// holding conflict markers, or when a key has no conflict in the files.
+func resolveConflicts(ctx context.Context, repo *filesystem.FileRepository, stateManager *state.StateManager, w io.Writer, files []string, keys []string) (int, error) {
+	if err := stateManager.Load(); err != nil {
+		return 0, fmt.Errorf("failed to load state: %w", err)
+	}
+	conflicted := stateManager.Conflicts()
+
+	type conflict struct{ key, file string }
+	var found []conflict
+	for _, file := range files {
+		tickets, err := repo.GetTickets(ctx, file)
+		if err != nil {
+			return 0, fmt.Errorf("%s: %w", file, err)
+		}
+		for _, ticket := range tickets {
+			if !slices.Contains(conflicted, ticket.JiraID) {
+				continue
+			}
+			if len(keys) == 0 || slices.Contains(keys, ticket.JiraID) {
+				found = append(found, conflict{key: ticket.JiraID, file: file})
+			}
+		}
+	}
+
+	var missing []string
+	for _, key := range keys {
+		if !slices.ContainsFunc(found, func(c conflict) bool { return c.key == key }) {
+			missing = append(missing, key)
+		}
+	}
+	if len(missing) > 0 {
+		return 0, fmt.Errorf("no conflict to resolve for %s in %s", strings.Join(missing, ", "), strings.Join(files, ", "))
+	}
+
+	for _, c := range found {
+		stateManager.Resolve(c.key)
+		fmt.Fprintf(w, "Resolved %s (%s)\n", c.key, c.file)
+	}
+	if len(found) > 0 {
+		if err := stateManager.Save(); err != nil {
+			return 0, fmt.Errorf("failed to save state: %w", err)
+		}
+	}
+	return len(found), nil
+}
@@ -0,0 +1,62 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/karolswdev/ticktr/internal/adapters/filesystem"
+	"github.com/karolswdev/ticktr/internal/core/domain"
+	"github.com/karolswdev/ticktr/internal/state"
+)
+
+// TestResolveConflicts verifies resolve refuses marked files and moves the state to Jira's version
+func TestResolveConflicts(t *testing.T) {
+	dir := t.TempDir()
+	file := filepath.Join(dir, "backlog.md")
+	stateFile := filepath.Join(dir, ".ticketr.state")
+
+	local := domain.Ticket{JiraID: "PROJ-1", Title: "Login"}
+	remote := domain.Ticket{JiraID: "PROJ-1", Title: "Sign in"}
+	stateManager := state.NewStateManager(stateFile)
+	stateManager.UpdateHash(local)
+	stateManager.MarkConflict(remote)
+	if err := stateManager.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	os.WriteFile(file, []byte("<<<<<<< local\n# TICKET: [PROJ-1] Login\n=======\n# TICKET: [PROJ-1] Sign in\n>>>>>>> jira\n"), 0644)
+	repo := filesystem.NewFileRepository()
+	var out bytes.Buffer
+	if _, err := resolveConflicts(context.Background(), repo, state.NewStateManager(stateFile), &out, []string{file}, nil); err == nil || !strings.Contains(err.Error(), "Unresolved conflict marker") {
+		t.Fatalf("Expected the markers to be refused, got %v", err)
+	}
+
+	os.WriteFile(file, []byte("# TICKET: [PROJ-1] Sign in to the app\n"), 0644)
+	if _, err := resolveConflicts(context.Background(), repo, state.NewStateManager(stateFile), &out, []string{file}, []string{"PROJ-9"}); err == nil || !strings.Contains(err.Error(), "PROJ-9") {
+		t.Fatalf("Expected an error for a key without a conflict, got %v", err)
+	}
+
+	resolved, err := resolveConflicts(context.Background(), repo, state.NewStateManager(stateFile), &out, []string{file}, nil)
+	if err != nil {
+		t.Fatalf("resolveConflicts returned error: %v", err)
+	}
+	if resolved != 1 || out.String() != "Resolved PROJ-1 ("+file+")\n" {
+		t.Errorf("Expected PROJ-1 to be resolved, got %d:\n%s", resolved, out.String())
+	}
+
+	reloaded := state.NewStateManager(stateFile)
+	if err := reloaded.Load(); err != nil {
+		t.Fatal(err)
+	}
+	stored, _ := reloaded.GetStoredState("PROJ-1")
+	if stored.Conflict != nil || stored.RemoteHash != reloaded.CalculateHash(remote) || stored.Base.Title != "Sign in" {
+		t.Errorf("Expected Jira's version as the synced one, got %+v", stored)
+	}
+	if stored.LocalHash != reloaded.CalculateHash(local) {
+		t.Error("Expected the local hash to be kept so push sends the resolved ticket")
+	}
+}
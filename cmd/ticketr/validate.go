@@ -0,0 +1,478 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/karolswdev/ticktr/internal/adapters/filesystem"
+	"github.com/karolswdev/ticktr/internal/adapters/jira"
+	"github.com/karolswdev/ticktr/internal/core/domain"
+	"github.com/karolswdev/ticktr/internal/core/ports"
+	"github.com/karolswdev/ticktr/internal/core/validation"
+	"github.com/karolswdev/ticktr/internal/parser"
+	"github.com/spf13/cobra"
+)
+
+var (
+	// Validate command flags
+	validateJira   bool
+	validateOutput string
+
+	validateCmd = &cobra.Command{
+		Use:   "validate [file|dir|glob...]",
+		Short: "Check Markdown files for problems push would run into",
+		Long: `Check tickets and tasks without sending anything to JIRA: Markdown the
+parser cannot read or ignores (misspelt sections, field lines without a colon,
+stray lines under tasks), titles, the task hierarchy, fields that
+field_mappings does not map, and number fields. Directories stand for the
+*.md files below them and globs may use ** (backlog/**/*.md); a Jira key used
+by two tickets or tasks, in one file or across files, is reported too.
+
+With --jira, the create metadata of the issue types in use is also fetched
+(read-only) to check that the issue types exist, required fields are set and
+option fields such as Priority and Components hold allowed values.
+
+The hierarchy, required fields, field rules and severities in the validation
+block of .ticketr.yaml apply, as they do for push. Problems are reported as
+file:line with the ID of the rule that found them, and the command exits 1
+when there are errors; warnings alone do not fail it. Rules can be disabled
+by ID with validation.disable.
+
+--output json prints the problems as JSON and --output sarif as a SARIF 2.1.0
+log for GitHub code scanning and other tools that annotate source lines.`,
+		Args: cobra.MinimumNArgs(1),
+		Run:  runValidate,
+	}
+)
+
+// runValidate handles the validate command
+func runValidate(cmd *cobra.Command, args []string) {
+	if validateOutput != "text" && validateOutput != "json" && validateOutput != "sarif" {
+		fmt.Printf("Error: unsupported output format %q (use text, json or sarif)\n", validateOutput)
+		os.Exit(1)
+	}
+
+	files, err := filesystem.ExpandPaths(args)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	ctx, cancel := commandContext(cmd)
+	defer cancel()
+
+	opts := jiraOptionsFromConfig()
+	var jiraClient ports.JiraPort
+	if validateJira {
+		if jiraClient, err = jira.NewJiraAdapterWithOptions(opts); err != nil {
+			fmt.Printf("Error initializing JIRA adapter: %v\n", err)
+			os.Exit(1)
+		}
+	}
+
+	validator, err := validatorFromConfig()
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	reports, err := validateFiles(ctx, validator, fileRepositoryFromConfig(), files, validationFieldMappings(opts), jiraClient)
+	if err != nil {
+		fmt.Printf("Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	switch validateOutput {
+	case "json":
+		err = writeValidationJSON(os.Stdout, reports)
+	case "sarif":
+		err = writeValidationSARIF(os.Stdout, reports)
+	default:
+		writeValidationErrors(os.Stdout, reports)
+	}
+	if err != nil {
+		fmt.Printf("Error writing validation output: %v\n", err)
+		os.Exit(1)
+	}
+	if hasErrors(reports) {
+		os.Exit(1)
+	}
+}
+
+// hasErrors reports whether any file has a problem that is not a warning,
+// which makes validate exit 1
+func hasErrors(reports []fileProblems) bool {
+	for _, report := range reports {
+		if errors, _ := validation.SplitBySeverity(report.Problems); len(errors) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// fileProblems are the problems found in one file
+type fileProblems struct {
+	File     string
+	Problems []validation.ValidationError
+}
+
+// validateFiles reads and validates each file, then reports Jira keys that
+// are used more than once across them. With jiraClient set, the create
+// metadata of every issue type in use is fetched once for all files.
+func validateFiles(ctx context.Context, validator *validation.Validator, repo *filesystem.FileRepository, files []string, fieldMappings map[string]interface{}, jiraClient ports.JiraPort) ([]fileProblems, error) {
+	parsed := make([]validation.FileTickets, len(files))
+	diagnostics := make([]parser.Diagnostics, len(files))
+	var all []domain.Ticket
+	for i, file := range files {
+		tickets, fileDiagnostics, err := repo.GetTicketsWithDiagnostics(ctx, file)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read tickets from %s: %w", file, err)
+		}
+		parsed[i] = validation.FileTickets{File: file, Tickets: tickets}
+		diagnostics[i] = fileDiagnostics
+		all = append(all, tickets...)
+	}
+
+	var schema *validation.Schema
+	if jiraClient != nil {
+		fetched, err := fetchSchema(ctx, jiraClient, all)
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch JIRA metadata: %w", err)
+		}
+		schema = &fetched
+	}
+
+	duplicates := validator.ValidateKeys(parsed)
+	reports := make([]fileProblems, len(files))
+	for i, file := range parsed {
+		problems := validateTickets(validator, file.Tickets, diagnostics[i], fieldMappings, schema)
+		problems = append(problems, duplicates[file.File]...)
+		sort.SliceStable(problems, func(a, b int) bool { return problems[a].Line < problems[b].Line })
+		reports[i] = fileProblems{File: file.File, Problems: problems}
+	}
+	return reports, nil
+}
+
+// validationFieldMappings returns the field names push sends to Jira
+func validationFieldMappings(opts jira.Options) map[string]interface{} {
+	mappings := make(map[string]interface{})
+	for name, mapping := range jira.FieldMappings(opts) {
+		mappings[name] = mapping
+	}
+	if _, mapped := mappings[jira.EpicLinkFieldName]; !mapped && opts.Deployment != jira.DeploymentServer {
+		// Cloud sends the epic as the parent field
+		mappings[jira.EpicLinkFieldName] = "parent"
+	}
+	return mappings
+}
+
+// validateTickets reports the parser's diagnostics and runs the offline
+// checks, including the configured rules and, when schema is set, the checks
+// against the create metadata of the issue types the tickets use. The
+// problems are sorted by line.
+func validateTickets(validator *validation.Validator, tickets []domain.Ticket, diagnostics parser.Diagnostics, fieldMappings map[string]interface{}, schema *validation.Schema) []validation.ValidationError {
+	problems := syntaxProblems(validator, diagnostics)
+	problems = append(problems, validator.ValidateTickets(tickets)...)
+	problems = append(problems, validator.ValidateFields(tickets, fieldMappings)...)
+	if schema != nil {
+		problems = append(problems, validator.ValidateSchema(tickets, fieldMappings, *schema)...)
+	}
+
+	sort.SliceStable(problems, func(i, j int) bool { return problems[i].Line < problems[j].Line })
+	return problems
+}
+
+// syntaxProblems converts parser diagnostics into problems of the syntax
+// rule. Warnings stay warnings unless the rule's configuration sets a
+// severity, and can be disabled; errors, which stop push, are always
+// reported.
+func syntaxProblems(validator *validation.Validator, diagnostics parser.Diagnostics) []validation.ValidationError {
+	var problems, warnings []validation.ValidationError
+	for _, diagnostic := range diagnostics {
+		message := diagnostic.Message
+		if diagnostic.Suggestion != "" {
+			message += " (" + diagnostic.Suggestion + ")"
+		}
+		problem := validation.ValidationError{
+			Field:   "Markdown",
+			Message: message,
+			Line:    diagnostic.Line,
+			Column:  diagnostic.Column,
+			Rule:    validation.RuleSyntax,
+		}
+		if diagnostic.Severity == parser.SeverityError {
+			problem.Severity = validation.SeverityError
+			problems = append(problems, problem)
+		} else {
+			problem.Severity = validation.SeverityWarning
+			warnings = append(warnings, problem)
+		}
+	}
+	return append(problems, validator.Apply(warnings)...)
+}
+
+// fetchSchema fetches the create metadata of the issue types tickets would be
+// created as. Issue types the project does not have are left out.
+func fetchSchema(ctx context.Context, jiraClient ports.JiraPort, tickets []domain.Ticket) (validation.Schema, error) {
+	schema := validation.Schema{IssueTypes: make(map[string][]validation.FieldSpec)}
+	schema.TicketType, schema.TaskType = jira.IssueTypes()
+
+	project, err := jiraClient.GetProjectIssueTypes(ctx)
+	if err != nil {
+		return schema, err
+	}
+	exists := make(map[string]bool)
+	for _, name := range project["issueTypes"] {
+		exists[strings.TrimSuffix(name, " (subtask)")] = true
+	}
+
+	used := make(map[string]bool)
+	for _, ticket := range tickets {
+		issueType := ticket.CustomFields["Type"]
+		if issueType == "" {
+			issueType = schema.TicketType
+		}
+		used[issueType] = true
+		if len(ticket.Tasks) > 0 {
+			used[schema.TaskType] = true
+		}
+	}
+
+	for issueType := range used {
+		if !exists[issueType] {
+			continue
+		}
+		meta, err := jiraClient.GetIssueTypeFields(ctx, issueType)
+		if err != nil {
+			return schema, fmt.Errorf("failed to fetch fields of %s: %w", issueType, err)
+		}
+		schema.IssueTypes[issueType] = validation.FieldSpecsFromCreateMeta(meta)
+	}
+	return schema, nil
+}
+
+// writeValidationErrors prints each problem as file:line with its rule ID, or
+// that a file is valid, followed by the number of problems in all files
+func writeValidationErrors(w io.Writer, reports []fileProblems) {
+	errorCount, warningCount := 0, 0
+	for _, report := range reports {
+		if len(report.Problems) == 0 {
+			fmt.Fprintf(w, "%s: no problems found\n", report.File)
+			continue
+		}
+		for _, problem := range report.Problems {
+			position := problemPosition(report.File, problem)
+			if problem.IsWarning() {
+				fmt.Fprintf(w, "%s: warning: %s: %s [%s]\n", position, problem.Field, problem.Message, problem.Rule)
+			} else {
+				fmt.Fprintf(w, "%s: %s: %s [%s]\n", position, problem.Field, problem.Message, problem.Rule)
+			}
+		}
+		errors, warnings := validation.SplitBySeverity(report.Problems)
+		errorCount += len(errors)
+		warningCount += len(warnings)
+	}
+	if errorCount+warningCount == 0 {
+		return
+	}
+	fmt.Fprintf(w, "\n%d error(s), %d warning(s) found\n", errorCount, warningCount)
+}
+
+// printValidationWarnings prints warnings to stderr, which do not stop push
+func printValidationWarnings(warnings []fileProblems) {
+	if problemCount(warnings) == 0 {
+		return
+	}
+	fmt.Fprintln(os.Stderr, "Validation warnings:")
+	printProblems(os.Stderr, warnings)
+}
+
+// printProblems lists problems, each prefixed with its file:line:column
+func printProblems(w io.Writer, reports []fileProblems) {
+	for _, report := range reports {
+		for _, problem := range report.Problems {
+			fmt.Fprintf(w, "  - %s: %s: %s\n", problemPosition(report.File, problem), problem.Field, problem.Message)
+		}
+	}
+}
+
+// problemPosition returns file:line:column for a problem, leaving out what it
+// does not have
+func problemPosition(file string, problem validation.ValidationError) string {
+	position := file
+	if problem.Line > 0 {
+		position += fmt.Sprintf(":%d", problem.Line)
+		if problem.Column > 0 {
+			position += fmt.Sprintf(":%d", problem.Column)
+		}
+	}
+	return position
+}
+
+// problemCount returns the number of problems in all files
+func problemCount(reports []fileProblems) int {
+	count := 0
+	for _, report := range reports {
+		count += len(report.Problems)
+	}
+	return count
+}
+
+// validationProblem is a problem in the JSON output of validate
+type validationProblem struct {
+	Rule     string `json:"rule"`
+	Severity string `json:"severity"`
+	Field    string `json:"field"`
+	Message  string `json:"message"`
+	Line     int    `json:"line,omitempty"`
+	Column   int    `json:"column,omitempty"`
+}
+
+// validationFile is the problems of one file in the JSON output of validate
+type validationFile struct {
+	File     string              `json:"file"`
+	Problems []validationProblem `json:"problems"`
+}
+
+// validationReport is the JSON output of validate
+type validationReport struct {
+	Files   []validationFile `json:"files"`
+	Summary struct {
+		Errors   int `json:"errors"`
+		Warnings int `json:"warnings"`
+	} `json:"summary"`
+}
+
+// writeValidationJSON writes the problems of each file as indented JSON
+func writeValidationJSON(w io.Writer, reports []fileProblems) error {
+	report := validationReport{Files: []validationFile{}}
+	for _, fileReport := range reports {
+		file := validationFile{File: fileReport.File, Problems: []validationProblem{}}
+		for _, problem := range fileReport.Problems {
+			file.Problems = append(file.Problems, validationProblem{
+				Rule:     problem.Rule,
+				Severity: string(severityOf(problem)),
+				Field:    problem.Field,
+				Message:  problem.Message,
+				Line:     problem.Line,
+				Column:   problem.Column,
+			})
+		}
+		report.Files = append(report.Files, file)
+
+		errors, warnings := validation.SplitBySeverity(fileReport.Problems)
+		report.Summary.Errors += len(errors)
+		report.Summary.Warnings += len(warnings)
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(report)
+}
+
+// SARIF 2.1.0 log, reduced to what validate reports
+type (
+	sarifLog struct {
+		Schema  string     `json:"$schema"`
+		Version string     `json:"version"`
+		Runs    []sarifRun `json:"runs"`
+	}
+	sarifRun struct {
+		Tool    sarifTool     `json:"tool"`
+		Results []sarifResult `json:"results"`
+	}
+	sarifTool struct {
+		Driver sarifDriver `json:"driver"`
+	}
+	sarifDriver struct {
+		Name           string      `json:"name"`
+		InformationURI string      `json:"informationUri"`
+		Rules          []sarifRule `json:"rules"`
+	}
+	sarifRule struct {
+		ID               string       `json:"id"`
+		ShortDescription sarifMessage `json:"shortDescription"`
+	}
+	sarifResult struct {
+		RuleID    string          `json:"ruleId"`
+		RuleIndex int             `json:"ruleIndex"`
+		Level     string          `json:"level"`
+		Message   sarifMessage    `json:"message"`
+		Locations []sarifLocation `json:"locations"`
+	}
+	sarifMessage struct {
+		Text string `json:"text"`
+	}
+	sarifLocation struct {
+		PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+	}
+	sarifPhysicalLocation struct {
+		ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+		Region           *sarifRegion          `json:"region,omitempty"`
+	}
+	sarifArtifactLocation struct {
+		URI string `json:"uri"`
+	}
+	sarifRegion struct {
+		StartLine   int `json:"startLine"`
+		StartColumn int `json:"startColumn,omitempty"`
+	}
+)
+
+// writeValidationSARIF writes the problems as a SARIF log with one result per
+// problem, located at its line of its Markdown file
+func writeValidationSARIF(w io.Writer, reports []fileProblems) error {
+	driver := sarifDriver{
+		Name:           "ticketr",
+		InformationURI: "https://github.com/karolswdev/ticktr",
+	}
+	var ids []string
+	for id := range validation.RuleDescriptions {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	ruleIndex := make(map[string]int)
+	for _, id := range ids {
+		ruleIndex[id] = len(driver.Rules)
+		driver.Rules = append(driver.Rules, sarifRule{ID: id, ShortDescription: sarifMessage{Text: validation.RuleDescriptions[id]}})
+	}
+
+	run := sarifRun{Tool: sarifTool{Driver: driver}, Results: []sarifResult{}}
+	for _, report := range reports {
+		for _, problem := range report.Problems {
+			location := sarifPhysicalLocation{ArtifactLocation: sarifArtifactLocation{URI: filepath.ToSlash(report.File)}}
+			if problem.Line > 0 {
+				location.Region = &sarifRegion{StartLine: problem.Line, StartColumn: problem.Column}
+			}
+			run.Results = append(run.Results, sarifResult{
+				RuleID:    problem.Rule,
+				RuleIndex: ruleIndex[problem.Rule],
+				Level:     string(severityOf(problem)),
+				Message:   sarifMessage{Text: fmt.Sprintf("%s: %s", problem.Field, problem.Message)},
+				Locations: []sarifLocation{{PhysicalLocation: location}},
+			})
+		}
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(sarifLog{
+		Schema:  "https://json.schemastore.org/sarif-2.1.0.json",
+		Version: "2.1.0",
+		Runs:    []sarifRun{run},
+	})
+}
+
+// severityOf returns a problem's severity, error when it has none
+func severityOf(problem validation.ValidationError) validation.Severity {
+	if problem.IsWarning() {
+		return validation.SeverityWarning
+	}
+	return validation.SeverityError
+}
@@ -0,0 +1,284 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+	"github.com/karolswdev/ticktr/internal/core/validation"
+	"github.com/karolswdev/ticktr/internal/parser"
+	"github.com/spf13/viper"
+)
+
+// MockJiraPortMetadata serves create metadata and fails on any other call
+type MockJiraPortMetadata struct {
+	*MockJiraPortNeverCalled
+	fetched []string
+}
+
+func (m *MockJiraPortMetadata) GetProjectIssueTypes(ctx context.Context) (map[string][]string, error) {
+	return map[string][]string{"issueTypes": {"Story", "Task", "Sub-task (subtask)"}}, nil
+}
+
+func (m *MockJiraPortMetadata) GetIssueTypeFields(ctx context.Context, issueTypeName string) (map[string]interface{}, error) {
+	m.fetched = append(m.fetched, issueTypeName)
+	return map[string]interface{}{
+		"issueType": issueTypeName,
+		"fields": []map[string]interface{}{
+			{"key": "summary", "name": "Summary", "required": true},
+			{"key": "priority", "name": "Priority", "type": "priority", "allowedValues": []string{"High", "Low"}},
+		},
+	}, nil
+}
+
+// TestValidateTickets_WithJira verifies offline and schema problems are merged and sorted by line
+func TestValidateTickets_WithJira(t *testing.T) {
+	tickets := []domain.Ticket{
+		{Title: "First", CustomFields: map[string]string{"Type": "Story", "Priority": "Urgent"}, SourceLine: 1},
+		{Title: "Second", CustomFields: map[string]string{"Type": "Epic", "Colour": "Red"}, SourceLine: 8},
+	}
+	fieldMappings := map[string]interface{}{"Type": "issuetype", "Priority": "priority"}
+	client := &MockJiraPortMetadata{MockJiraPortNeverCalled: &MockJiraPortNeverCalled{t: t}}
+
+	schema, err := fetchSchema(context.Background(), client, tickets)
+	if err != nil {
+		t.Fatalf("fetchSchema returned error: %v", err)
+	}
+	problems := validateTickets(validation.NewValidator(), tickets, nil, fieldMappings, &schema)
+
+	if len(client.fetched) != 1 || client.fetched[0] != "Story" {
+		t.Errorf("Expected only the Story metadata to be fetched, got %v", client.fetched)
+	}
+	want := []string{"Priority", "Colour", "Type"}
+	if len(problems) != len(want) {
+		t.Fatalf("Expected %d problems, got %d: %v", len(want), len(problems), problems)
+	}
+	for i, field := range want {
+		if problems[i].Field != field {
+			t.Errorf("Problem %d: expected %s, got %+v", i, field, problems[i])
+		}
+	}
+}
+
+// TestWriteValidationErrors verifies problems are printed as file:line
+func TestWriteValidationErrors(t *testing.T) {
+	var out bytes.Buffer
+	writeValidationErrors(&out, []fileProblems{{File: "backlog.md", Problems: []validation.ValidationError{
+		{Field: "Priority", Message: "'Urgent' is not an allowed value (High, Low)", Line: 4, Rule: validation.RuleJiraAllowedValue},
+		{Field: "Sprnt", Message: "Unknown field", Line: 9, Rule: validation.RuleUnknownField, Severity: validation.SeverityWarning},
+	}}})
+
+	want := "backlog.md:4: Priority: 'Urgent' is not an allowed value (High, Low) [jira_allowed_value]\n" +
+		"backlog.md:9: warning: Sprnt: Unknown field [unknown_field]\n" +
+		"\n1 error(s), 1 warning(s) found\n"
+	if out.String() != want {
+		t.Errorf("Expected:\n%s\nGot:\n%s", want, out.String())
+	}
+
+	out.Reset()
+	writeValidationErrors(&out, []fileProblems{{File: "backlog.md"}})
+	if out.String() != "backlog.md: no problems found\n" {
+		t.Errorf("Expected the no problems line, got %q", out.String())
+	}
+}
+
+// TestValidatorFromConfig verifies the validation block of .ticketr.yaml is applied
+func TestValidatorFromConfig(t *testing.T) {
+	config := `
+validation:
+  hierarchy:
+    Initiative: [Epic]
+    Epic: [Story]
+  required_fields:
+    Story: [Team]
+  fields:
+    - field: Title
+      pattern: '^\[[A-Z]+\] '
+      severity: warning
+  severity:
+    required_fields: warning
+`
+	viper.SetConfigType("yaml")
+	if err := viper.ReadConfig(strings.NewReader(config)); err != nil {
+		t.Fatalf("Failed to read config: %v", err)
+	}
+	t.Cleanup(viper.Reset)
+
+	validator, err := validatorFromConfig()
+	if err != nil {
+		t.Fatalf("validatorFromConfig returned error: %v", err)
+	}
+
+	tickets := []domain.Ticket{{
+		Title:        "Platform",
+		CustomFields: map[string]string{"Type": "Story"},
+		SourceLine:   1,
+		Tasks:        []domain.Task{{Title: "[WEB] Login", CustomFields: map[string]string{"Type": "Sub-task"}, SourceLine: 5}},
+	}}
+	problems := validator.ValidateTickets(tickets)
+
+	errors, warnings := validation.SplitBySeverity(problems)
+	if len(errors) != 0 {
+		t.Errorf("Expected no errors, got %v", errors)
+	}
+	if len(warnings) != 2 || warnings[0].Field != "Team" || warnings[1].Field != "Title" {
+		t.Errorf("Expected the Team and Title warnings, got %v", warnings)
+	}
+}
+
+// TestWriteValidationJSON verifies the JSON output carries rule IDs, severities and lines
+func TestWriteValidationJSON(t *testing.T) {
+	var out bytes.Buffer
+	err := writeValidationJSON(&out, []fileProblems{
+		{File: "backlog.md", Problems: []validation.ValidationError{
+			{Field: "Type", Message: "A 'Story' cannot be the child of a 'Task'", Line: 12, Rule: validation.RuleHierarchy, Severity: validation.SeverityError},
+			{Field: "Title", Message: "Too long", Line: 3, Rule: validation.RuleMaxLength, Severity: validation.SeverityWarning},
+		}},
+		{File: "done.md"},
+	})
+	if err != nil {
+		t.Fatalf("writeValidationJSON returned error: %v", err)
+	}
+
+	var report validationReport
+	if err := json.Unmarshal(out.Bytes(), &report); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, out.String())
+	}
+	if len(report.Files) != 2 || report.Files[0].File != "backlog.md" || len(report.Files[0].Problems) != 2 {
+		t.Fatalf("Unexpected report: %+v", report)
+	}
+	if report.Files[1].Problems == nil {
+		t.Error("Expected an empty problems list for a valid file")
+	}
+	if got := report.Files[0].Problems[0]; got.Rule != "hierarchy" || got.Severity != "error" || got.Line != 12 {
+		t.Errorf("Unexpected first problem: %+v", got)
+	}
+	if report.Summary.Errors != 1 || report.Summary.Warnings != 1 {
+		t.Errorf("Unexpected summary: %+v", report.Summary)
+	}
+}
+
+// TestWriteValidationSARIF verifies each problem becomes a SARIF result at its file line
+func TestWriteValidationSARIF(t *testing.T) {
+	var out bytes.Buffer
+	err := writeValidationSARIF(&out, []fileProblems{{File: "docs/backlog.md", Problems: []validation.ValidationError{
+		{Field: "Sprint", Message: "'next' does not match pattern", Line: 7, Rule: validation.RulePattern, Severity: validation.SeverityWarning},
+		{Field: "Title", Message: "Title is required", Rule: validation.RuleTitleRequired},
+	}}})
+	if err != nil {
+		t.Fatalf("writeValidationSARIF returned error: %v", err)
+	}
+
+	var log sarifLog
+	if err := json.Unmarshal(out.Bytes(), &log); err != nil {
+		t.Fatalf("Output is not valid JSON: %v\n%s", err, out.String())
+	}
+	if log.Version != "2.1.0" || len(log.Runs) != 1 {
+		t.Fatalf("Unexpected log: %+v", log)
+	}
+	run := log.Runs[0]
+	if len(run.Tool.Driver.Rules) != len(validation.RuleDescriptions) {
+		t.Errorf("Expected every rule to be described, got %d", len(run.Tool.Driver.Rules))
+	}
+	if len(run.Results) != 2 {
+		t.Fatalf("Expected 2 results, got %d", len(run.Results))
+	}
+
+	first := run.Results[0]
+	if first.RuleID != "pattern" || first.Level != "warning" || run.Tool.Driver.Rules[first.RuleIndex].ID != "pattern" {
+		t.Errorf("Unexpected first result: %+v", first)
+	}
+	location := first.Locations[0].PhysicalLocation
+	if location.ArtifactLocation.URI != "docs/backlog.md" || location.Region == nil || location.Region.StartLine != 7 {
+		t.Errorf("Expected docs/backlog.md line 7, got %+v", location)
+	}
+	if second := run.Results[1]; second.Level != "error" || second.Locations[0].PhysicalLocation.Region != nil {
+		t.Errorf("Expected an error without a region, got %+v", second)
+	}
+}
+
+// TestSyntaxProblems verifies parse warnings follow the syntax rule's configuration while errors are kept
+func TestSyntaxProblems(t *testing.T) {
+	diagnostics := parser.Diagnostics{
+		{Severity: parser.SeverityError, Line: 1, Column: 1, Message: "Ticket heading has no title"},
+		{Severity: parser.SeverityWarning, Line: 4, Column: 3, Message: "Field line has no ':' and is ignored", Suggestion: "write fields as 'Name: value'"},
+	}
+
+	problems := syntaxProblems(validation.NewValidator(), diagnostics)
+	if len(problems) != 2 || problems[1].Column != 3 || problems[1].Message != "Field line has no ':' and is ignored (write fields as 'Name: value')" {
+		t.Fatalf("Unexpected problems: %+v", problems)
+	}
+	if problems[0].IsWarning() || !problems[1].IsWarning() {
+		t.Errorf("Expected the error to stay an error and the warning a warning, got %+v", problems)
+	}
+
+	strict, err := validation.NewValidatorWithRules(validation.Rules{Severity: map[string]validation.Severity{validation.RuleSyntax: validation.SeverityError}})
+	if err != nil {
+		t.Fatalf("NewValidatorWithRules returned error: %v", err)
+	}
+	if problems := syntaxProblems(strict, diagnostics); len(problems) != 2 || problems[1].IsWarning() {
+		t.Errorf("Expected validation.severity.syntax to raise the warning, got %+v", problems)
+	}
+
+	quiet, err := validation.NewValidatorWithRules(validation.Rules{Disabled: []string{validation.RuleSyntax}})
+	if err != nil {
+		t.Fatalf("NewValidatorWithRules returned error: %v", err)
+	}
+	problems = syntaxProblems(quiet, diagnostics)
+	if len(problems) != 1 || problems[0].IsWarning() {
+		t.Errorf("Expected only the error to remain, got %+v", problems)
+	}
+
+	var out bytes.Buffer
+	writeValidationErrors(&out, []fileProblems{{File: "backlog.md", Problems: problems}})
+	if !strings.HasPrefix(out.String(), "backlog.md:1:1: Markdown: Ticket heading has no title [syntax]\n") {
+		t.Errorf("Expected file:line:column output, got:\n%s", out.String())
+	}
+}
+
+// TestValidateFiles_WarningsOnlyExitZero verifies a file push accepts, with
+// only parser warnings, does not fail validate
+func TestValidateFiles_WarningsOnlyExitZero(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backlog.md")
+	os.WriteFile(path, []byte("# TICKET: Login\n\n## Fields\nSprint 24\n\n## Acceptence Criteria\n- Works\n"), 0644)
+
+	reports, err := validateFiles(context.Background(), validation.NewValidator(), fileRepositoryFromConfig(), []string{path}, map[string]interface{}{"Sprint": "customfield_10020"}, nil)
+	if err != nil {
+		t.Fatalf("validateFiles returned error: %v", err)
+	}
+	if problemCount(reports) == 0 {
+		t.Fatal("Expected the parser warnings to be reported")
+	}
+	if hasErrors(reports) {
+		t.Errorf("Expected only warnings, got %+v", reports[0].Problems)
+	}
+}
+
+// TestValidateFiles verifies each file is reported and a Jira key shared by two files is found
+func TestValidateFiles(t *testing.T) {
+	dir := t.TempDir()
+	auth := filepath.Join(dir, "auth.md")
+	billing := filepath.Join(dir, "billing.md")
+	os.WriteFile(auth, []byte("# TICKET: [PROJ-1] Login\n\n# TICKET: Logout\n"), 0644)
+	os.WriteFile(billing, []byte("# TICKET: [PROJ-2] Invoices\n\n# TICKET: [PROJ-1] Login copy\n"), 0644)
+
+	reports, err := validateFiles(context.Background(), validation.NewValidator(), fileRepositoryFromConfig(), []string{auth, billing}, map[string]interface{}{}, nil)
+	if err != nil {
+		t.Fatalf("validateFiles returned error: %v", err)
+	}
+
+	if len(reports) != 2 || reports[0].File != auth || reports[1].File != billing {
+		t.Fatalf("Expected a report per file, got %+v", reports)
+	}
+	if len(reports[0].Problems) != 1 || reports[0].Problems[0].Rule != validation.RuleDuplicateKey || reports[0].Problems[0].Line != 1 {
+		t.Errorf("Expected the shared key on line 1 of auth.md, got %+v", reports[0].Problems)
+	}
+	if len(reports[1].Problems) != 1 || reports[1].Problems[0].Line != 3 {
+		t.Errorf("Expected the shared key on line 3 of billing.md, got %+v", reports[1].Problems)
+	}
+}
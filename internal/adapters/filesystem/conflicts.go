@@ -0,0 +1,94 @@
+package filesystem
+
+import (
+	"github.com/karolswdev/ticktr/internal/parser"
+)
+
+// markConflicts returns ours with each run of lines that differs from theirs
+// wrapped in conflict markers holding both versions. Both are the same file
+// written with different versions of some tickets, so they are compared
+// ticket by ticket.
+func markConflicts(ours, theirs []string) []string {
+	oursBlocks, theirsBlocks := ticketBlocks(ours), ticketBlocks(theirs)
+	if len(oursBlocks) != len(theirsBlocks) {
+		return markDiff(ours, theirs)
+	}
+	var lines []string
+	for i := range oursBlocks {
+		lines = append(lines, markDiff(oursBlocks[i], theirsBlocks[i])...)
+	}
+	return lines
+}
+
+// ticketBlocks splits lines into the lines before the first ticket and one
+// block per ticket
+func ticketBlocks(lines []string) [][]string {
+	blocks := [][]string{nil}
+	for _, line := range lines {
+		if ticketHeadingRegex.MatchString(line) {
+			blocks = append(blocks, nil)
+		}
+		blocks[len(blocks)-1] = append(blocks[len(blocks)-1], line)
+	}
+	return blocks
+}
+
+// markDiff returns ours with the lines that differ from theirs, by longest
+// common subsequence, wrapped in conflict markers
+func markDiff(ours, theirs []string) []string {
+	prefix := 0
+	for prefix < len(ours) && prefix < len(theirs) && ours[prefix] == theirs[prefix] {
+		prefix++
+	}
+	suffix := 0
+	for suffix < len(ours)-prefix && suffix < len(theirs)-prefix && ours[len(ours)-1-suffix] == theirs[len(theirs)-1-suffix] {
+		suffix++
+	}
+	a, b := ours[prefix:len(ours)-suffix], theirs[prefix:len(theirs)-suffix]
+
+	// common[i][j] is the length of the longest common subsequence of a[i:] and b[j:]
+	common := make([][]int, len(a)+1)
+	for i := range common {
+		common[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	lines := append([]string(nil), ours[:prefix]...)
+	var oursHunk, theirsHunk []string
+	flush := func() {
+		if len(oursHunk) == 0 && len(theirsHunk) == 0 {
+			return
+		}
+		lines = append(lines, parser.ConflictStart)
+		lines = append(lines, oursHunk...)
+		lines = append(lines, parser.ConflictSeparator)
+		lines = append(lines, theirsHunk...)
+		lines = append(lines, parser.ConflictEnd)
+		oursHunk, theirsHunk = nil, nil
+	}
+	for i, j := 0, 0; i < len(a) || j < len(b); {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			flush()
+			lines = append(lines, a[i])
+			i++
+			j++
+		case j == len(b) || (i < len(a) && common[i+1][j] >= common[i][j+1]):
+			oursHunk = append(oursHunk, a[i])
+			i++
+		default:
+			theirsHunk = append(theirsHunk, b[j])
+			j++
+		}
+	}
+	flush()
+	return append(lines, ours[len(ours)-suffix:]...)
+}
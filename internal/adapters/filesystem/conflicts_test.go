@@ -0,0 +1,105 @@
+package filesystem
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+)
+
+func TestFileRepository_SaveTicketsWithConflicts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "backlog.md")
+	original := `# TICKET: [PROJ-1] Login
+
+## Description
+Users log in.
+
+## Fields
+Priority: Low
+
+## Tasks
+- [PROJ-2] Form
+
+# TICKET: [PROJ-3] Logout
+
+## Description
+Users log out.
+`
+	if err := os.WriteFile(path, []byte(original), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := NewFileRepository()
+	tickets, err := repo.GetTickets(context.Background(), path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ours := tickets[0]
+	ours.CustomFields = map[string]string{"Priority": "Medium"}
+	ours.Tasks = []domain.Task{{JiraID: "PROJ-2", Title: "Login form"}}
+	theirs := ours
+	theirs.CustomFields = map[string]string{"Priority": "High"}
+	theirs.Tasks = []domain.Task{{JiraID: "PROJ-2", Title: "Sign-in form"}}
+	tickets[0] = ours
+	tickets[1].Description = "Users log out everywhere."
+
+	err = repo.SaveTicketsWithConflicts(context.Background(), path, tickets, map[string]domain.Ticket{"PROJ-1": theirs})
+	if err != nil {
+		t.Fatalf("SaveTicketsWithConflicts failed: %v", err)
+	}
+
+	content, _ := os.ReadFile(path)
+	want := `# TICKET: [PROJ-1] Login
+
+## Description
+Users log in.
+
+## Fields
+<<<<<<< local
+Priority: Medium
+=======
+Priority: High
+>>>>>>> jira
+
+## Tasks
+<<<<<<< local
+- [PROJ-2] Login form
+=======
+- [PROJ-2] Sign-in form
+>>>>>>> jira
+
+# TICKET: [PROJ-3] Logout
+
+## Description
+Users log out everywhere.
+`
+	if string(content) != want {
+		t.Errorf("Unexpected file:\n%s\nwant:\n%s", content, want)
+	}
+
+	if _, err := repo.GetTickets(context.Background(), path); err == nil || !strings.Contains(err.Error(), "Unresolved conflict marker") {
+		t.Errorf("Expected the markers to stop the file being read, got %v", err)
+	}
+}
+
+func TestMarkDiff(t *testing.T) {
+	ours := []string{"a", "b", "c", "d", "e"}
+	theirs := []string{"a", "x", "c", "e", "f"}
+
+	got := strings.Join(markDiff(ours, theirs), "\n")
+
+	want := strings.Join([]string{
+		"a",
+		"<<<<<<< local", "b", "=======", "x", ">>>>>>> jira",
+		"c",
+		"<<<<<<< local", "d", "=======", ">>>>>>> jira",
+		"e",
+		"<<<<<<< local", "=======", "f", ">>>>>>> jira",
+	}, "\n")
+	if got != want {
+		t.Errorf("Unexpected lines:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -1,30 +1,44 @@
 package filesystem
 
 import (
-	"bufio"
+	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	"github.com/karolswdev/ticktr/internal/core/domain"
 	"github.com/karolswdev/ticktr/internal/core/ports"
 	"github.com/karolswdev/ticktr/internal/parser"
+	"github.com/karolswdev/ticktr/internal/renderer"
 )
 
 // FileRepository implements the Repository port for file-based storage
 type FileRepository struct {
-	parser *parser.Parser
+	parser   *parser.Parser
+	renderer *renderer.Renderer
 }
 
 // NewFileRepository creates a new instance of FileRepository
 func NewFileRepository() *FileRepository {
+	return NewFileRepositoryWithFieldOrder(renderer.DefaultFieldOrder)
+}
+
+// NewFileRepositoryWithFieldOrder creates a FileRepository that writes
+// ## Fields blocks in the given order
+func NewFileRepositoryWithFieldOrder(fieldOrder renderer.FieldOrder) *FileRepository {
 	return &FileRepository{
-		parser: parser.New(),
+		parser:   parser.New(),
+		renderer: renderer.NewRendererWithFieldOrder(nil, fieldOrder),
 	}
 }
 
 // GetTickets reads and parses tickets from a file using the new parser
-func (r *FileRepository) GetTickets(filepath string) ([]domain.Ticket, error) {
+func (r *FileRepository) GetTickets(ctx context.Context, filepath string) ([]domain.Ticket, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	tickets, err := r.parser.Parse(filepath)
 	if err != nil {
 		// Check if the error is due to file not existing
@@ -36,94 +50,154 @@ func (r *FileRepository) GetTickets(filepath string) ([]domain.Ticket, error) {
 	return tickets, nil
 }
 
-// SaveTickets writes tickets to a file in the new TICKET format
-func (r *FileRepository) SaveTickets(filepath string, tickets []domain.Ticket) error {
-	file, err := os.Create(filepath)
+// GetTicketsWithDiagnostics reads and parses tickets from a file and returns
+// every problem the parser found. Tickets are returned even when there are
+// errors among the diagnostics.
+func (r *FileRepository) GetTicketsWithDiagnostics(ctx context.Context, filepath string) ([]domain.Ticket, parser.Diagnostics, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, nil, err
+	}
+	tickets, diagnostics, err := r.parser.ParseWithDiagnostics(filepath)
 	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil, ports.ErrFileNotFound
+		}
+		return nil, nil, err
+	}
+	return tickets, diagnostics, nil
+}
+
+// SaveTickets writes tickets to a file in the TICKET format. When the file
+// already exists, only the lines whose content changed are rewritten, so
+// comments, blank lines, field order and sections the parser ignores stay
+// byte-identical.
+func (r *FileRepository) SaveTickets(ctx context.Context, filepath string, tickets []domain.Ticket) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	original, _ := os.ReadFile(filepath)
+	content, _ := r.render(original, tickets)
+	if err := os.WriteFile(filepath, content, 0644); err != nil {
 		return fmt.Errorf("failed to create file: %w", err)
 	}
-	defer file.Close()
+	return nil
+}
 
-	writer := bufio.NewWriter(file)
+// SaveTicketsWithConflicts saves tickets like SaveTickets and, for each ticket
+// whose Jira ID is in theirs, wraps the lines where it and theirs differ in
+// "<<<<<<< local" / "=======" / ">>>>>>> jira" markers. The file cannot be
+// read again until the markers are removed.
+func (r *FileRepository) SaveTicketsWithConflicts(ctx context.Context, filepath string, tickets []domain.Ticket, theirs map[string]domain.Ticket) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 
+	original, _ := os.ReadFile(filepath)
+	ours, newline, finalNewline, _ := r.renderLines(original, tickets)
+
+	swapped := make([]domain.Ticket, len(tickets))
 	for i, ticket := range tickets {
-		// Write ticket heading with Jira ID if present
-		if ticket.JiraID != "" {
-			fmt.Fprintf(writer, "# TICKET: [%s] %s\n", ticket.JiraID, ticket.Title)
-		} else {
-			fmt.Fprintf(writer, "# TICKET: %s\n", ticket.Title)
+		swapped[i] = ticket
+		if their, ok := theirs[ticket.JiraID]; ok && ticket.JiraID != "" {
+			swapped[i] = their
 		}
-		fmt.Fprintln(writer)
+	}
+	their, _, _, _ := r.renderLines(original, swapped)
 
-		// Write description
-		if ticket.Description != "" {
-			fmt.Fprintln(writer, "## Description")
-			fmt.Fprintln(writer, ticket.Description)
-			fmt.Fprintln(writer)
-		}
+	content := joinLines(markConflicts(ours, their), newline, finalNewline)
+	if err := os.WriteFile(filepath, content, 0644); err != nil {
+		return fmt.Errorf("failed to create file: %w", err)
+	}
+	return nil
+}
 
-		// Write fields
-		if len(ticket.CustomFields) > 0 {
-			fmt.Fprintln(writer, "## Fields")
-			for key, value := range ticket.CustomFields {
-				fmt.Fprintf(writer, "%s: %s\n", key, value)
-			}
-			fmt.Fprintln(writer)
-		}
+// FormatFile rewrites the ## Fields blocks of a file into canonical order,
+// leaving the rest of the file as it is. It reports whether the file was not
+// already canonical; with check set, the file is not written.
+func (r *FileRepository) FormatFile(ctx context.Context, filepath string, check bool) (bool, error) {
+	if err := ctx.Err(); err != nil {
+		return false, err
+	}
 
-		// Write acceptance criteria
-		if len(ticket.AcceptanceCriteria) > 0 {
-			fmt.Fprintln(writer, "## Acceptance Criteria")
-			for _, ac := range ticket.AcceptanceCriteria {
-				fmt.Fprintf(writer, "- %s\n", ac)
-			}
-			fmt.Fprintln(writer)
+	original, err := os.ReadFile(filepath)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return false, ports.ErrFileNotFound
 		}
+		return false, fmt.Errorf("failed to read file: %w", err)
+	}
 
-		// Write tasks
-		if len(ticket.Tasks) > 0 {
-			fmt.Fprintln(writer, "## Tasks")
-			for _, task := range ticket.Tasks {
-				// Write task with Jira ID if present
-				if task.JiraID != "" {
-					fmt.Fprintf(writer, "- [%s] %s\n", task.JiraID, task.Title)
-				} else {
-					fmt.Fprintf(writer, "- %s\n", task.Title)
-				}
-
-				// Write task description (indented)
-				if task.Description != "" {
-					fmt.Fprintln(writer, "  ## Description")
-					// Indent description lines
-					fmt.Fprintf(writer, "  %s\n", task.Description)
-					fmt.Fprintln(writer)
-				}
-
-				// Write task fields (indented)
-				if len(task.CustomFields) > 0 {
-					fmt.Fprintln(writer, "  ## Fields")
-					for key, value := range task.CustomFields {
-						fmt.Fprintf(writer, "  %s: %s\n", key, value)
-					}
-					fmt.Fprintln(writer)
-				}
-
-				// Write task acceptance criteria (indented)
-				if len(task.AcceptanceCriteria) > 0 {
-					fmt.Fprintln(writer, "  ## Acceptance Criteria")
-					for _, ac := range task.AcceptanceCriteria {
-						fmt.Fprintf(writer, "  - %s\n", ac)
-					}
-					fmt.Fprintln(writer)
-				}
-			}
+	lines, _, _ := splitLines(original)
+	tickets, err := r.parser.ParseLines(lines)
+	if err != nil {
+		return false, err
+	}
+	for i := range tickets {
+		ticket := &tickets[i]
+		ticket.FieldOrder = r.renderer.FieldOrder().FieldNames(ticket.Status, ticket.CustomFields, nil)
+		for j := range ticket.Tasks {
+			task := &ticket.Tasks[j]
+			task.FieldOrder = r.renderer.FieldOrder().FieldNames(task.Status, task.CustomFields, nil)
 		}
+	}
 
-		// Add spacing between tickets
-		if i < len(tickets)-1 {
-			fmt.Fprintln(writer)
+	content, patched := r.render(original, tickets)
+	if len(original) > 0 && !patched {
+		return false, fmt.Errorf("failed to format %s: its ticket headings could not be matched up", filepath)
+	}
+	if bytes.Equal(content, original) {
+		return false, nil
+	}
+	if !check {
+		if err := os.WriteFile(filepath, content, 0644); err != nil {
+			return true, fmt.Errorf("failed to write file: %w", err)
 		}
 	}
+	return true, nil
+}
+
+// render returns the file content holding tickets. When original has content
+// the tickets are patched into it, keeping its line endings, and render
+// reports whether that succeeded; otherwise the tickets are written afresh.
+func (r *FileRepository) render(original []byte, tickets []domain.Ticket) ([]byte, bool) {
+	lines, newline, finalNewline, patched := r.renderLines(original, tickets)
+	return joinLines(lines, newline, finalNewline), patched
+}
+
+// renderLines is render before the lines are joined, also returning the line
+// ending and whether the content ends with one
+func (r *FileRepository) renderLines(original []byte, tickets []domain.Ticket) ([]string, string, bool, bool) {
+	var lines []string
+	newline, finalNewline, patched := "\n", true, false
+	if len(original) > 0 {
+		var existing []string
+		existing, newline, finalNewline = splitLines(original)
+		lines, patched = r.patchFile(existing, tickets)
+	}
+	if !patched {
+		lines = r.renderer.Lines(tickets)
+	}
+	return lines, newline, finalNewline, patched
+}
 
-	return writer.Flush()
+// joinLines joins lines into file content
+func joinLines(lines []string, newline string, finalNewline bool) []byte {
+	content := strings.Join(lines, newline)
+	if finalNewline && len(lines) > 0 {
+		content += newline
+	}
+	return []byte(content)
+}
+
+// splitLines splits file content into lines, returning the line ending it
+// uses and whether it ends with one
+func splitLines(content []byte) ([]string, string, bool) {
+	text, newline := string(content), "\n"
+	if strings.Contains(text, "\r\n") {
+		newline = "\r\n"
+		text = strings.ReplaceAll(text, "\r\n", "\n")
+	}
+	finalNewline := strings.HasSuffix(text, "\n")
+	return strings.Split(strings.TrimSuffix(text, "\n"), "\n"), newline, finalNewline
 }
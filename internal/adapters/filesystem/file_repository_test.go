@@ -1,6 +1,7 @@
 package filesystem
 
 import (
+	"context"
 	"os"
 	"testing"
 
@@ -59,7 +60,7 @@ Project: PROJ
 
 	// Act: Pass the file to the parser
 	repo := NewFileRepository()
-	tickets, err := repo.GetTickets(tmpFile.Name())
+	tickets, err := repo.GetTickets(context.Background(), tmpFile.Name())
 
 	// Assert: The parser returns a slice containing exactly two Ticket objects
 	if err != nil {
@@ -106,7 +107,7 @@ Project: PROJ
 
 	// Act: Parse the string
 	repo := NewFileRepository()
-	tickets, err := repo.GetTickets(tmpFile.Name())
+	tickets, err := repo.GetTickets(context.Background(), tmpFile.Name())
 
 	// Assert: The resulting Task object has its Description and AcceptanceCriteria fields correctly populated
 	if err != nil {
@@ -173,7 +174,7 @@ Project: PROJ
 
 	// Act: Parse the string
 	repo := NewFileRepository()
-	tickets, err := repo.GetTickets(tmpFile.Name())
+	tickets, err := repo.GetTickets(context.Background(), tmpFile.Name())
 
 	// Assert: The JiraID field is correctly populated for items with keys and empty for those without
 	if err != nil {
@@ -237,7 +238,7 @@ This should fail parsing.
 
 	// Act: Parse the string
 	repo := NewFileRepository()
-	tickets, err := repo.GetTickets(tmpFile.Name())
+	tickets, err := repo.GetTickets(context.Background(), tmpFile.Name())
 
 	// Assert: The parser returns no error but an empty slice (no valid tickets found)
 	if err != nil {
@@ -252,7 +253,7 @@ This should fail parsing.
 func TestFileRepository_GetTickets_NonExistentFile(t *testing.T) {
 	repo := NewFileRepository()
 
-	_, err := repo.GetTickets("/nonexistent/path/to/file.md")
+	_, err := repo.GetTickets(context.Background(), "/nonexistent/path/to/file.md")
 
 	if err == nil {
 		t.Fatal("Expected error for nonexistent file, got nil")
@@ -269,7 +270,7 @@ func TestFileRepository_GetTickets_EmptyFile(t *testing.T) {
 	tmpFile.Close()
 
 	repo := NewFileRepository()
-	tickets, err := repo.GetTickets(tmpFile.Name())
+	tickets, err := repo.GetTickets(context.Background(), tmpFile.Name())
 
 	if err != nil {
 		t.Fatalf("Expected no error for empty file, got: %v", err)
@@ -299,7 +300,7 @@ Random content here
 	tmpFile.Close()
 
 	repo := NewFileRepository()
-	tickets, err := repo.GetTickets(tmpFile.Name())
+	tickets, err := repo.GetTickets(context.Background(), tmpFile.Name())
 
 	// Should not error but return empty slice
 	if err != nil {
@@ -339,7 +340,7 @@ func TestFileRepository_SaveTickets_ValidTickets(t *testing.T) {
 		},
 	}
 
-	err := repo.SaveTickets(filepath, tickets)
+	err := repo.SaveTickets(context.Background(), filepath, tickets)
 	if err != nil {
 		t.Fatalf("Expected no error saving tickets, got: %v", err)
 	}
@@ -350,7 +351,7 @@ func TestFileRepository_SaveTickets_ValidTickets(t *testing.T) {
 	}
 
 	// Verify we can read it back
-	readTickets, err := repo.GetTickets(filepath)
+	readTickets, err := repo.GetTickets(context.Background(), filepath)
 	if err != nil {
 		t.Fatalf("Expected no error reading tickets, got: %v", err)
 	}
@@ -374,7 +375,7 @@ func TestFileRepository_SaveTickets_InvalidPath(t *testing.T) {
 	}
 
 	// Try to save to invalid path
-	err := repo.SaveTickets("/invalid/nonexistent/path/file.md", tickets)
+	err := repo.SaveTickets(context.Background(), "/invalid/nonexistent/path/file.md", tickets)
 
 	if err == nil {
 		t.Fatal("Expected error for invalid path, got nil")
@@ -389,7 +390,7 @@ func TestFileRepository_SaveTickets_EmptyTickets(t *testing.T) {
 	repo := NewFileRepository()
 	tickets := []domain.Ticket{}
 
-	err := repo.SaveTickets(filepath, tickets)
+	err := repo.SaveTickets(context.Background(), filepath, tickets)
 	if err != nil {
 		t.Fatalf("Expected no error saving empty tickets, got: %v", err)
 	}
@@ -426,6 +427,14 @@ func TestFileRepository_SaveTickets_RoundTrip(t *testing.T) {
 				"Data persists correctly",
 				"All fields are preserved",
 			},
+			Links: []domain.Link{
+				{Type: "blocks", Key: "RT-200"},
+				{Type: "relates to", Title: "Local ticket"},
+			},
+			Comments: []domain.Comment{
+				{ID: "10001", Author: "Jane Doe", Created: "2024-01-02T10:00:00.000+0000", Body: "Looks good.\n\n- but check refunds\n- and `## headings`"},
+				{Body: "Refunds are covered.\nSee RT-200."},
+			},
 			Tasks: []domain.Task{
 				{
 					JiraID:      "RT-101",
@@ -443,13 +452,13 @@ func TestFileRepository_SaveTickets_RoundTrip(t *testing.T) {
 	}
 
 	// Save tickets
-	err := repo.SaveTickets(filepath, original)
+	err := repo.SaveTickets(context.Background(), filepath, original)
 	if err != nil {
 		t.Fatalf("Failed to save tickets: %v", err)
 	}
 
 	// Load tickets back
-	loaded, err := repo.GetTickets(filepath)
+	loaded, err := repo.GetTickets(context.Background(), filepath)
 	if err != nil {
 		t.Fatalf("Failed to load tickets: %v", err)
 	}
@@ -479,6 +488,24 @@ func TestFileRepository_SaveTickets_RoundTrip(t *testing.T) {
 	if len(loaded[0].AcceptanceCriteria) != len(original[0].AcceptanceCriteria) {
 		t.Errorf("AC count mismatch: expected %d, got %d", len(original[0].AcceptanceCriteria), len(loaded[0].AcceptanceCriteria))
 	}
+
+	if len(loaded[0].Links) != len(original[0].Links) {
+		t.Fatalf("Links count mismatch: expected %d, got %d", len(original[0].Links), len(loaded[0].Links))
+	}
+	for i, link := range original[0].Links {
+		if loaded[0].Links[i] != link {
+			t.Errorf("Link %d mismatch: expected %+v, got %+v", i, link, loaded[0].Links[i])
+		}
+	}
+
+	if len(loaded[0].Comments) != len(original[0].Comments) {
+		t.Fatalf("Comments count mismatch: expected %d, got %d", len(original[0].Comments), len(loaded[0].Comments))
+	}
+	for i, comment := range original[0].Comments {
+		if loaded[0].Comments[i] != comment {
+			t.Errorf("Comment %d mismatch: expected %+v, got %+v", i, comment, loaded[0].Comments[i])
+		}
+	}
 }
 
 // TestFileRepository_GetTickets_PermissionDenied tests handling of permission errors
@@ -496,7 +523,7 @@ func TestFileRepository_GetTickets_PermissionDenied(t *testing.T) {
 	}
 
 	repo := NewFileRepository()
-	_, err := repo.GetTickets(filepath)
+	_, err := repo.GetTickets(context.Background(), filepath)
 
 	if err == nil {
 		t.Error("Expected error for permission denied, got nil")
@@ -531,12 +558,12 @@ func TestFileRepository_SaveTickets_MultipleTickets(t *testing.T) {
 		},
 	}
 
-	err := repo.SaveTickets(filepath, tickets)
+	err := repo.SaveTickets(context.Background(), filepath, tickets)
 	if err != nil {
 		t.Fatalf("Failed to save multiple tickets: %v", err)
 	}
 
-	loaded, err := repo.GetTickets(filepath)
+	loaded, err := repo.GetTickets(context.Background(), filepath)
 	if err != nil {
 		t.Fatalf("Failed to load multiple tickets: %v", err)
 	}
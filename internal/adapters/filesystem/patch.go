@@ -0,0 +1,499 @@
+package filesystem
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+	"github.com/karolswdev/ticktr/internal/renderer"
+)
+
+var (
+	ticketHeadingRegex = regexp.MustCompile(`^# TICKET:\s*(?:\[([^\]]+)\])?\s*(.+)$`)
+	taskItemRegex      = regexp.MustCompile(`^-\s*(?:\[([^\]]+)\])?\s*(.+)$`)
+	fieldLineRegex     = regexp.MustCompile(`^([^:]+):\s*(.*)$`)
+)
+
+// part is a run of lines in a ticket or task: one "## " section, or the
+// heading with the lines before the first section
+type part struct {
+	name   string   // Section name; "" for the heading part and unknown sections
+	header string   // The "## " line, or the ticket or task heading
+	body   []string // Lines the parser reads as the section's content
+	tail   []string // Lines after the content that the parser ignores, blank lines included
+}
+
+// patchFile rewrites the original file lines so they hold tickets, changing
+// only what differs from what the parser reads in them. Tickets keep their
+// place in the file, new ones are appended, and ones no longer present are
+// dropped. It reports false when the file's layout cannot be matched up with
+// what the parser reads, in which case the file should be written afresh.
+func (r *FileRepository) patchFile(original []string, tickets []domain.Ticket) ([]string, bool) {
+	old, err := r.parser.ParseLines(original)
+	if err != nil {
+		return nil, false
+	}
+
+	// Split into the lines before the first ticket and one block per ticket
+	var starts []int
+	for i, line := range original {
+		if ticketHeadingRegex.MatchString(line) {
+			starts = append(starts, i)
+		} else if strings.HasPrefix(strings.TrimSpace(line), "# TICKET:") {
+			return nil, false
+		}
+	}
+	if len(starts) != len(old) {
+		return nil, false
+	}
+
+	matches, used := matchItems(len(old), len(tickets), func(i, j int) (string, string, string, string) {
+		return old[i].JiraID, old[i].Title, tickets[j].JiraID, tickets[j].Title
+	})
+
+	var lines []string
+	if len(starts) > 0 {
+		lines = append(lines, original[:starts[0]]...)
+	} else {
+		lines = append(lines, original...)
+	}
+	for i, start := range starts {
+		end := len(original)
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		if matches[i] >= 0 {
+			lines = append(lines, r.patchTicket(original[start:end], old[i], tickets[matches[i]])...)
+		}
+	}
+
+	for j, ticket := range tickets {
+		if used[j] {
+			continue
+		}
+		if len(lines) > 0 && strings.TrimSpace(lines[len(lines)-1]) != "" {
+			lines = append(lines, "")
+		}
+		lines = append(lines, r.renderer.TicketLines(ticket)...)
+	}
+
+	return lines, true
+}
+
+// matchItems pairs the tickets or tasks in the file with the ones to write:
+// first by Jira ID, then the ones the file has no ID for by title, so items
+// that were just created in Jira are found. ids returns the Jira ID and title
+// of file item i and of item j to write. It returns the item to write for each
+// file item (-1 when it is dropped) and which items to write were paired.
+func matchItems(inFile, toWrite int, ids func(i, j int) (string, string, string, string)) ([]int, []bool) {
+	matches := make([]int, inFile)
+	used := make([]bool, toWrite)
+	for i := range matches {
+		matches[i] = -1
+	}
+
+	for _, byTitle := range []bool{false, true} {
+		for i := range matches {
+			for j := range used {
+				if matches[i] >= 0 || used[j] {
+					continue
+				}
+				fileID, fileTitle, id, title := ids(i, j)
+				if (!byTitle && fileID != "" && id == fileID) || (byTitle && fileID == "" && title == fileTitle) {
+					matches[i], used[j] = j, true
+					break
+				}
+			}
+		}
+	}
+	return matches, used
+}
+
+// patchTicket rewrites a ticket's lines to hold ticket, given what the parser read from them
+func (r *FileRepository) patchTicket(lines []string, old, ticket domain.Ticket) []string {
+	parts := splitParts(lines, isTicketSectionHeader, ticketSectionEnd, renderer.TicketSections)
+	if hasDuplicateSections(parts) {
+		// Which copy the parser kept is not worth guessing; write the ticket afresh
+		_, blank := splitTrailingBlank(lines)
+		return append(trimTrailingBlank(r.renderer.TicketLines(ticket)), blank...)
+	}
+
+	if old.JiraID != ticket.JiraID || old.Title != ticket.Title {
+		parts[0].header = renderer.TicketHeading(ticket)
+	}
+
+	for _, name := range renderer.TicketSections {
+		var changed bool
+		switch name {
+		case renderer.SectionDescription:
+			changed = old.Description != ticket.Description
+		case renderer.SectionFields:
+			changed = !fieldsEqual(old.Status, old.CustomFields, ticket.Status, ticket.CustomFields) || reordered(old.FieldOrder, ticket.FieldOrder)
+		case renderer.SectionAcceptanceCriteria:
+			changed = !slices.Equal(old.AcceptanceCriteria, ticket.AcceptanceCriteria)
+		case renderer.SectionLinks:
+			changed = !slices.Equal(old.Links, ticket.Links)
+		case renderer.SectionComments:
+			changed = !slices.Equal(old.Comments, ticket.Comments)
+		case renderer.SectionTasks:
+			changed = !slices.EqualFunc(old.Tasks, ticket.Tasks, tasksEqual)
+		}
+		if !changed {
+			continue
+		}
+
+		parts = setSection(parts, name, "## "+name, renderer.TicketSections, func(existing []string) []string {
+			switch name {
+			case renderer.SectionFields:
+				if reordered(old.FieldOrder, ticket.FieldOrder) {
+					return r.renderer.FieldLines(ticket.Status, ticket.CustomFields, ticket.FieldOrder, "")
+				}
+				return r.patchFields(existing, ticket.Status, ticket.CustomFields, old.FieldOrder, "")
+			case renderer.SectionTasks:
+				return r.patchTasks(existing, old.Tasks, ticket.Tasks)
+			}
+			return r.renderer.TicketSection(ticket, name)
+		})
+	}
+
+	return joinParts(parts)
+}
+
+// patchTasks rewrites the body of a Tasks section to hold tasks, given what
+// the parser read from it. Tasks keep their place, new ones are appended and
+// ones no longer present are dropped.
+func (r *FileRepository) patchTasks(body []string, old, tasks []domain.Task) []string {
+	// Split into the lines before the first task and one item per task
+	var starts []int
+	for i, line := range body {
+		if !strings.HasPrefix(line, "  ") && taskItemRegex.MatchString(strings.TrimSpace(line)) {
+			starts = append(starts, i)
+		}
+	}
+	if len(starts) != len(old) {
+		return r.renderer.TicketSection(domain.Ticket{Tasks: tasks}, renderer.SectionTasks)
+	}
+
+	matches, used := matchItems(len(old), len(tasks), func(i, j int) (string, string, string, string) {
+		return old[i].JiraID, old[i].Title, tasks[j].JiraID, tasks[j].Title
+	})
+
+	var lines []string
+	if len(starts) > 0 {
+		lines = append(lines, body[:starts[0]]...)
+	}
+	lastSpan := 0 // Lines taken by the last task kept
+	for i, start := range starts {
+		end := len(body)
+		if i+1 < len(starts) {
+			end = starts[i+1]
+		}
+		if matches[i] >= 0 {
+			item := r.patchTask(body[start:end], old[i], tasks[matches[i]])
+			lines = append(lines, item...)
+			lastSpan = len(trimTrailingBlank(item))
+		}
+	}
+	lines = trimTrailingBlank(lines)
+
+	for j, task := range tasks {
+		if used[j] {
+			continue
+		}
+		// Separate multi-line tasks with a blank line, as SaveTickets writes them
+		item := trimTrailingBlank(r.renderer.TaskLines(task))
+		if len(lines) > 0 && (lastSpan > 1 || len(item) > 1) {
+			lines = append(lines, "")
+		}
+		lines = append(lines, item...)
+		lastSpan = len(item)
+	}
+
+	return lines
+}
+
+// patchTask rewrites a task's lines to hold task, given what the parser read from them
+func (r *FileRepository) patchTask(lines []string, old, task domain.Task) []string {
+	parts := splitParts(lines, isTaskSectionHeader, taskSectionEnd, renderer.TaskSections)
+	if hasDuplicateSections(parts) {
+		_, blank := splitTrailingBlank(lines)
+		return append(trimTrailingBlank(r.renderer.TaskLines(task)), blank...)
+	}
+
+	if old.JiraID != task.JiraID || old.Title != task.Title {
+		parts[0].header = renderer.TaskHeading(task)
+	}
+
+	for _, name := range renderer.TaskSections {
+		var changed bool
+		switch name {
+		case renderer.SectionDescription:
+			changed = old.Description != task.Description
+		case renderer.SectionFields:
+			changed = !fieldsEqual(old.Status, old.CustomFields, task.Status, task.CustomFields) || reordered(old.FieldOrder, task.FieldOrder)
+		case renderer.SectionAcceptanceCriteria:
+			changed = !slices.Equal(old.AcceptanceCriteria, task.AcceptanceCriteria)
+		}
+		if !changed {
+			continue
+		}
+
+		parts = setSection(parts, name, "  ## "+name, renderer.TaskSections, func(existing []string) []string {
+			switch {
+			case name != renderer.SectionFields:
+				return r.renderer.TaskSection(task, name)
+			case reordered(old.FieldOrder, task.FieldOrder):
+				return r.renderer.FieldLines(task.Status, task.CustomFields, task.FieldOrder, "  ")
+			}
+			return r.patchFields(existing, task.Status, task.CustomFields, old.FieldOrder, "  ")
+		})
+	}
+
+	return joinParts(parts)
+}
+
+// patchFields rewrites the "Key: Value" lines of a Fields section in place:
+// changed values are rewritten, removed fields dropped and new ones appended
+// in the repository's field order. Other lines are kept as they are.
+func (r *FileRepository) patchFields(existing []string, status string, fields map[string]string, source []string, indent string) []string {
+	want := maps.Clone(fields)
+	if want == nil {
+		want = make(map[string]string)
+	}
+	if status != "" {
+		want[domain.StatusField] = status
+	}
+	if len(want) == 0 {
+		return nil
+	}
+
+	var lines []string
+	written := make(map[string]bool)
+	for _, line := range existing {
+		trimmed := strings.TrimSpace(line)
+		matches := fieldLineRegex.FindStringSubmatch(trimmed)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") || matches == nil {
+			lines = append(lines, line)
+			continue
+		}
+
+		key := matches[1]
+		value, ok := want[key]
+		if !ok || written[key] {
+			continue
+		}
+		written[key] = true
+		if strings.TrimSpace(matches[2]) == value {
+			lines = append(lines, line)
+		} else {
+			lines = append(lines, line[:len(line)-len(strings.TrimLeft(line, " \t"))]+key+": "+value)
+		}
+	}
+
+	var missing []string
+	for key := range want {
+		if !written[key] {
+			missing = append(missing, key)
+		}
+	}
+	for _, key := range r.renderer.FieldOrder().Sort(missing, source) {
+		lines = append(lines, indent+key+": "+want[key])
+	}
+
+	return trimTrailingBlank(lines)
+}
+
+// splitParts splits a ticket's or task's lines, heading first, into parts
+func splitParts(lines []string, isHeader func(string) bool, sectionEnd func(name string, region []string) int, known []string) []part {
+	parts := []part{{header: lines[0]}}
+	for _, line := range lines[1:] {
+		if isHeader(line) {
+			parts = append(parts, part{name: sectionName(line, known), header: line})
+			continue
+		}
+		last := &parts[len(parts)-1]
+		last.tail = append(last.tail, line)
+	}
+
+	// Separate each known section's content from the lines after it
+	for i := range parts[1:] {
+		p := &parts[i+1]
+		if p.name == "" {
+			continue
+		}
+		region := p.tail
+		p.body = trimTrailingBlank(region[:sectionEnd(p.name, region)])
+		p.tail = region[len(p.body):]
+	}
+	return parts
+}
+
+// setSection gives a section a new body, built from its current one. An
+// empty body removes the section; a missing section is inserted before the
+// first section that comes after it in order.
+func setSection(parts []part, name, header string, order []string, build func(existing []string) []string) []part {
+	idx := slices.IndexFunc(parts, func(p part) bool { return p.name == name })
+	var existing []string
+	if idx >= 0 {
+		existing = parts[idx].body
+	}
+	body := build(existing)
+
+	switch {
+	case idx >= 0 && len(body) > 0:
+		parts[idx].body = body
+
+	case idx >= 0:
+		// Remove the section, keeping any lines the parser ignored and the
+		// blank lines that end the ticket or task
+		removed := parts[idx]
+		parts = slices.Delete(parts, idx, idx+1)
+		prev := &parts[idx-1]
+		if content, _ := splitTrailingBlank(removed.tail); len(content) > 0 {
+			prev.tail = append(prev.tail, removed.tail...)
+		} else if idx == len(parts) {
+			prev.tail = append(trimTrailingBlank(prev.tail), removed.tail...)
+		}
+
+	case len(body) > 0:
+		rank := slices.Index(order, name)
+		at := slices.IndexFunc(parts, func(p part) bool { return p.name != "" && slices.Index(order, p.name) > rank })
+		added := part{name: name, header: header, body: body, tail: []string{""}}
+		if at >= 0 {
+			parts = slices.Insert(parts, at, added)
+			break
+		}
+		// Append, moving the blank lines that end the ticket or task after it
+		last := &parts[len(parts)-1]
+		content, blank := splitTrailingBlank(last.tail)
+		last.tail = content
+		if len(parts) > 1 || !strings.HasPrefix(header, " ") {
+			// Tasks start their first section right under the item
+			last.tail = append(last.tail, "")
+		}
+		added.tail = blank
+		parts = append(parts, added)
+	}
+
+	return parts
+}
+
+// joinParts puts parts back together into lines
+func joinParts(parts []part) []string {
+	var lines []string
+	for _, p := range parts {
+		lines = append(lines, p.header)
+		lines = append(lines, p.body...)
+		lines = append(lines, p.tail...)
+	}
+	return lines
+}
+
+// sectionName returns the known section a "## " line opens, matched by prefix
+// as the parser does, or "" for a section the parser ignores
+func sectionName(line string, known []string) string {
+	heading := strings.TrimSpace(line)
+	for _, name := range known {
+		if strings.HasPrefix(heading, "## "+name) {
+			return name
+		}
+	}
+	return ""
+}
+
+// hasDuplicateSections reports whether a known section appears more than once
+func hasDuplicateSections(parts []part) bool {
+	seen := make(map[string]bool)
+	for _, p := range parts {
+		if p.name != "" {
+			if seen[p.name] {
+				return true
+			}
+			seen[p.name] = true
+		}
+	}
+	return false
+}
+
+// isTicketSectionHeader reports whether a line opens a ticket section
+func isTicketSectionHeader(line string) bool {
+	return strings.HasPrefix(line, "## ")
+}
+
+// isTaskSectionHeader reports whether a line opens an indented task section
+func isTaskSectionHeader(line string) bool {
+	return strings.HasPrefix(line, " ") && strings.HasPrefix(strings.TrimSpace(line), "## ")
+}
+
+// ticketSectionEnd returns where the parser stops reading a ticket section's content
+func ticketSectionEnd(name string, region []string) int {
+	for i, line := range region {
+		switch name {
+		case renderer.SectionTasks:
+			// Runs until the next ticket section
+		case renderer.SectionComments:
+			if strings.HasPrefix(line, "#") {
+				return i
+			}
+		default:
+			if strings.HasPrefix(strings.TrimSpace(line), "##") {
+				return i
+			}
+		}
+	}
+	return len(region)
+}
+
+// taskSectionEnd returns where the parser stops reading a task section's content
+func taskSectionEnd(name string, region []string) int {
+	for i, line := range region {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "##") {
+			return i
+		}
+		if name == renderer.SectionFields {
+			if strings.HasPrefix(trimmed, "-") {
+				return i
+			}
+		} else if trimmed != "" && !strings.HasPrefix(line, "  ") {
+			return i
+		}
+	}
+	return len(region)
+}
+
+// fieldsEqual reports whether two status and custom field sets are the same
+func fieldsEqual(statusA string, fieldsA map[string]string, statusB string, fieldsB map[string]string) bool {
+	return statusA == statusB && maps.Equal(fieldsA, fieldsB)
+}
+
+// reordered reports whether fields are to be written in a different order
+// than the file lists them. A ticket from Jira has no order of its own and
+// keeps the file's.
+func reordered(inFile, toWrite []string) bool {
+	return toWrite != nil && !slices.Equal(inFile, toWrite)
+}
+
+// tasksEqual reports whether two tasks hold the same Markdown content
+func tasksEqual(a, b domain.Task) bool {
+	return a.JiraID == b.JiraID && a.Title == b.Title && a.Description == b.Description &&
+		fieldsEqual(a.Status, a.CustomFields, b.Status, b.CustomFields) && !reordered(a.FieldOrder, b.FieldOrder) &&
+		slices.Equal(a.AcceptanceCriteria, b.AcceptanceCriteria)
+}
+
+// trimTrailingBlank drops the blank lines at the end of lines
+func trimTrailingBlank(lines []string) []string {
+	end := len(lines)
+	for end > 0 && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	return lines[:end]
+}
+
+// splitTrailingBlank splits lines into their content and the blank lines ending them
+func splitTrailingBlank(lines []string) ([]string, []string) {
+	content := trimTrailingBlank(lines)
+	return content, lines[len(content):]
+}
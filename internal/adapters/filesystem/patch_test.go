@@ -0,0 +1,278 @@
+package filesystem
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+	"github.com/karolswdev/ticktr/internal/renderer"
+)
+
+const templatedFile = `<!-- Sprint 12 backlog, generated from the team template -->
+
+# TICKET: Build checkout page
+
+## Description
+Let customers review their basket and pay.
+
+## Fields
+Type: Story
+Priority: High
+Sprint: 12
+
+## Notes
+Design review is on Thursday.
+
+## Tasks
+- Lay out the basket summary
+  ### Design
+  See the Figma board.
+
+  ## Fields
+  Priority: Low
+
+- Add the pay button
+
+
+# TICKET: [PROJ-7] Fix login timeout
+
+## Fields
+Priority: Low
+Type: Bug
+`
+
+// saveAndRead writes content to a file, saves tickets over it and returns the result
+func saveAndRead(t *testing.T, content string, edit func(tickets []domain.Ticket) []domain.Ticket) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "tickets.md")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	repo := NewFileRepository()
+	tickets, err := repo.GetTickets(context.Background(), path)
+	if err != nil {
+		t.Fatalf("GetTickets failed: %v", err)
+	}
+	if err := repo.SaveTickets(context.Background(), path, edit(tickets)); err != nil {
+		t.Fatalf("SaveTickets failed: %v", err)
+	}
+
+	saved, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(saved)
+}
+
+// TestSaveTickets_UnchangedFileIsByteIdentical verifies saving what was read leaves the file untouched
+func TestSaveTickets_UnchangedFileIsByteIdentical(t *testing.T) {
+	for name, content := range map[string]string{
+		"template":         templatedFile,
+		"crlf":             strings.ReplaceAll(templatedFile, "\n", "\r\n"),
+		"no final newline": strings.TrimSuffix(templatedFile, "\n"),
+	} {
+		saved := saveAndRead(t, content, func(tickets []domain.Ticket) []domain.Ticket { return tickets })
+		if saved != content {
+			t.Errorf("%s: expected the file to be unchanged, got:\n%s", name, saved)
+		}
+	}
+}
+
+// TestSaveTickets_InjectsKeysInPlace verifies a push only rewrites the heading lines of created issues
+func TestSaveTickets_InjectsKeysInPlace(t *testing.T) {
+	saved := saveAndRead(t, templatedFile, func(tickets []domain.Ticket) []domain.Ticket {
+		tickets[0].JiraID = "PROJ-10"
+		tickets[0].Tasks[0].JiraID = "PROJ-11"
+		tickets[0].Tasks[1].JiraID = "PROJ-12"
+		return tickets
+	})
+
+	expected := strings.NewReplacer(
+		"# TICKET: Build checkout page", "# TICKET: [PROJ-10] Build checkout page",
+		"- Lay out the basket summary", "- [PROJ-11] Lay out the basket summary",
+		"- Add the pay button", "- [PROJ-12] Add the pay button",
+	).Replace(templatedFile)
+	if saved != expected {
+		t.Errorf("Expected only the headings to change, got:\n%s", saved)
+	}
+}
+
+// TestSaveTickets_PatchesChangedSections verifies a pull rewrites changed values where they are
+func TestSaveTickets_PatchesChangedSections(t *testing.T) {
+	saved := saveAndRead(t, templatedFile, func(tickets []domain.Ticket) []domain.Ticket {
+		ticket := &tickets[0]
+		ticket.Description = "Let customers review their basket,\nthen pay."
+		ticket.CustomFields["Priority"] = "Critical"
+		delete(ticket.CustomFields, "Sprint")
+		ticket.CustomFields["Labels"] = "checkout"
+		ticket.Status = "In Progress"
+		ticket.AcceptanceCriteria = []string{"Totals include tax"}
+		ticket.Tasks[0].CustomFields["Priority"] = "High"
+		return tickets
+	})
+
+	expected := `<!-- Sprint 12 backlog, generated from the team template -->
+
+# TICKET: Build checkout page
+
+## Description
+Let customers review their basket,
+then pay.
+
+## Fields
+Type: Story
+Priority: Critical
+Status: In Progress
+Labels: checkout
+
+## Notes
+Design review is on Thursday.
+
+## Acceptance Criteria
+- Totals include tax
+
+## Tasks
+- Lay out the basket summary
+  ### Design
+  See the Figma board.
+
+  ## Fields
+  Priority: High
+
+- Add the pay button
+
+
+# TICKET: [PROJ-7] Fix login timeout
+
+## Fields
+Priority: Low
+Type: Bug
+`
+	if saved != expected {
+		t.Errorf("Unexpected result:\n%s", saved)
+	}
+}
+
+// TestSaveTickets_AddsAndDropsTickets verifies new tickets and tasks are appended and missing ones removed
+func TestSaveTickets_AddsAndDropsTickets(t *testing.T) {
+	saved := saveAndRead(t, templatedFile, func(tickets []domain.Ticket) []domain.Ticket {
+		tickets[0].Tasks = append(tickets[0].Tasks[1:], domain.Task{Title: "Show delivery dates"})
+		tickets[1] = domain.Ticket{JiraID: "PROJ-8", Title: "Remember me", Status: "To Do"}
+		return tickets
+	})
+
+	expected := `<!-- Sprint 12 backlog, generated from the team template -->
+
+# TICKET: Build checkout page
+
+## Description
+Let customers review their basket and pay.
+
+## Fields
+Type: Story
+Priority: High
+Sprint: 12
+
+## Notes
+Design review is on Thursday.
+
+## Tasks
+- Add the pay button
+- Show delivery dates
+
+
+# TICKET: [PROJ-8] Remember me
+
+## Fields
+Status: To Do
+
+`
+	if saved != expected {
+		t.Errorf("Unexpected result:\n%s", saved)
+	}
+}
+
+// TestSaveTickets_FallsBackOnDuplicateSections verifies a ticket with a repeated section is written afresh
+func TestSaveTickets_FallsBackOnDuplicateSections(t *testing.T) {
+	content := "# TICKET: Twice\n\n## Description\nOne\n\n## Description\nTwo\n"
+	saved := saveAndRead(t, content, func(tickets []domain.Ticket) []domain.Ticket {
+		tickets[0].Description = "Three"
+		return tickets
+	})
+
+	if saved != "# TICKET: Twice\n\n## Description\nThree\n" {
+		t.Errorf("Unexpected result:\n%q", saved)
+	}
+}
+
+// TestSaveTickets_NewFieldsFollowFieldOrder verifies fields without a place in the file are written in the configured order
+func TestSaveTickets_NewFieldsFollowFieldOrder(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tickets.md")
+	repo := NewFileRepositoryWithFieldOrder(renderer.FieldOrder{Priority: []string{"Type", "Priority"}})
+	tickets := []domain.Ticket{{
+		Title:        "Pulled",
+		Status:       "Done",
+		CustomFields: map[string]string{"Sprint": "12", "Priority": "High", "Type": "Story", "Assignee": "jane"},
+	}}
+	if err := repo.SaveTickets(context.Background(), path, tickets); err != nil {
+		t.Fatalf("SaveTickets failed: %v", err)
+	}
+
+	saved, _ := os.ReadFile(path)
+	want := "## Fields\nType: Story\nPriority: High\nAssignee: jane\nSprint: 12\nStatus: Done\n"
+	if !strings.Contains(string(saved), want) {
+		t.Errorf("Expected fields in the configured order, got:\n%s", saved)
+	}
+}
+
+// TestFormatFile_SortsFieldsOnly verifies fmt reorders Fields blocks and leaves everything else alone
+func TestFormatFile_SortsFieldsOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "tickets.md")
+	if err := os.WriteFile(path, []byte(templatedFile), 0644); err != nil {
+		t.Fatal(err)
+	}
+	repo := NewFileRepositoryWithFieldOrder(renderer.FieldOrder{Priority: []string{"Type", "Priority"}})
+
+	changed, err := repo.FormatFile(context.Background(), path, true)
+	if err != nil || !changed {
+		t.Fatalf("Expected check to report the file as not canonical, got %v, %v", changed, err)
+	}
+	if saved, _ := os.ReadFile(path); string(saved) != templatedFile {
+		t.Fatal("Expected check to leave the file untouched")
+	}
+
+	if _, err := repo.FormatFile(context.Background(), path, false); err != nil {
+		t.Fatalf("FormatFile failed: %v", err)
+	}
+	saved, _ := os.ReadFile(path)
+	expected := strings.NewReplacer(
+		"Priority: Low\nType: Bug\n", "Type: Bug\nPriority: Low\n",
+	).Replace(templatedFile)
+	if string(saved) != expected {
+		t.Errorf("Expected only the second ticket's fields to be reordered, got:\n%s", saved)
+	}
+
+	if changed, err := repo.FormatFile(context.Background(), path, true); err != nil || changed {
+		t.Errorf("Expected a formatted file to be canonical, got %v, %v", changed, err)
+	}
+}
+
+// TestSaveTickets_PatchesTaskDescriptionWithList verifies a task description holding a list is replaced whole
+func TestSaveTickets_PatchesTaskDescriptionWithList(t *testing.T) {
+	content := "# TICKET: Docs\n\n## Tasks\n- Write the guide\n  ## Description\n  Cover:\n  - auth\n  - errors\n\n  ## Fields\n  Priority: Low\n"
+	saved := saveAndRead(t, content, func(tickets []domain.Ticket) []domain.Ticket {
+		if got := tickets[0].Tasks[0].Description; got != "Cover:\n- auth\n- errors" {
+			t.Fatalf("Expected the list to be read as part of the description, got %q", got)
+		}
+		tickets[0].Tasks[0].Description = "Cover:\n- auth"
+		return tickets
+	})
+
+	if want := strings.Replace(content, "  - errors\n", "", 1); saved != want {
+		t.Errorf("Unexpected result:\n%q", saved)
+	}
+}
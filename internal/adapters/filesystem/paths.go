@@ -0,0 +1,141 @@
+package filesystem
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// ExpandPaths turns command line arguments into the Markdown files they name.
+// A file is used as given, a directory stands for every *.md file below it,
+// and a glob pattern for every file it matches, where "**" matches any number
+// of directories (backlog/**/*.md). Files are returned once each, in the
+// order of the arguments and sorted within each. It fails when a directory or
+// pattern names no files.
+func ExpandPaths(args []string) ([]string, error) {
+	var files []string
+	seen := make(map[string]bool)
+	add := func(file string) {
+		clean := filepath.Clean(file)
+		if !seen[clean] {
+			seen[clean] = true
+			files = append(files, clean)
+		}
+	}
+
+	for _, arg := range args {
+		var matches []string
+		var err error
+		if isGlob(arg) {
+			matches, err = Glob(arg)
+		} else if info, statErr := os.Stat(arg); statErr == nil && info.IsDir() {
+			matches, err = markdownFiles(arg)
+		} else {
+			// Missing files are reported by whoever reads them
+			add(arg)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no Markdown files match %s", arg)
+		}
+		for _, match := range matches {
+			add(match)
+		}
+	}
+	return files, nil
+}
+
+// isGlob reports whether an argument is a pattern rather than a path
+func isGlob(arg string) bool {
+	return strings.ContainsAny(arg, "*?[")
+}
+
+// markdownFiles returns the *.md files below dir, sorted
+func markdownFiles(dir string) ([]string, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() && strings.EqualFold(filepath.Ext(file), ".md") {
+			files = append(files, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", dir, err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// Glob returns the files matching pattern, where "**" matches any number of
+// directories, sorted. The walk starts at the directories before the first
+// wildcard; none match when they do not exist.
+func Glob(pattern string) ([]string, error) {
+	pattern = filepath.ToSlash(filepath.Clean(pattern))
+	segments := strings.Split(pattern, "/")
+	fixed := 0
+	for fixed < len(segments)-1 && !isGlob(segments[fixed]) {
+		fixed++
+	}
+	root := strings.Join(segments[:fixed], "/")
+	if root == "" && fixed > 0 {
+		root = "/" // Absolute pattern
+	}
+	if fixed == 0 {
+		root = "."
+	}
+
+	var files []string
+	err := filepath.WalkDir(filepath.FromSlash(root), func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) && file == filepath.FromSlash(root) {
+				return filepath.SkipAll
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		if matchSegments(segments, strings.Split(filepath.ToSlash(file), "/")) {
+			files = append(files, file)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to match %s: %w", pattern, err)
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// matchSegments reports whether the segments of a path match those of a
+// pattern, where a "**" segment matches zero or more path segments
+func matchSegments(pattern, name []string) bool {
+	if len(pattern) == 0 {
+		return len(name) == 0
+	}
+	if pattern[0] == "**" {
+		for i := 0; i <= len(name); i++ {
+			if matchSegments(pattern[1:], name[i:]) {
+				return true
+			}
+		}
+		return false
+	}
+	if len(name) == 0 {
+		return false
+	}
+	if matched, err := path.Match(pattern[0], name[0]); err != nil || !matched {
+		return false
+	}
+	return matchSegments(pattern[1:], name[1:])
+}
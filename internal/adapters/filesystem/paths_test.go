@@ -0,0 +1,82 @@
+package filesystem
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+// writeTree creates empty files at the given slash-separated paths below dir
+func writeTree(t *testing.T, dir string, files ...string) {
+	t.Helper()
+	for _, file := range files {
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// TestExpandPaths verifies files, directories and "**" globs expand to Markdown files once each
+func TestExpandPaths(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, "backlog/billing.md", "backlog/auth/login.md", "backlog/auth/notes.txt", "backlog/auth/sso/saml.md", "README.md")
+	join := func(file string) string { return filepath.Join(dir, filepath.FromSlash(file)) }
+
+	got, err := ExpandPaths([]string{
+		join("README.md"),
+		join("backlog/**/*.md"),
+		join("backlog/auth"),
+	})
+	if err != nil {
+		t.Fatalf("ExpandPaths returned error: %v", err)
+	}
+
+	want := []string{
+		join("README.md"),
+		join("backlog/auth/login.md"),
+		join("backlog/auth/sso/saml.md"),
+		join("backlog/billing.md"),
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v, got %v", want, got)
+	}
+}
+
+// TestExpandPaths_NoMatches verifies patterns and directories without Markdown files are errors
+func TestExpandPaths_NoMatches(t *testing.T) {
+	dir := t.TempDir()
+	writeTree(t, dir, "notes.txt")
+
+	if _, err := ExpandPaths([]string{filepath.Join(dir, "*.md")}); err == nil {
+		t.Error("Expected an error for a pattern without matches")
+	}
+	if _, err := ExpandPaths([]string{dir}); err == nil {
+		t.Error("Expected an error for a directory without Markdown files")
+	}
+	if files, err := ExpandPaths([]string{filepath.Join(dir, "missing.md")}); err != nil || len(files) != 1 {
+		t.Errorf("Expected a missing file to be passed through, got %v, %v", files, err)
+	}
+}
+
+// TestMatchSegments verifies "**" matches zero or more directories
+func TestMatchSegments(t *testing.T) {
+	tests := []struct {
+		pattern, name []string
+		want          bool
+	}{
+		{[]string{"backlog", "**", "*.md"}, []string{"backlog", "a.md"}, true},
+		{[]string{"backlog", "**", "*.md"}, []string{"backlog", "x", "y", "a.md"}, true},
+		{[]string{"backlog", "**", "*.md"}, []string{"docs", "a.md"}, false},
+		{[]string{"backlog", "*.md"}, []string{"backlog", "x", "a.md"}, false},
+	}
+	for _, tt := range tests {
+		if got := matchSegments(tt.pattern, tt.name); got != tt.want {
+			t.Errorf("matchSegments(%v, %v) = %v, want %v", tt.pattern, tt.name, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,130 @@
+package jira
+
+import (
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const (
+	// AuthBasic authenticates with an email and API token on Cloud, or a
+	// username and password on Server (JIRA_EMAIL or JIRA_USERNAME, and
+	// JIRA_API_KEY or JIRA_PASSWORD)
+	AuthBasic = "basic"
+	// AuthPAT authenticates with a Server / Data Center personal access token (JIRA_PAT)
+	AuthPAT = "pat"
+	// AuthOAuth2 authenticates with an OAuth 2.0 (3LO) access token for a Cloud
+	// site (JIRA_OAUTH_TOKEN), calling it through the Atlassian API gateway
+	AuthOAuth2 = "oauth2"
+
+	// DeploymentCloud is Jira Cloud, where users are identified by accountId
+	DeploymentCloud = "cloud"
+	// DeploymentServer is Jira Server or Data Center, where users are identified
+	// by name and epics are linked through the Epic Link custom field
+	DeploymentServer = "server"
+
+	// oauthGatewayURL is the base URL OAuth 2.0 apps use to reach a Cloud site by its cloud ID
+	oauthGatewayURL = "https://api.atlassian.com/ex/jira/"
+)
+
+// Authenticator adds credentials to a Jira request
+type Authenticator interface {
+	Authorize(req *http.Request)
+}
+
+// BasicAuth authenticates with HTTP Basic credentials
+type BasicAuth struct {
+	Username string
+	Password string
+}
+
+// Authorize sets the Basic authorization header
+func (a BasicAuth) Authorize(req *http.Request) {
+	req.SetBasicAuth(a.Username, a.Password)
+}
+
+// BearerAuth authenticates with a bearer token: a personal access token or an
+// OAuth 2.0 access token
+type BearerAuth struct {
+	Token string
+}
+
+// Authorize sets the Bearer authorization header
+func (a BearerAuth) Authorize(req *http.Request) {
+	req.Header.Set("Authorization", "Bearer "+a.Token)
+}
+
+// authFromEnv builds the authenticator for an auth type from environment variables
+func authFromEnv(authType string) (Authenticator, error) {
+	switch authType {
+	case "", AuthBasic:
+		username := firstEnv("JIRA_EMAIL", "JIRA_USERNAME")
+		password := firstEnv("JIRA_API_KEY", "JIRA_PASSWORD")
+		if username == "" || password == "" {
+			return nil, fmt.Errorf("basic auth needs JIRA_EMAIL (or JIRA_USERNAME) and JIRA_API_KEY (or JIRA_PASSWORD)")
+		}
+		return BasicAuth{Username: username, Password: password}, nil
+
+	case AuthPAT:
+		token := os.Getenv("JIRA_PAT")
+		if token == "" {
+			return nil, fmt.Errorf("personal access token auth needs JIRA_PAT")
+		}
+		return BearerAuth{Token: token}, nil
+
+	case AuthOAuth2:
+		token := os.Getenv("JIRA_OAUTH_TOKEN")
+		if token == "" {
+			return nil, fmt.Errorf("OAuth 2.0 auth needs JIRA_OAUTH_TOKEN")
+		}
+		return BearerAuth{Token: token}, nil
+
+	default:
+		return nil, fmt.Errorf("unsupported auth type %q (use %q, %q or %q)", authType, AuthBasic, AuthPAT, AuthOAuth2)
+	}
+}
+
+// firstEnv returns the first non-empty environment variable of names
+func firstEnv(names ...string) string {
+	for _, name := range names {
+		if value := os.Getenv(name); value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+// oauthBaseURL returns the API gateway URL for a Cloud site's cloud ID
+func oauthBaseURL(cloudID string) string {
+	return oauthGatewayURL + strings.Trim(cloudID, "/")
+}
+
+// isServer reports whether the adapter talks to Jira Server or Data Center
+func (j *JiraAdapter) isServer() bool {
+	return j.deployment == DeploymentServer
+}
+
+// userFieldValue returns the payload identifying a user: accountId on Cloud,
+// name on Server
+func (j *JiraAdapter) userFieldValue(user string) interface{} {
+	if user == "" {
+		return nil
+	}
+	if j.isServer() {
+		return map[string]interface{}{"name": user}
+	}
+	return map[string]interface{}{"accountId": user}
+}
+
+// isUserField reports whether a field mapping refers to a user picker field
+func isUserField(mapping interface{}) bool {
+	switch m := mapping.(type) {
+	case string:
+		return m == "assignee" || m == "reporter"
+	case map[string]interface{}:
+		t, _ := m["type"].(string)
+		return t == "user"
+	}
+	return false
+}
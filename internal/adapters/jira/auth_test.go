@@ -0,0 +1,225 @@
+package jira
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+)
+
+// setJiraEnv sets the connection environment with every credential variable cleared
+func setJiraEnv(t *testing.T) {
+	t.Setenv("JIRA_URL", "https://jira.example.com")
+	t.Setenv("JIRA_PROJECT_KEY", "PROJ")
+	for _, name := range []string{"JIRA_EMAIL", "JIRA_USERNAME", "JIRA_API_KEY", "JIRA_PASSWORD", "JIRA_PAT", "JIRA_OAUTH_TOKEN", "JIRA_CLOUD_ID"} {
+		t.Setenv(name, "")
+	}
+}
+
+// TestNewJiraAdapterWithOptions_AuthTypes verifies each auth type reads its credentials and sets the Authorization header
+func TestNewJiraAdapterWithOptions_AuthTypes(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		opts    Options
+		header  string
+		baseURL string
+	}{
+		{"cloud api token", map[string]string{"JIRA_EMAIL": "me@example.com", "JIRA_API_KEY": "token"},
+			Options{}, "Basic bWVAZXhhbXBsZS5jb206dG9rZW4=", "https://jira.example.com"},
+		{"server password", map[string]string{"JIRA_USERNAME": "jdoe", "JIRA_PASSWORD": "secret"},
+			Options{Deployment: DeploymentServer, AuthType: AuthBasic}, "Basic amRvZTpzZWNyZXQ=", "https://jira.example.com"},
+		{"personal access token", map[string]string{"JIRA_PAT": "pat-123"},
+			Options{Deployment: DeploymentServer, AuthType: AuthPAT}, "Bearer pat-123", "https://jira.example.com"},
+		{"oauth2", map[string]string{"JIRA_OAUTH_TOKEN": "oauth-456"},
+			Options{AuthType: AuthOAuth2, CloudID: "abc-123"}, "Bearer oauth-456", "https://api.atlassian.com/ex/jira/abc-123"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			setJiraEnv(t)
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			port, err := NewJiraAdapterWithOptions(tt.opts)
+			if err != nil {
+				t.Fatalf("NewJiraAdapterWithOptions failed: %v", err)
+			}
+			adapter := port.(*JiraAdapter)
+			if adapter.baseURL != tt.baseURL {
+				t.Errorf("baseURL = %s, want %s", adapter.baseURL, tt.baseURL)
+			}
+
+			var header string
+			adapter.client = &http.Client{Transport: &MockRoundTripper{
+				RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+					header = req.Header.Get("Authorization")
+					return response(200, nil, `{}`), nil
+				},
+			}}
+			if err := adapter.Authenticate(context.Background()); err != nil {
+				t.Fatalf("Authenticate failed: %v", err)
+			}
+			if header != tt.header {
+				t.Errorf("Authorization = %q, want %q", header, tt.header)
+			}
+		})
+	}
+}
+
+// TestNewJiraAdapterWithOptions_RejectsInvalidAuth verifies missing credentials and Cloud-only options on Server are rejected
+func TestNewJiraAdapterWithOptions_RejectsInvalidAuth(t *testing.T) {
+	setJiraEnv(t)
+	t.Setenv("JIRA_OAUTH_TOKEN", "oauth-456")
+
+	tests := []struct {
+		name string
+		opts Options
+	}{
+		{"missing basic credentials", Options{}},
+		{"missing personal access token", Options{AuthType: AuthPAT}},
+		{"missing cloud id", Options{AuthType: AuthOAuth2}},
+		{"unknown auth type", Options{AuthType: "kerberos"}},
+		{"unknown deployment", Options{Deployment: "hybrid"}},
+		{"oauth2 on server", Options{Deployment: DeploymentServer, AuthType: AuthOAuth2, CloudID: "abc"}},
+		{"api v3 on server", Options{Deployment: DeploymentServer, APIVersion: APIVersion3, Auth: BearerAuth{Token: "t"}}},
+	}
+
+	for _, tt := range tests {
+		if _, err := NewJiraAdapterWithOptions(tt.opts); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+// TestBuildFieldsPayload_UserAndEpicFieldsByDeployment verifies users and epic links take each deployment's shape
+func TestBuildFieldsPayload_UserAndEpicFieldsByDeployment(t *testing.T) {
+	setJiraEnv(t)
+	t.Setenv("JIRA_PAT", "pat-123")
+	port, err := NewJiraAdapterWithOptions(Options{Deployment: DeploymentServer, AuthType: AuthPAT, EpicLinkField: "customfield_10008"})
+	if err != nil {
+		t.Fatalf("NewJiraAdapterWithOptions failed: %v", err)
+	}
+	server := port.(*JiraAdapter)
+	cloud := &JiraAdapter{projectKey: "PROJ", storyType: "Story", fieldMappings: getDefaultFieldMappings()}
+
+	customFields := map[string]string{"Assignee": "jdoe", "Epic Link": "PROJ-1"}
+
+	fields := server.buildFieldsPayload(customFields, "Title", "", nil)
+	if assignee, _ := fields["assignee"].(map[string]interface{}); assignee["name"] != "jdoe" {
+		t.Errorf("Server assignee = %#v, want a name", fields["assignee"])
+	}
+	if fields["customfield_10008"] != "PROJ-1" || fields["parent"] != nil {
+		t.Errorf("Server epic link = %#v, parent = %#v", fields["customfield_10008"], fields["parent"])
+	}
+
+	fields = cloud.buildFieldsPayload(customFields, "Title", "", nil)
+	if assignee, _ := fields["assignee"].(map[string]interface{}); assignee["accountId"] != "jdoe" {
+		t.Errorf("Cloud assignee = %#v, want an accountId", fields["assignee"])
+	}
+	if parent, _ := fields["parent"].(map[string]interface{}); parent["key"] != "PROJ-1" {
+		t.Errorf("Cloud parent = %#v, want the epic key", fields["parent"])
+	}
+
+	ticket := server.parseJiraIssue(map[string]interface{}{
+		"key": "PROJ-2",
+		"fields": map[string]interface{}{
+			"summary":           "Title",
+			"assignee":          map[string]interface{}{"name": "jdoe", "displayName": "Jane Doe"},
+			"customfield_10008": "PROJ-1",
+		},
+	})
+	if ticket.CustomFields["Assignee"] != "jdoe" || ticket.CustomFields["Epic Link"] != "PROJ-1" {
+		t.Errorf("Server pull fields = %v", ticket.CustomFields)
+	}
+
+	ticket = cloud.parseJiraIssue(map[string]interface{}{
+		"key":    "PROJ-3",
+		"fields": map[string]interface{}{"assignee": map[string]interface{}{"accountId": "5b10ac8d82e05b22cc7d4ef5", "displayName": "Jane Doe"}},
+	})
+	if ticket.CustomFields["Assignee"] != "Jane Doe" {
+		t.Errorf("Cloud assignee pulled as %q, want the display name", ticket.CustomFields["Assignee"])
+	}
+}
+
+// TestGetIssueTypeFields_ServerCreateMeta verifies Server reads fields from the paginated per-issue-type createmeta endpoints
+func TestGetIssueTypeFields_ServerCreateMeta(t *testing.T) {
+	var paths []string
+	mockTransport := &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			paths = append(paths, req.URL.Path+"?"+req.URL.RawQuery)
+			switch {
+			case req.URL.Path == "/rest/api/2/issue/createmeta/PROJ/issuetypes" && req.URL.Query().Get("startAt") == "0":
+				return response(200, nil, `{"startAt": 0, "total": 2, "isLast": false, "values": [{"id": "1", "name": "Bug"}]}`), nil
+			case req.URL.Path == "/rest/api/2/issue/createmeta/PROJ/issuetypes":
+				return response(200, nil, `{"startAt": 1, "total": 2, "isLast": true, "values": [{"id": "10001", "name": "Story"}]}`), nil
+			case req.URL.Path == "/rest/api/2/issue/createmeta/PROJ/issuetypes/10001":
+				return response(200, nil, `{"startAt": 0, "total": 2, "values": [
+					{"fieldId": "summary", "name": "Summary", "required": true, "schema": {"type": "string"}},
+					{"fieldId": "customfield_10008", "name": "Epic Link", "required": false, "schema": {"type": "any"}}
+				]}`), nil
+			}
+			return response(404, nil, `{}`), nil
+		},
+	}
+
+	adapter := &JiraAdapter{
+		baseURL:    "https://jira.example.com",
+		deployment: DeploymentServer,
+		projectKey: "PROJ",
+		client:     &http.Client{Transport: mockTransport},
+	}
+
+	result, err := adapter.GetIssueTypeFields(context.Background(), "Story")
+	if err != nil {
+		t.Fatalf("GetIssueTypeFields failed: %v (requests: %v)", err, paths)
+	}
+	fields := result["fields"].([]map[string]interface{})
+	if len(fields) != 2 {
+		t.Fatalf("Expected 2 fields, got %v", fields)
+	}
+	for _, field := range fields {
+		if field["key"] == "summary" && field["required"] != true {
+			t.Errorf("Summary should be required: %v", field)
+		}
+	}
+	if len(paths) != 3 || strings.Contains(strings.Join(paths, " "), "expand=") {
+		t.Errorf("Unexpected requests: %v", paths)
+	}
+
+	if _, err := adapter.GetIssueTypeFields(context.Background(), "Epic"); err == nil || !strings.Contains(err.Error(), "Story") {
+		t.Errorf("Expected an error listing the available types, got %v", err)
+	}
+}
+
+// TestUpdateTicket_ServerSendsUserName verifies an update sent to Server identifies the assignee by name
+func TestUpdateTicket_ServerSendsUserName(t *testing.T) {
+	mockTransport := &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			return response(204, nil, ``), nil
+		},
+	}
+	adapter := &JiraAdapter{
+		baseURL:       "https://jira.example.com",
+		auth:          BearerAuth{Token: "pat-123"},
+		deployment:    DeploymentServer,
+		projectKey:    "PROJ",
+		storyType:     "Story",
+		client:        &http.Client{Transport: mockTransport},
+		fieldMappings: getDefaultFieldMappings(),
+	}
+
+	ticket := domain.Ticket{JiraID: "PROJ-1", Title: "Title", CustomFields: map[string]string{"Assignee": "jdoe"}}
+	if err := adapter.UpdateTicket(context.Background(), ticket); err != nil {
+		t.Fatalf("UpdateTicket failed: %v", err)
+	}
+	if body := string(mockTransport.LastBody); !strings.Contains(body, `"assignee":{"name":"jdoe"}`) {
+		t.Errorf("Expected the assignee by name, got %s", body)
+	}
+	if got := mockTransport.LastRequest.Header.Get("Authorization"); got != "Bearer pat-123" {
+		t.Errorf("Authorization = %q", got)
+	}
+}
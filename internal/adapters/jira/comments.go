@@ -0,0 +1,76 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+	"github.com/karolswdev/ticktr/internal/markup"
+)
+
+// AddComment posts a Markdown comment on an issue and returns it as Jira stored it
+func (j *JiraAdapter) AddComment(ctx context.Context, issueKey string, comment domain.Comment) (domain.Comment, error) {
+	var body interface{} = markup.MarkdownToWiki(comment.Body)
+	if j.apiVersion == APIVersion3 {
+		body = markup.MarkdownToADF(comment.Body)
+	}
+
+	payload, err := json.Marshal(map[string]interface{}{"body": body})
+	if err != nil {
+		return domain.Comment{}, fmt.Errorf("failed to marshal payload: %w", err)
+	}
+
+	resp, err := j.do(ctx, "POST", j.restURL(fmt.Sprintf("issue/%s/comment", issueKey)), payload, false)
+	if err != nil {
+		return domain.Comment{}, err
+	}
+	if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+		return domain.Comment{}, fmt.Errorf("failed to add comment to %s with status %d: %s", issueKey, resp.StatusCode, string(resp.Body))
+	}
+
+	var created map[string]interface{}
+	if err := json.Unmarshal(resp.Body, &created); err != nil {
+		return domain.Comment{}, fmt.Errorf("failed to parse response: %w", err)
+	}
+	return parseComment(created), nil
+}
+
+// parseComments converts a Jira comment field into comments, oldest first
+func parseComments(value interface{}) []domain.Comment {
+	field, _ := value.(map[string]interface{})
+	entries, _ := field["comments"].([]interface{})
+
+	var comments []domain.Comment
+	for _, e := range entries {
+		if entry, ok := e.(map[string]interface{}); ok {
+			comments = append(comments, parseComment(entry))
+		}
+	}
+	return comments
+}
+
+// parseComment converts a Jira comment, whose body is wiki markup or an ADF
+// document, to a comment with a Markdown body
+func parseComment(entry map[string]interface{}) domain.Comment {
+	comment := domain.Comment{
+		ID:      stringValue(entry["id"]),
+		Created: stringValue(entry["created"]),
+	}
+	if author, ok := entry["author"].(map[string]interface{}); ok {
+		comment.Author = stringValue(author["displayName"])
+		if comment.Author == "" {
+			comment.Author = stringValue(author["name"])
+		}
+	}
+
+	if markup.IsADF(entry["body"]) {
+		comment.Body = markup.ADFToMarkdown(entry["body"])
+	} else {
+		comment.Body = markup.WikiToMarkdown(stringValue(entry["body"]))
+	}
+	comment.Body = strings.TrimSpace(comment.Body)
+	return comment
+}
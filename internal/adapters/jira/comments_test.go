@@ -0,0 +1,74 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+)
+
+// TestAddComment_PostsMarkdownAsWiki verifies comments are sent as wiki markup on v2 and returned as stored
+func TestAddComment_PostsMarkdownAsWiki(t *testing.T) {
+	mockTransport := &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Method != "POST" || req.URL.Path != "/rest/api/2/issue/PROJ-1/comment" {
+				return response(404, nil, `{}`), nil
+			}
+			return response(201, nil, `{"id": "10005", "author": {"displayName": "Jane Doe"},
+				"created": "2024-01-02T10:00:00.000+0000", "body": "Is *this* in scope?"}`), nil
+		},
+	}
+	adapter := &JiraAdapter{baseURL: "https://test.atlassian.net", client: &http.Client{Transport: mockTransport}}
+
+	comment, err := adapter.AddComment(context.Background(), "PROJ-1", domain.Comment{Body: "Is **this** in scope?"})
+	if err != nil {
+		t.Fatalf("AddComment failed: %v", err)
+	}
+
+	var payload map[string]interface{}
+	json.Unmarshal(mockTransport.LastBody, &payload)
+	if payload["body"] != "Is *this* in scope?" {
+		t.Errorf("Expected a wiki markup body, got %v", payload["body"])
+	}
+
+	expected := domain.Comment{ID: "10005", Author: "Jane Doe", Created: "2024-01-02T10:00:00.000+0000", Body: "Is **this** in scope?"}
+	if comment != expected {
+		t.Errorf("Expected %+v, got %+v", expected, comment)
+	}
+}
+
+// TestParseJiraIssue_Comments verifies pulled comments carry their author, timestamp and Markdown body
+func TestParseJiraIssue_Comments(t *testing.T) {
+	adapter := &JiraAdapter{apiVersion: APIVersion3, fieldMappings: getDefaultFieldMappings()}
+
+	ticket := adapter.parseJiraIssue(map[string]interface{}{
+		"key": "PROJ-1",
+		"fields": map[string]interface{}{
+			"summary": "Refunds",
+			"comment": map[string]interface{}{
+				"comments": []interface{}{
+					map[string]interface{}{
+						"id":      "10001",
+						"author":  map[string]interface{}{"accountId": "5b10ac8d", "displayName": "Jane Doe"},
+						"created": "2024-01-02T10:00:00.000+0000",
+						"body": map[string]interface{}{"type": "doc", "version": float64(1), "content": []interface{}{
+							map[string]interface{}{"type": "paragraph", "content": []interface{}{
+								map[string]interface{}{"type": "text", "text": "Partial refunds too?"},
+							}},
+						}},
+					},
+				},
+			},
+		},
+	})
+
+	expected := []domain.Comment{{ID: "10001", Author: "Jane Doe", Created: "2024-01-02T10:00:00.000+0000", Body: "Partial refunds too?"}}
+	if len(ticket.Comments) != 1 || ticket.Comments[0] != expected[0] {
+		t.Errorf("Expected %+v, got %+v", expected, ticket.Comments)
+	}
+	if _, ok := ticket.CustomFields["comment"]; ok {
+		t.Errorf("Comments should not be a custom field, got %v", ticket.CustomFields)
+	}
+}
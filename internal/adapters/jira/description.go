@@ -0,0 +1,93 @@
+package jira
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/karolswdev/ticktr/internal/markup"
+)
+
+const (
+	// APIVersion2 is the Jira REST API v2, which stores descriptions as wiki markup
+	APIVersion2 = "2"
+	// APIVersion3 is the Jira Cloud REST API v3, which stores descriptions as
+	// Atlassian Document Format
+	APIVersion3 = "3"
+
+	// acceptanceCriteriaHeading titles the description section holding acceptance criteria
+	acceptanceCriteriaHeading = "Acceptance Criteria"
+)
+
+// restURL returns the URL of a REST API resource for the configured API version
+func (j *JiraAdapter) restURL(path string) string {
+	version := j.apiVersion
+	if version == "" {
+		version = APIVersion2
+	}
+	return fmt.Sprintf("%s/rest/api/%s/%s", j.baseURL, version, path)
+}
+
+// formatDescription builds the description field value from Markdown, with
+// acceptance criteria appended as a section. API v3 takes an ADF document;
+// API v2 takes wiki markup.
+func (j *JiraAdapter) formatDescription(description string, acceptanceCriteria []string) interface{} {
+	blocks := markup.ParseMarkdown(description)
+	if len(acceptanceCriteria) > 0 {
+		list := markup.Block{Kind: markup.BulletList}
+		for _, ac := range acceptanceCriteria {
+			list.Items = append(list.Items, []markup.Block{{Kind: markup.Paragraph, Spans: markup.ParseInline(ac)}})
+		}
+		blocks = append(blocks,
+			markup.Block{Kind: markup.Heading, Level: 3, Spans: []markup.Span{{Text: acceptanceCriteriaHeading}}},
+			list)
+	}
+
+	if j.apiVersion != APIVersion3 {
+		return markup.RenderWiki(blocks)
+	}
+	if len(blocks) == 0 {
+		return nil
+	}
+	return markup.ToADF(blocks)
+}
+
+// parseDescription converts a description field value to Markdown and splits
+// off its acceptance criteria. Wiki markup strings and ADF documents are both
+// understood.
+func parseDescription(value interface{}) (string, []string) {
+	if markup.IsADF(value) {
+		return splitAcceptanceCriteria(markup.FromADF(value))
+	}
+
+	description, ok := value.(string)
+	if !ok {
+		return "", nil
+	}
+	return splitAcceptanceCriteria(markup.ParseWiki(description))
+}
+
+// splitAcceptanceCriteria renders description blocks as Markdown, taking the
+// items of the lists under the acceptance criteria heading as acceptance criteria
+func splitAcceptanceCriteria(blocks []markup.Block) (string, []string) {
+	for i, block := range blocks {
+		if block.Kind != markup.Heading || strings.TrimSpace(markup.PlainText(block.Spans)) != acceptanceCriteriaHeading {
+			continue
+		}
+
+		var acceptanceCriteria []string
+		for _, section := range blocks[i+1:] {
+			if section.Kind == markup.Heading {
+				break
+			}
+			if section.Kind != markup.BulletList && section.Kind != markup.OrderedList {
+				continue
+			}
+			for _, item := range section.Items {
+				acceptanceCriteria = append(acceptanceCriteria, markup.RenderMarkdown(item))
+			}
+		}
+		return markup.RenderMarkdown(blocks[:i]), acceptanceCriteria
+	}
+
+	return markup.RenderMarkdown(blocks), nil
+}
@@ -0,0 +1,156 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+	"github.com/karolswdev/ticktr/internal/markup"
+	"github.com/karolswdev/ticktr/internal/state"
+)
+
+// TestBuildFieldsPayload_APIVersion3SendsADF verifies v3 descriptions are ADF documents ending with the acceptance criteria
+func TestBuildFieldsPayload_APIVersion3SendsADF(t *testing.T) {
+	adapter := &JiraAdapter{projectKey: "PROJ", storyType: "Story", apiVersion: APIVersion3, fieldMappings: getDefaultFieldMappings()}
+
+	fields := adapter.buildFieldsPayload(nil, "Title", "Intro with **bold**\n\n## Notes\n\n- a\n  - b", []string{"Works `offline`"})
+
+	doc, ok := fields["description"].(map[string]interface{})
+	if !ok || !markup.IsADF(doc) {
+		t.Fatalf("Expected an ADF description, got %#v", fields["description"])
+	}
+
+	content := doc["content"].([]interface{})
+	var types []string
+	for _, node := range content {
+		types = append(types, node.(map[string]interface{})["type"].(string))
+	}
+	if strings.Join(types, ",") != "paragraph,heading,bulletList,heading,bulletList" {
+		t.Errorf("Unexpected block sequence: %v", types)
+	}
+
+	want := "Intro with **bold**\n\n## Notes\n\n- a\n  - b\n\n### Acceptance Criteria\n\n- Works `offline`"
+	if got := markup.ADFToMarkdown(doc); got != want {
+		t.Errorf("Description converts back to:\n%s\nwant:\n%s", got, want)
+	}
+}
+
+// TestParseJiraIssue_APIVersion3ConvertsADF verifies ADF descriptions are split into Markdown and acceptance criteria
+func TestParseJiraIssue_APIVersion3ConvertsADF(t *testing.T) {
+	adapter := &JiraAdapter{apiVersion: APIVersion3, fieldMappings: getDefaultFieldMappings()}
+	description := "Use the [API](https://example.com/api).\n\n```json\n{\"a\": 1}\n```\n\n| Key | Value |\n| --- | --- |\n| a | 1 |"
+	criteria := []string{"Returns **200**", "Logs the request"}
+
+	var decoded map[string]interface{}
+	encoded, _ := json.Marshal(adapter.formatDescription(description, criteria))
+	json.Unmarshal(encoded, &decoded)
+
+	ticket := adapter.parseJiraIssue(map[string]interface{}{
+		"key":    "PROJ-1",
+		"fields": map[string]interface{}{"summary": "Title", "description": decoded},
+	})
+	if ticket.Description != description {
+		t.Errorf("Description =\n%s\nwant\n%s", ticket.Description, description)
+	}
+	if strings.Join(ticket.AcceptanceCriteria, "|") != strings.Join(criteria, "|") {
+		t.Errorf("AcceptanceCriteria = %v, want %v", ticket.AcceptanceCriteria, criteria)
+	}
+
+	task := adapter.parseJiraSubtask(map[string]interface{}{
+		"key":    "PROJ-2",
+		"fields": map[string]interface{}{"summary": "Task", "description": decoded},
+	})
+	if task.Description != description || len(task.AcceptanceCriteria) != 2 {
+		t.Errorf("Unexpected subtask description %q and criteria %v", task.Description, task.AcceptanceCriteria)
+	}
+}
+
+// TestCreateTask_APIVersion3UsesV3Endpoint verifies v3 requests go to /rest/api/3 with a single acceptance criteria section
+func TestCreateTask_APIVersion3UsesV3Endpoint(t *testing.T) {
+	var path string
+	var body []byte
+	mockTransport := &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			path = req.URL.Path
+			body, _ = io.ReadAll(req.Body)
+			return response(201, nil, `{"key": "PROJ-3"}`), nil
+		},
+	}
+
+	adapter := &JiraAdapter{
+		baseURL:       "https://test.atlassian.net",
+		projectKey:    "PROJ",
+		subTaskType:   "Sub-task",
+		apiVersion:    APIVersion3,
+		client:        &http.Client{Transport: mockTransport},
+		fieldMappings: getDefaultFieldMappings(),
+	}
+
+	task := domain.Task{Title: "Task", Description: "Do it", AcceptanceCriteria: []string{"Done"}}
+	if _, err := adapter.CreateTask(context.Background(), task, "PROJ-1"); err != nil {
+		t.Fatalf("CreateTask failed: %v", err)
+	}
+	if path != "/rest/api/3/issue" {
+		t.Errorf("Expected the v3 issue endpoint, got %s", path)
+	}
+	if n := strings.Count(string(body), acceptanceCriteriaHeading); n != 1 {
+		t.Errorf("Expected one acceptance criteria section, found %d in %s", n, body)
+	}
+}
+
+// TestBuildTaskCreateFields_SingleAcceptanceCriteriaSection verifies v2 task descriptions list acceptance criteria once
+func TestBuildTaskCreateFields_SingleAcceptanceCriteriaSection(t *testing.T) {
+	adapter := &JiraAdapter{projectKey: "PROJ", subTaskType: "Sub-task", fieldMappings: getDefaultFieldMappings()}
+
+	fields := adapter.buildTaskCreateFields(domain.Task{Title: "Task", Description: "Do it", AcceptanceCriteria: []string{"Done"}}, "PROJ-1")
+
+	want := "Do it\n\nh3. Acceptance Criteria\n\n* Done"
+	if fields["description"] != want {
+		t.Errorf("description = %q, want %q", fields["description"], want)
+	}
+}
+
+// TestBuildFieldsPayload_APIVersion2SendsWikiMarkup verifies v2 descriptions are converted from Markdown to wiki markup
+func TestBuildFieldsPayload_APIVersion2SendsWikiMarkup(t *testing.T) {
+	adapter := &JiraAdapter{projectKey: "PROJ", storyType: "Story", fieldMappings: getDefaultFieldMappings()}
+
+	fields := adapter.buildFieldsPayload(nil, "Title", "Intro with **bold** and [docs](https://example.com)\n\n### Steps\n\n```go\nrun()\n```", []string{"Returns `200`"})
+
+	want := "Intro with *bold* and [docs|https://example.com]\n\nh3. Steps\n\n{code:go}\nrun()\n{code}\n\nh3. Acceptance Criteria\n\n* Returns {{200}}"
+	if fields["description"] != want {
+		t.Errorf("description =\n%s\nwant\n%s", fields["description"], want)
+	}
+}
+
+// TestParseJiraIssue_APIVersion2RoundTrip verifies a ticket pushed as wiki markup pulls back with the same Markdown and hash
+func TestParseJiraIssue_APIVersion2RoundTrip(t *testing.T) {
+	adapter := &JiraAdapter{fieldMappings: getDefaultFieldMappings()}
+	ticket := domain.Ticket{
+		Title:              "Title",
+		Description:        "Use the *new* [API](https://example.com/api).\n\n## Notes\n\n1. first\n2. second\n\n| Key | Value |\n| --- | --- |\n| a | `1` |",
+		AcceptanceCriteria: []string{"Returns **200**", "Logs snake_case names"},
+	}
+
+	pulled := adapter.parseJiraIssue(map[string]interface{}{
+		"key": "PROJ-1",
+		"fields": map[string]interface{}{
+			"summary":     ticket.Title,
+			"description": adapter.formatDescription(ticket.Description, ticket.AcceptanceCriteria),
+		},
+	})
+	if pulled.Description != ticket.Description {
+		t.Errorf("Description =\n%s\nwant\n%s", pulled.Description, ticket.Description)
+	}
+	if strings.Join(pulled.AcceptanceCriteria, "|") != strings.Join(ticket.AcceptanceCriteria, "|") {
+		t.Errorf("AcceptanceCriteria = %v, want %v", pulled.AcceptanceCriteria, ticket.AcceptanceCriteria)
+	}
+
+	sm := state.NewStateManager(t.TempDir() + "/.ticketr.state")
+	if sm.CalculateHash(pulled) != sm.CalculateHash(ticket) {
+		t.Error("Pulled ticket hashes differently from the pushed one")
+	}
+}
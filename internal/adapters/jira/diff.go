@@ -0,0 +1,168 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+	"github.com/karolswdev/ticktr/internal/markup"
+)
+
+// DiffTicket compares the payload CreateTicket/UpdateTicket would send with the
+// values currently stored in Jira. New tickets are diffed against empty values.
+func (j *JiraAdapter) DiffTicket(ctx context.Context, ticket domain.Ticket) ([]domain.FieldChange, error) {
+	var err error
+	if ticket.CustomFields, err = j.resolveUsers(ctx, ticket.CustomFields); err != nil {
+		return nil, err
+	}
+
+	if ticket.JiraID == "" {
+		fields := j.buildFieldsPayload(ticket.CustomFields, ticket.Title, ticket.Description, ticket.AcceptanceCriteria)
+		addStatus(fields, ticket.Status)
+		return j.diffFields(fields, nil), nil
+	}
+
+	fields := j.buildTicketUpdateFields(ticket)
+	addStatus(fields, ticket.Status)
+	current, err := j.getIssueFields(ctx, ticket.JiraID, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return j.diffFields(fields, current), nil
+}
+
+// DiffTask compares the payload CreateTask/UpdateTask would send with the
+// values currently stored in Jira. New tasks are diffed against empty values.
+func (j *JiraAdapter) DiffTask(ctx context.Context, task domain.Task, parentID string) ([]domain.FieldChange, error) {
+	var err error
+	if task.CustomFields, err = j.resolveUsers(ctx, task.CustomFields); err != nil {
+		return nil, err
+	}
+
+	if task.JiraID == "" {
+		fields := j.buildTaskCreateFields(task, parentID)
+		addStatus(fields, task.Status)
+		return j.diffFields(fields, nil), nil
+	}
+
+	fields := j.buildTaskUpdateFields(task)
+	addStatus(fields, task.Status)
+	current, err := j.getIssueFields(ctx, task.JiraID, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	return j.diffFields(fields, current), nil
+}
+
+// addStatus adds the status a push transitions to, so it is diffed like a
+// field even though it is not sent with the fields
+func addStatus(fields map[string]interface{}, status string) {
+	if status != "" {
+		fields["status"] = map[string]interface{}{"name": status}
+	}
+}
+
+// getIssueFields fetches the current values of the payload's fields for an issue
+func (j *JiraAdapter) getIssueFields(ctx context.Context, issueKey string, payload map[string]interface{}) (map[string]interface{}, error) {
+	fieldIDs := make([]string, 0, len(payload))
+	for id := range payload {
+		fieldIDs = append(fieldIDs, id)
+	}
+	sort.Strings(fieldIDs)
+
+	endpoint := j.restURL(fmt.Sprintf("issue/%s?fields=%s", issueKey, url.QueryEscape(strings.Join(fieldIDs, ","))))
+	resp, err := j.do(ctx, "GET", endpoint, nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get issue %s with status %d: %s", issueKey, resp.StatusCode, string(resp.Body))
+	}
+
+	var issue map[string]interface{}
+	if err := json.Unmarshal(resp.Body, &issue); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	fields, _ := issue["fields"].(map[string]interface{})
+	return fields, nil
+}
+
+// diffFields returns the payload fields whose display value differs from the current value
+func (j *JiraAdapter) diffFields(payload map[string]interface{}, current map[string]interface{}) []domain.FieldChange {
+	reverseMapping := j.createReverseFieldMapping()
+
+	fieldIDs := make([]string, 0, len(payload))
+	for id := range payload {
+		fieldIDs = append(fieldIDs, id)
+	}
+	sort.Strings(fieldIDs)
+
+	changes := []domain.FieldChange{}
+	for _, id := range fieldIDs {
+		proposed := displayValue(payload[id])
+		existing := displayValue(current[id])
+		if proposed == existing {
+			continue
+		}
+
+		label := id
+		if humanName, ok := reverseMapping[id]; ok && humanName != id {
+			label = fmt.Sprintf("%s (%s)", humanName, id)
+		}
+
+		changes = append(changes, domain.FieldChange{
+			Field:    label,
+			Current:  existing,
+			Proposed: proposed,
+		})
+	}
+
+	return changes
+}
+
+// displayValue flattens a Jira payload or response value into comparable text
+func displayValue(value interface{}) string {
+	switch v := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return strings.TrimSpace(v)
+	case float64:
+		return fmt.Sprintf("%g", v)
+	case []string:
+		return strings.Join(v, ", ")
+	case []interface{}:
+		parts := make([]string, 0, len(v))
+		for _, item := range v {
+			if s := displayValue(item); s != "" {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, ", ")
+	case map[string]interface{}:
+		// Rich text descriptions compare as Markdown
+		if markup.IsADF(v) {
+			return markup.ADFToMarkdown(v)
+		}
+		// Objects such as project, issuetype, priority or users; Cloud users
+		// compare by the accountId push sends
+		for _, key := range []string{"key", "name", "value", "accountId", "displayName"} {
+			if s, ok := v[key].(string); ok {
+				return s
+			}
+		}
+		encoded, _ := json.Marshal(v)
+		return string(encoded)
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,110 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+)
+
+// TestJiraAdapter_DiffTicket_ExistingIssue verifies only changed fields are reported
+func TestJiraAdapter_DiffTicket_ExistingIssue(t *testing.T) {
+	mockTransport := &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			if req.Method != "GET" {
+				t.Errorf("DiffTicket must only read from Jira, got %s request", req.Method)
+			}
+			responseBody := `{
+				"key": "PROJ-1",
+				"fields": {
+					"summary": "Same title",
+					"description": "Old description",
+					"priority": {"name": "High", "id": "2"},
+					"labels": ["backend", "api"]
+				}
+			}`
+			return &http.Response{
+				StatusCode: 200,
+				Body:       io.NopCloser(bytes.NewBufferString(responseBody)),
+			}, nil
+		},
+	}
+
+	adapter := &JiraAdapter{
+		baseURL:       "https://test.atlassian.net",
+		auth:          BasicAuth{Username: "test@example.com", Password: "test-api-key"},
+		projectKey:    "PROJ",
+		storyType:     "Task",
+		client:        &http.Client{Transport: mockTransport},
+		fieldMappings: getDefaultFieldMappings(),
+	}
+
+	changes, err := adapter.DiffTicket(context.Background(), domain.Ticket{
+		JiraID:      "PROJ-1",
+		Title:       "Same title",
+		Description: "New description",
+		CustomFields: map[string]string{
+			"Priority": "High",
+			"Labels":   "backend, api",
+		},
+	})
+	if err != nil {
+		t.Fatalf("DiffTicket returned error: %v", err)
+	}
+
+	if !strings.HasPrefix(mockTransport.LastRequest.URL.Path, "/rest/api/2/issue/PROJ-1") {
+		t.Errorf("Unexpected request path: %s", mockTransport.LastRequest.URL.Path)
+	}
+
+	if len(changes) != 1 {
+		t.Fatalf("Expected 1 change, got %d: %+v", len(changes), changes)
+	}
+	if changes[0].Field != "Description (description)" {
+		t.Errorf("Expected description change, got %s", changes[0].Field)
+	}
+	if changes[0].Current != "Old description" || changes[0].Proposed != "New description" {
+		t.Errorf("Unexpected change values: %+v", changes[0])
+	}
+}
+
+// TestJiraAdapter_DiffTicket_NewIssue verifies new tickets are diffed without calling Jira
+func TestJiraAdapter_DiffTicket_NewIssue(t *testing.T) {
+	mockTransport := &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			t.Fatal("DiffTicket should not call Jira for a new ticket")
+			return nil, nil
+		},
+	}
+
+	adapter := &JiraAdapter{
+		baseURL:       "https://test.atlassian.net",
+		projectKey:    "PROJ",
+		storyType:     "Story",
+		client:        &http.Client{Transport: mockTransport},
+		fieldMappings: getDefaultFieldMappings(),
+	}
+
+	changes, err := adapter.DiffTicket(context.Background(), domain.Ticket{Title: "Brand new"})
+	if err != nil {
+		t.Fatalf("DiffTicket returned error: %v", err)
+	}
+
+	proposed := map[string]string{}
+	for _, change := range changes {
+		if change.Current != "" {
+			t.Errorf("Expected empty current value for new ticket, got %+v", change)
+		}
+		proposed[change.Field] = change.Proposed
+	}
+
+	if proposed["Summary (summary)"] != "Brand new" {
+		t.Errorf("Expected summary in plan, got %v", proposed)
+	}
+	if proposed["Type (issuetype)"] != "Story" || proposed["Project (project)"] != "PROJ" {
+		t.Errorf("Expected default project and issue type in plan, got %v", proposed)
+	}
+}
@@ -1,14 +1,14 @@
 package jira
 
 import (
-	"bytes"
-	"encoding/base64"
+	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"net/http"
 	"os"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/karolswdev/ticktr/internal/core/domain"
 	"github.com/karolswdev/ticktr/internal/core/ports"
@@ -17,13 +17,84 @@ import (
 // JiraAdapter implements the JiraPort interface for Jira API integration
 type JiraAdapter struct {
 	baseURL       string
-	email         string
-	apiKey        string
+	auth          Authenticator // Credentials added to every request (none when nil)
+	deployment    string        // DeploymentCloud or DeploymentServer (DeploymentCloud when empty)
 	projectKey    string
 	storyType     string
 	subTaskType   string
 	client        *http.Client
 	fieldMappings map[string]interface{} // Maps human-readable names to JIRA field IDs
+
+	pageSize       int    // Issues requested per search page (defaultPageSize when zero)
+	maxResults     int    // Cap on issues fetched per search (unlimited when zero)
+	searchEndpoint string // SearchEndpointClassic or SearchEndpointJQL
+	apiVersion     string // APIVersion2 or APIVersion3 (APIVersion2 when empty)
+
+	retry          RetryPolicy         // Retry limits for transient failures (no retries when zero)
+	requestTimeout time.Duration       // Deadline for a single HTTP attempt (none when zero)
+	sleep          func(time.Duration) // Waits between retries (time.Sleep when nil)
+	rateMu         sync.Mutex          // Guards throttleUntil
+	throttleUntil  time.Time           // Reset time of an exhausted rate limit window
+
+	linkTypesMu sync.Mutex // Guards linkTypes
+	linkTypes   []linkType // Issue link types, fetched on first use
+
+	transitionsMu     sync.Mutex              // Guards statusTransitions
+	statusTransitions map[string][]transition // Transitions out of each issue type's statuses, learned on use
+
+	usersMu    sync.Mutex        // Guards accountIDs
+	accountIDs map[string]string // Cloud account IDs of the users named in tickets, looked up on use
+}
+
+const (
+	// SearchEndpointClassic is the startAt/total paginated /search endpoint
+	SearchEndpointClassic = "search"
+	// SearchEndpointJQL is the nextPageToken paginated /search/jql endpoint
+	SearchEndpointJQL = "search/jql"
+
+	// defaultPageSize is the number of issues requested per search page
+	defaultPageSize = 100
+
+	// DefaultRequestTimeout bounds a single HTTP attempt when no timeout is configured
+	DefaultRequestTimeout = 30 * time.Second
+
+	// subtaskBatchSize is the number of parent keys per `parent in (...)` subtask search
+	subtaskBatchSize = 50
+
+	// EpicLinkFieldName is the Markdown field holding the key of a ticket's epic
+	EpicLinkFieldName = "Epic Link"
+)
+
+// Options configures a JiraAdapter beyond the connection environment variables
+type Options struct {
+	// FieldMappings maps human-readable field names to Jira field IDs (defaults when nil)
+	FieldMappings map[string]interface{}
+	// PageSize is the number of issues requested per search page (default 100)
+	PageSize int
+	// MaxResults caps the total number of issues fetched by a single search (0 means no cap)
+	MaxResults int
+	// SearchEndpoint selects SearchEndpointClassic (default) or SearchEndpointJQL
+	SearchEndpoint string
+	// APIVersion selects APIVersion2 (default, wiki markup descriptions) or
+	// APIVersion3 (Atlassian Document Format descriptions)
+	APIVersion string
+	// Deployment selects DeploymentCloud (default) or DeploymentServer for
+	// Jira Server and Data Center
+	Deployment string
+	// AuthType selects AuthBasic (default), AuthPAT or AuthOAuth2; the
+	// credentials are read from environment variables
+	AuthType string
+	// Auth overrides AuthType with custom credentials
+	Auth Authenticator
+	// CloudID identifies the Cloud site for AuthOAuth2 (JIRA_CLOUD_ID when empty)
+	CloudID string
+	// EpicLinkField is the Server Epic Link custom field ID, e.g. customfield_10008.
+	// Cloud links epics through the parent field instead.
+	EpicLinkField string
+	// Retry limits retries of transient failures (DefaultRetryPolicy when nil)
+	Retry *RetryPolicy
+	// RequestTimeout bounds a single HTTP attempt (DefaultRequestTimeout when zero)
+	RequestTimeout time.Duration
 }
 
 // NewJiraAdapter creates a new instance of JiraAdapter using environment variables
@@ -33,46 +104,152 @@ func NewJiraAdapter() (ports.JiraPort, error) {
 
 // NewJiraAdapterWithConfig creates a new instance of JiraAdapter with custom field mappings
 func NewJiraAdapterWithConfig(fieldMappings map[string]interface{}) (ports.JiraPort, error) {
+	return NewJiraAdapterWithOptions(Options{FieldMappings: fieldMappings})
+}
+
+// NewJiraAdapterWithOptions creates a new instance of JiraAdapter with the given options
+func NewJiraAdapterWithOptions(opts Options) (ports.JiraPort, error) {
 	baseURL := os.Getenv("JIRA_URL")
-	email := os.Getenv("JIRA_EMAIL")
-	apiKey := os.Getenv("JIRA_API_KEY")
 	projectKey := os.Getenv("JIRA_PROJECT_KEY")
 
-	if baseURL == "" || email == "" || apiKey == "" || projectKey == "" {
-		return nil, fmt.Errorf("missing required environment variables: JIRA_URL, JIRA_EMAIL, JIRA_API_KEY, JIRA_PROJECT_KEY")
+	if (baseURL == "" && opts.AuthType != AuthOAuth2) || projectKey == "" {
+		return nil, fmt.Errorf("missing required environment variables: JIRA_URL, JIRA_PROJECT_KEY")
 	}
 
-	// Get issue types from environment, with sensible defaults
-	storyType := os.Getenv("JIRA_STORY_TYPE")
-	if storyType == "" {
-		storyType = "Task" // Default to Task which is more common
+	switch opts.Deployment {
+	case "", DeploymentCloud, DeploymentServer:
+	default:
+		return nil, fmt.Errorf("unsupported Jira deployment %q (use %q or %q)", opts.Deployment, DeploymentCloud, DeploymentServer)
+	}
+	if opts.Deployment == DeploymentServer {
+		if opts.APIVersion == APIVersion3 {
+			return nil, fmt.Errorf("Jira Server and Data Center only support API version %q", APIVersion2)
+		}
+		if opts.SearchEndpoint == SearchEndpointJQL {
+			return nil, fmt.Errorf("the %q search endpoint is only available on Jira Cloud", SearchEndpointJQL)
+		}
+		if opts.AuthType == AuthOAuth2 {
+			return nil, fmt.Errorf("OAuth 2.0 auth is only available on Jira Cloud")
+		}
 	}
 
-	subTaskType := os.Getenv("JIRA_SUBTASK_TYPE")
-	if subTaskType == "" {
-		subTaskType = "Sub-task" // Standard JIRA subtask type
+	auth := opts.Auth
+	if auth == nil {
+		var err error
+		if auth, err = authFromEnv(opts.AuthType); err != nil {
+			return nil, fmt.Errorf("missing Jira credentials: %w", err)
+		}
 	}
 
-	// If no field mappings provided, use defaults
-	if fieldMappings == nil {
-		fieldMappings = getDefaultFieldMappings()
+	if opts.AuthType == AuthOAuth2 {
+		// OAuth 2.0 apps reach the site through the API gateway, not its own URL
+		cloudID := opts.CloudID
+		if cloudID == "" {
+			cloudID = os.Getenv("JIRA_CLOUD_ID")
+		}
+		if cloudID == "" {
+			return nil, fmt.Errorf("OAuth 2.0 auth needs the site's cloud ID (jira.auth.cloud_id or JIRA_CLOUD_ID)")
+		}
+		baseURL = oauthBaseURL(cloudID)
+	}
+
+	storyType, subTaskType := IssueTypes()
+
+	if opts.PageSize < 0 || opts.MaxResults < 0 {
+		return nil, fmt.Errorf("search page size and max results must not be negative")
+	}
+
+	switch opts.SearchEndpoint {
+	case "", SearchEndpointClassic, SearchEndpointJQL:
+	default:
+		return nil, fmt.Errorf("unsupported search endpoint %q (use %q or %q)", opts.SearchEndpoint, SearchEndpointClassic, SearchEndpointJQL)
+	}
+
+	switch opts.APIVersion {
+	case "", APIVersion2, APIVersion3:
+	default:
+		return nil, fmt.Errorf("unsupported Jira API version %q (use %q or %q)", opts.APIVersion, APIVersion2, APIVersion3)
 	}
 
+	requestTimeout := opts.RequestTimeout
+	if requestTimeout < 0 {
+		return nil, fmt.Errorf("request timeout must not be negative")
+	}
+	if requestTimeout == 0 {
+		requestTimeout = DefaultRequestTimeout
+	}
+
+	retry := DefaultRetryPolicy()
+	if opts.Retry != nil {
+		retry = *opts.Retry
+	}
+	if retry.MaxRetries < 0 || retry.InitialBackoff < 0 || retry.MaxBackoff < 0 {
+		return nil, fmt.Errorf("retry limits must not be negative")
+	}
+
+	fieldMappings := FieldMappings(opts)
+
 	// Ensure base URL doesn't have trailing slash
 	baseURL = strings.TrimRight(baseURL, "/")
 
 	return &JiraAdapter{
 		baseURL:       baseURL,
-		email:         email,
-		apiKey:        apiKey,
+		auth:          auth,
+		deployment:    opts.Deployment,
 		projectKey:    projectKey,
 		storyType:     storyType,
 		subTaskType:   subTaskType,
 		client:        &http.Client{},
 		fieldMappings: fieldMappings,
+
+		pageSize:       opts.PageSize,
+		maxResults:     opts.MaxResults,
+		searchEndpoint: opts.SearchEndpoint,
+		apiVersion:     opts.APIVersion,
+
+		retry:          retry,
+		requestTimeout: requestTimeout,
 	}, nil
 }
 
+// IssueTypes returns the issue types tickets without a Type field and tasks
+// are created as, from JIRA_STORY_TYPE and JIRA_SUBTASK_TYPE
+func IssueTypes() (storyType, subTaskType string) {
+	storyType = os.Getenv("JIRA_STORY_TYPE")
+	if storyType == "" {
+		storyType = "Task" // Default to Task which is more common
+	}
+
+	subTaskType = os.Getenv("JIRA_SUBTASK_TYPE")
+	if subTaskType == "" {
+		subTaskType = "Sub-task" // Standard JIRA subtask type
+	}
+	return storyType, subTaskType
+}
+
+// FieldMappings returns the field mappings an adapter with opts sends fields
+// through: the configured ones or the defaults, plus the Epic Link field on
+// Jira Server
+func FieldMappings(opts Options) map[string]interface{} {
+	// If no field mappings provided, use defaults
+	fieldMappings := opts.FieldMappings
+	if fieldMappings == nil {
+		fieldMappings = getDefaultFieldMappings()
+	}
+	if opts.Deployment == DeploymentServer && opts.EpicLinkField != "" {
+		if _, mapped := fieldMappings[EpicLinkFieldName]; !mapped {
+			// Map the Epic Link field like any custom field, without changing the caller's map
+			withEpicLink := make(map[string]interface{}, len(fieldMappings)+1)
+			for name, mapping := range fieldMappings {
+				withEpicLink[name] = mapping
+			}
+			withEpicLink[EpicLinkFieldName] = opts.EpicLinkField
+			fieldMappings = withEpicLink
+		}
+	}
+	return fieldMappings
+}
+
 // getDefaultFieldMappings returns default field mappings for JIRA
 func getDefaultFieldMappings() map[string]interface{} {
 	return map[string]interface{}{
@@ -94,63 +271,30 @@ func getDefaultFieldMappings() map[string]interface{} {
 	}
 }
 
-// getAuthHeader returns the base64 encoded authentication header value
-func (j *JiraAdapter) getAuthHeader() string {
-	auth := fmt.Sprintf("%s:%s", j.email, j.apiKey)
-	return base64.StdEncoding.EncodeToString([]byte(auth))
-}
-
 // Authenticate verifies the connection to Jira with the provided credentials
-func (j *JiraAdapter) Authenticate() error {
+func (j *JiraAdapter) Authenticate(ctx context.Context) error {
 	// Use the myself endpoint to verify authentication
-	url := fmt.Sprintf("%s/rest/api/2/myself", j.baseURL)
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Authorization", fmt.Sprintf("Basic %s", j.getAuthHeader()))
-	req.Header.Set("Content-Type", "application/json")
+	url := j.restURL("myself")
 
-	resp, err := j.client.Do(req)
+	resp, err := j.do(ctx, "GET", url, nil, true)
 	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
+		return err
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("authentication failed with status %d: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("authentication failed with status %d: %s", resp.StatusCode, string(resp.Body))
 	}
 
 	return nil
 }
 
 // CreateTask creates a new sub-task in Jira under the specified parent story
-func (j *JiraAdapter) CreateTask(task domain.Task, parentID string) (string, error) {
-	// Build the description with acceptance criteria
-	description := task.Description
-	if len(task.AcceptanceCriteria) > 0 {
-		description += "\n\nh3. Acceptance Criteria\n"
-		for _, ac := range task.AcceptanceCriteria {
-			description += fmt.Sprintf("* %s\n", ac)
-		}
-	}
-
-	// Build fields payload with custom field mappings (similar to CreateTicket)
-	fields := j.buildFieldsPayload(task.CustomFields, task.Title, description, task.AcceptanceCriteria)
-
-	// Override to ensure correct project/type/parent for subtask
-	fields["project"] = map[string]interface{}{
-		"key": j.projectKey,
-	}
-	fields["issuetype"] = map[string]interface{}{
-		"name": j.subTaskType,
-	}
-	fields["parent"] = map[string]interface{}{
-		"key": parentID,
+func (j *JiraAdapter) CreateTask(ctx context.Context, task domain.Task, parentID string) (string, error) {
+	var err error
+	if task.CustomFields, err = j.resolveUsers(ctx, task.CustomFields); err != nil {
+		return "", err
 	}
+	fields := j.buildTaskCreateFields(task, parentID)
 
 	payload := map[string]interface{}{
 		"fields": fields,
@@ -161,33 +305,19 @@ func (j *JiraAdapter) CreateTask(task domain.Task, parentID string) (string, err
 		return "", fmt.Errorf("failed to marshal payload: %w", err)
 	}
 
-	url := fmt.Sprintf("%s/rest/api/2/issue", j.baseURL)
-	req, err := http.NewRequest("POST", url, bytes.NewBuffer(jsonPayload))
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Authorization", fmt.Sprintf("Basic %s", j.getAuthHeader()))
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := j.client.Do(req)
+	url := j.restURL("issue")
+	resp, err := j.do(ctx, "POST", url, jsonPayload, false)
 	if err != nil {
-		return "", fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return "", fmt.Errorf("failed to read response body: %w", err)
+		return "", err
 	}
 
 	if resp.StatusCode != http.StatusCreated {
-		return "", fmt.Errorf("failed to create task with status %d: %s", resp.StatusCode, string(body))
+		return "", fmt.Errorf("failed to create task with status %d: %s", resp.StatusCode, string(resp.Body))
 	}
 
 	// Parse the response to get the issue key
 	var result map[string]interface{}
-	if err := json.Unmarshal(body, &result); err != nil {
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
 		return "", fmt.Errorf("failed to parse response: %w", err)
 	}
 
@@ -200,32 +330,18 @@ func (j *JiraAdapter) CreateTask(task domain.Task, parentID string) (string, err
 }
 
 // GetProjectIssueTypes fetches available issue types for the configured project
-func (j *JiraAdapter) GetProjectIssueTypes() (map[string][]string, error) {
-	url := fmt.Sprintf("%s/rest/api/2/project/%s", j.baseURL, j.projectKey)
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Authorization", fmt.Sprintf("Basic %s", j.getAuthHeader()))
-	req.Header.Set("Content-Type", "application/json")
+func (j *JiraAdapter) GetProjectIssueTypes(ctx context.Context) (map[string][]string, error) {
+	url := j.restURL(fmt.Sprintf("project/%s", j.projectKey))
 
-	resp, err := j.client.Do(req)
+	resp, err := j.do(ctx, "GET", url, nil, true)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+		return nil, err
 	}
-	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get project with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
+		return nil, fmt.Errorf("failed to get project with status %d: %s", resp.StatusCode, string(resp.Body))
 	}
+	body := resp.Body
 
 	// Parse the response
 	var project map[string]interface{}
@@ -267,84 +383,20 @@ func (j *JiraAdapter) GetProjectIssueTypes() (map[string][]string, error) {
 }
 
 // GetIssueTypeFields fetches field requirements for a specific issue type
-func (j *JiraAdapter) GetIssueTypeFields(issueTypeName string) (map[string]interface{}, error) {
-	// Use the createmeta endpoint to get field information
-	url := fmt.Sprintf("%s/rest/api/2/issue/createmeta?projectKeys=%s&expand=projects.issuetypes.fields",
-		j.baseURL, j.projectKey)
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Authorization", fmt.Sprintf("Basic %s", j.getAuthHeader()))
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := j.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute request: %w", err)
+func (j *JiraAdapter) GetIssueTypeFields(ctx context.Context, issueTypeName string) (map[string]interface{}, error) {
+	var fields map[string]interface{}
+	var err error
+	if j.isServer() {
+		fields, err = j.getServerCreateMetaFields(ctx, issueTypeName)
+	} else {
+		fields, err = j.getCreateMetaFields(ctx, issueTypeName)
 	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return nil, fmt.Errorf("failed to get createmeta with status %d: %s", resp.StatusCode, string(body))
-	}
-
-	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read response body: %w", err)
-	}
-
-	// Parse the response
-	var createMeta map[string]interface{}
-	if err := json.Unmarshal(body, &createMeta); err != nil {
-		return nil, fmt.Errorf("failed to parse response: %w", err)
+		return nil, err
 	}
 
 	result := make(map[string]interface{})
 
-	// Navigate through the response structure
-	projects, ok := createMeta["projects"].([]interface{})
-	if !ok || len(projects) == 0 {
-		return nil, fmt.Errorf("no projects found in response")
-	}
-
-	project := projects[0].(map[string]interface{})
-	issueTypes, ok := project["issuetypes"].([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("no issue types found in project")
-	}
-
-	// Find the requested issue type
-	var targetIssueType map[string]interface{}
-	for _, it := range issueTypes {
-		issueType := it.(map[string]interface{})
-		if name, ok := issueType["name"].(string); ok && name == issueTypeName {
-			targetIssueType = issueType
-			break
-		}
-	}
-
-	if targetIssueType == nil {
-		// List available issue types
-		availableTypes := []string{}
-		for _, it := range issueTypes {
-			if issueType, ok := it.(map[string]interface{}); ok {
-				if name, ok := issueType["name"].(string); ok {
-					availableTypes = append(availableTypes, name)
-				}
-			}
-		}
-		return nil, fmt.Errorf("issue type '%s' not found. Available types: %v", issueTypeName, availableTypes)
-	}
-
-	// Extract field information
-	fields, ok := targetIssueType["fields"].(map[string]interface{})
-	if !ok {
-		return nil, fmt.Errorf("no fields found for issue type")
-	}
-
 	// Process fields to extract relevant information
 	fieldInfo := []map[string]interface{}{}
 	for fieldKey, fieldData := range fields {
@@ -362,6 +414,10 @@ func (j *JiraAdapter) GetIssueTypeFields(issueTypeName string) (map[string]inter
 			info["required"] = required
 		}
 
+		if hasDefault, ok := field["hasDefaultValue"].(bool); ok {
+			info["hasDefaultValue"] = hasDefault
+		}
+
 		if schema, ok := field["schema"].(map[string]interface{}); ok {
 			if fieldType, ok := schema["type"].(string); ok {
 				info["type"] = fieldType
@@ -396,28 +452,144 @@ func (j *JiraAdapter) GetIssueTypeFields(issueTypeName string) (map[string]inter
 	return result, nil
 }
 
-// UpdateTask updates an existing task in Jira
-func (j *JiraAdapter) UpdateTask(task domain.Task) error {
-	if task.JiraID == "" {
-		return fmt.Errorf("task does not have a Jira ID")
+// getCreateMetaFields fetches an issue type's fields, keyed by field ID, from
+// the project-wide createmeta endpoint Jira Cloud serves
+func (j *JiraAdapter) getCreateMetaFields(ctx context.Context, issueTypeName string) (map[string]interface{}, error) {
+	url := j.restURL(fmt.Sprintf("issue/createmeta?projectKeys=%s&expand=projects.issuetypes.fields", j.projectKey))
+
+	resp, err := j.do(ctx, "GET", url, nil, true)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get createmeta with status %d: %s", resp.StatusCode, string(resp.Body))
+	}
+
+	var createMeta map[string]interface{}
+	if err := json.Unmarshal(resp.Body, &createMeta); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
 	}
 
-	// Build the description with acceptance criteria
-	description := task.Description
-	if len(task.AcceptanceCriteria) > 0 {
-		description += "\n\nh3. Acceptance Criteria\n"
-		for _, ac := range task.AcceptanceCriteria {
-			description += fmt.Sprintf("* %s\n", ac)
+	// Navigate through the response structure
+	projects, ok := createMeta["projects"].([]interface{})
+	if !ok || len(projects) == 0 {
+		return nil, fmt.Errorf("no projects found in response")
+	}
+
+	project := projects[0].(map[string]interface{})
+	issueTypes, ok := project["issuetypes"].([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("no issue types found in project")
+	}
+
+	targetIssueType, err := findIssueType(issueTypes, issueTypeName)
+	if err != nil {
+		return nil, err
+	}
+
+	fields, ok := targetIssueType["fields"].(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("no fields found for issue type")
+	}
+	return fields, nil
+}
+
+// getServerCreateMetaFields fetches an issue type's fields, keyed by field ID,
+// from the paginated per-issue-type createmeta endpoints of Jira Server and
+// Data Center, which no longer serve the project-wide one
+func (j *JiraAdapter) getServerCreateMetaFields(ctx context.Context, issueTypeName string) (map[string]interface{}, error) {
+	issueTypes, err := j.getCreateMetaValues(ctx, fmt.Sprintf("issue/createmeta/%s/issuetypes", j.projectKey))
+	if err != nil {
+		return nil, err
+	}
+
+	targetIssueType, err := findIssueType(issueTypes, issueTypeName)
+	if err != nil {
+		return nil, err
+	}
+	issueTypeID, _ := targetIssueType["id"].(string)
+
+	values, err := j.getCreateMetaValues(ctx, fmt.Sprintf("issue/createmeta/%s/issuetypes/%s", j.projectKey, issueTypeID))
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]interface{}, len(values))
+	for _, v := range values {
+		if field, ok := v.(map[string]interface{}); ok {
+			if id, ok := field["fieldId"].(string); ok {
+				fields[id] = field
+			}
 		}
 	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("no fields found for issue type")
+	}
+	return fields, nil
+}
 
-	// Build fields payload with custom field mappings (similar to UpdateTicket)
-	fields := j.buildFieldsPayload(task.CustomFields, task.Title, description, task.AcceptanceCriteria)
+// getCreateMetaValues fetches every page of a Server createmeta resource
+func (j *JiraAdapter) getCreateMetaValues(ctx context.Context, path string) ([]interface{}, error) {
+	var values []interface{}
 
-	// Remove fields that shouldn't be updated for subtasks
-	delete(fields, "project")
-	delete(fields, "issuetype")
-	delete(fields, "parent")
+	for startAt := 0; ; {
+		url := j.restURL(fmt.Sprintf("%s?startAt=%d", path, startAt))
+		resp, err := j.do(ctx, "GET", url, nil, true)
+		if err != nil {
+			return nil, err
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("failed to get createmeta with status %d: %s", resp.StatusCode, string(resp.Body))
+		}
+
+		var page struct {
+			Values []interface{} `json:"values"`
+			Total  int           `json:"total"`
+			IsLast *bool         `json:"isLast"`
+		}
+		if err := json.Unmarshal(resp.Body, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse response: %w", err)
+		}
+
+		values = append(values, page.Values...)
+		startAt += len(page.Values)
+		if len(page.Values) == 0 || (page.IsLast != nil && *page.IsLast) || (page.IsLast == nil && startAt >= page.Total) {
+			return values, nil
+		}
+	}
+}
+
+// findIssueType returns the createmeta issue type with the given name
+func findIssueType(issueTypes []interface{}, issueTypeName string) (map[string]interface{}, error) {
+	availableTypes := []string{}
+	for _, it := range issueTypes {
+		issueType, ok := it.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		if name, ok := issueType["name"].(string); ok {
+			if name == issueTypeName {
+				return issueType, nil
+			}
+			availableTypes = append(availableTypes, name)
+		}
+	}
+	return nil, fmt.Errorf("issue type '%s' not found. Available types: %v", issueTypeName, availableTypes)
+}
+
+// UpdateTask updates an existing task in Jira
+func (j *JiraAdapter) UpdateTask(ctx context.Context, task domain.Task) error {
+	if task.JiraID == "" {
+		return fmt.Errorf("task does not have a Jira ID")
+	}
+
+	var err error
+	if task.CustomFields, err = j.resolveUsers(ctx, task.CustomFields); err != nil {
+		return err
+	}
+	fields := j.buildTaskUpdateFields(task)
 
 	payload := map[string]interface{}{
 		"fields": fields,
@@ -428,31 +600,26 @@ func (j *JiraAdapter) UpdateTask(task domain.Task) error {
 		return fmt.Errorf("failed to marshal payload: %w", err)
 	}
 
-	url := fmt.Sprintf("%s/rest/api/2/issue/%s", j.baseURL, task.JiraID)
-	req, err := http.NewRequest("PUT", url, bytes.NewBuffer(jsonPayload))
+	url := j.restURL(fmt.Sprintf("issue/%s", task.JiraID))
+	resp, err := j.do(ctx, "PUT", url, jsonPayload, true)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Basic %s", j.getAuthHeader()))
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := j.client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close()
-
 	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to update task with status %d: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("failed to update task with status %d: %s", resp.StatusCode, string(resp.Body))
 	}
 
 	return nil
 }
 
 // CreateTicket creates a new ticket in JIRA with dynamic field mapping
-func (j *JiraAdapter) CreateTicket(ticket domain.Ticket) (string, error) {
+func (j *JiraAdapter) CreateTicket(ctx context.Context, ticket domain.Ticket) (string, error) {
+	var err error
+	if ticket.CustomFields, err = j.resolveUsers(ctx, ticket.CustomFields); err != nil {
+		return "", err
+	}
+
 	// Build the payload dynamically using field mappings
 	fields := j.buildFieldsPayload(ticket.CustomFields, ticket.Title, ticket.Description, ticket.AcceptanceCriteria)
 
@@ -466,30 +633,19 @@ func (j *JiraAdapter) CreateTicket(ticket domain.Ticket) (string, error) {
 	}
 
 	// Create issue in Jira
-	url := fmt.Sprintf("%s/rest/api/2/issue", j.baseURL)
-	req, err := http.NewRequest("POST", url, bytes.NewReader(jsonPayload))
-	if err != nil {
-		return "", fmt.Errorf("failed to create request: %w", err)
-	}
-
-	req.Header.Set("Authorization", fmt.Sprintf("Basic %s", j.getAuthHeader()))
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := j.client.Do(req)
+	url := j.restURL("issue")
+	resp, err := j.do(ctx, "POST", url, jsonPayload, false)
 	if err != nil {
-		return "", fmt.Errorf("failed to execute request: %w", err)
+		return "", err
 	}
-	defer resp.Body.Close()
-
-	body, _ := io.ReadAll(resp.Body)
 
 	if resp.StatusCode != http.StatusCreated {
-		return "", fmt.Errorf("failed to create ticket with status %d: %s", resp.StatusCode, string(body))
+		return "", fmt.Errorf("failed to create ticket with status %d: %s", resp.StatusCode, string(resp.Body))
 	}
 
 	// Parse the response to get the issue key
 	var result map[string]interface{}
-	if err := json.Unmarshal(body, &result); err != nil {
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
 		return "", fmt.Errorf("failed to parse response: %w", err)
 	}
 
@@ -502,17 +658,16 @@ func (j *JiraAdapter) CreateTicket(ticket domain.Ticket) (string, error) {
 }
 
 // UpdateTicket updates an existing ticket in JIRA with dynamic field mapping
-func (j *JiraAdapter) UpdateTicket(ticket domain.Ticket) error {
+func (j *JiraAdapter) UpdateTicket(ctx context.Context, ticket domain.Ticket) error {
 	if ticket.JiraID == "" {
 		return fmt.Errorf("ticket does not have a Jira ID")
 	}
 
-	// Build the payload dynamically using field mappings
-	fields := j.buildFieldsPayload(ticket.CustomFields, ticket.Title, ticket.Description, ticket.AcceptanceCriteria)
-
-	// Remove fields that shouldn't be updated
-	delete(fields, "project")
-	delete(fields, "issuetype")
+	var err error
+	if ticket.CustomFields, err = j.resolveUsers(ctx, ticket.CustomFields); err != nil {
+		return err
+	}
+	fields := j.buildTicketUpdateFields(ticket)
 
 	payload := map[string]interface{}{
 		"fields": fields,
@@ -524,29 +679,63 @@ func (j *JiraAdapter) UpdateTicket(ticket domain.Ticket) error {
 	}
 
 	// Update issue in Jira
-	url := fmt.Sprintf("%s/rest/api/2/issue/%s", j.baseURL, ticket.JiraID)
-	req, err := http.NewRequest("PUT", url, bytes.NewReader(jsonPayload))
+	url := j.restURL(fmt.Sprintf("issue/%s", ticket.JiraID))
+	resp, err := j.do(ctx, "PUT", url, jsonPayload, true)
 	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+		return err
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Basic %s", j.getAuthHeader()))
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := j.client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to execute request: %w", err)
-	}
-	defer resp.Body.Close()
-
 	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
-		body, _ := io.ReadAll(resp.Body)
-		return fmt.Errorf("failed to update ticket with status %d: %s", resp.StatusCode, string(body))
+		return fmt.Errorf("failed to update ticket with status %d: %s", resp.StatusCode, string(resp.Body))
 	}
 
 	return nil
 }
 
+// buildTaskCreateFields builds the fields payload CreateTask sends for a sub-task
+func (j *JiraAdapter) buildTaskCreateFields(task domain.Task, parentID string) map[string]interface{} {
+	// Build fields payload with custom field mappings (similar to CreateTicket)
+	fields := j.buildFieldsPayload(task.CustomFields, task.Title, task.Description, task.AcceptanceCriteria)
+
+	// Override to ensure correct project/type/parent for subtask
+	fields["project"] = map[string]interface{}{
+		"key": j.projectKey,
+	}
+	fields["issuetype"] = map[string]interface{}{
+		"name": j.subTaskType,
+	}
+	fields["parent"] = map[string]interface{}{
+		"key": parentID,
+	}
+
+	return fields
+}
+
+// buildTaskUpdateFields builds the fields payload UpdateTask sends for a sub-task
+func (j *JiraAdapter) buildTaskUpdateFields(task domain.Task) map[string]interface{} {
+	// Build fields payload with custom field mappings (similar to UpdateTicket)
+	fields := j.buildFieldsPayload(task.CustomFields, task.Title, task.Description, task.AcceptanceCriteria)
+
+	// Remove fields that shouldn't be updated for subtasks
+	delete(fields, "project")
+	delete(fields, "issuetype")
+	delete(fields, "parent")
+
+	return fields
+}
+
+// buildTicketUpdateFields builds the fields payload UpdateTicket sends
+func (j *JiraAdapter) buildTicketUpdateFields(ticket domain.Ticket) map[string]interface{} {
+	// Build the payload dynamically using field mappings
+	fields := j.buildFieldsPayload(ticket.CustomFields, ticket.Title, ticket.Description, ticket.AcceptanceCriteria)
+
+	// Remove fields that shouldn't be updated
+	delete(fields, "project")
+	delete(fields, "issuetype")
+
+	return fields
+}
+
 // buildFieldsPayload builds the JIRA fields payload using field mappings
 func (j *JiraAdapter) buildFieldsPayload(customFields map[string]string, title, description string, acceptanceCriteria []string) map[string]interface{} {
 	fields := make(map[string]interface{})
@@ -555,14 +744,7 @@ func (j *JiraAdapter) buildFieldsPayload(customFields map[string]string, title,
 	fields["summary"] = title
 
 	// Build description with acceptance criteria
-	fullDescription := description
-	if len(acceptanceCriteria) > 0 {
-		fullDescription += "\n\nh3. Acceptance Criteria\n"
-		for _, ac := range acceptanceCriteria {
-			fullDescription += fmt.Sprintf("* %s\n", ac)
-		}
-	}
-	fields["description"] = fullDescription
+	fields["description"] = j.formatDescription(description, acceptanceCriteria)
 
 	// Set project and issue type from defaults if not in custom fields
 	if _, hasProject := customFields["Project"]; !hasProject {
@@ -579,7 +761,18 @@ func (j *JiraAdapter) buildFieldsPayload(customFields map[string]string, title,
 
 	// Map custom fields using field mappings
 	for fieldName, fieldValue := range customFields {
+		if fieldName == EpicLinkFieldName && !j.isServer() {
+			// Cloud links issues to epics through the parent field
+			if fieldValue != "" {
+				fields["parent"] = map[string]interface{}{"key": fieldValue}
+			}
+			continue
+		}
 		if mappingInfo, exists := j.fieldMappings[fieldName]; exists {
+			if isUserField(mappingInfo) {
+				fields[fieldID(mappingInfo)] = j.userFieldValue(fieldValue)
+				continue
+			}
 			// Check if mapping is complex (has id and type)
 			switch mapping := mappingInfo.(type) {
 			case string:
@@ -640,113 +833,118 @@ func (j *JiraAdapter) convertFieldValue(value string, fieldType string) interfac
 }
 
 // SearchTickets searches for tickets in Jira using JQL query
-func (j *JiraAdapter) SearchTickets(projectKey string, jql string) ([]domain.Ticket, error) {
+func (j *JiraAdapter) SearchTickets(ctx context.Context, projectKey string, jql string) ([]domain.Ticket, error) {
 	// Construct JQL query - combine project filter with provided JQL
-	fullJQL := fmt.Sprintf(`project = "%s"`, projectKey)
-	if jql != "" {
-		fullJQL = fmt.Sprintf(`%s AND %s`, fullJQL, jql)
-	}
-
-	// Build fields list based on field mappings
-	fields := []string{"key", "summary", "description", "issuetype", "parent"}
-	for _, mapping := range j.fieldMappings {
-		switch m := mapping.(type) {
-		case string:
-			if m != "summary" && m != "description" && m != "issuetype" && m != "project" {
-				fields = append(fields, m)
-			}
-		case map[string]interface{}:
-			if id, ok := m["id"].(string); ok {
-				fields = append(fields, id)
-			}
+	fullJQL := jql
+	if projectKey != "" {
+		fullJQL = fmt.Sprintf(`project = "%s"`, projectKey)
+		if jql != "" {
+			fullJQL = fmt.Sprintf(`%s AND %s`, fullJQL, jql)
 		}
 	}
 
-	// Prepare request payload
-	payload := map[string]interface{}{
-		"jql":        fullJQL,
-		"fields":     fields,
-		"maxResults": 100, // TODO: Add pagination support
-	}
+	// Build fields list based on field mappings
+	fields := append([]string{"key", "summary", "description", "issuetype", "parent", "status", "issuelinks", "comment"}, j.mappedSearchFields()...)
 
-	jsonPayload, err := json.Marshal(payload)
+	// The cap bounds the tickets searched for, never their subtasks
+	issues, capped, err := j.searchIssues(ctx, fullJQL, fields, j.maxResults)
 	if err != nil {
-		return nil, fmt.Errorf("failed to marshal search payload: %w", err)
+		return nil, err
 	}
 
-	// Execute search request
-	url := fmt.Sprintf("%s/rest/api/2/search", j.baseURL)
-	req, err := http.NewRequest("POST", url, bytes.NewReader(jsonPayload))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create search request: %w", err)
+	// Convert Jira issues to domain tickets
+	tickets := make([]domain.Ticket, 0, len(issues))
+	for _, issue := range issues {
+		ticket := j.parseJiraIssue(issue)
+		tickets = append(tickets, ticket)
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Basic %s", j.getAuthHeader()))
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := j.client.Do(req)
-	if err != nil {
-		return nil, fmt.Errorf("failed to execute search request: %w", err)
+	// Fetch subtasks for all parent tickets in batched queries
+	keys := make([]string, 0, len(tickets))
+	for _, ticket := range tickets {
+		keys = append(keys, ticket.JiraID)
 	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, fmt.Errorf("failed to read search response: %w", err)
+	subtasks, partialErr := j.fetchSubtasks(ctx, keys)
+	if err := ctx.Err(); err != nil {
+		// A cancelled search is a failure, not a partial result
+		return nil, fmt.Errorf("search cancelled: %w", err)
 	}
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("search failed with status %d: %s", resp.StatusCode, string(body))
+	for i := range tickets {
+		tickets[i].Tasks = subtasks[tickets[i].JiraID]
+		if tickets[i].Tasks == nil {
+			tickets[i].Tasks = []domain.Task{}
+		}
 	}
 
-	// Parse search response
-	var searchResult map[string]interface{}
-	if err := json.Unmarshal(body, &searchResult); err != nil {
-		return nil, fmt.Errorf("failed to parse search response: %w", err)
+	if capped {
+		if partialErr == nil {
+			partialErr = &ports.PartialResultError{}
+		}
+		partialErr.Errors = append(partialErr.Errors, fmt.Errorf("search stopped at %d issues (jira.search.max_results); later matches were not fetched", j.maxResults))
 	}
-
-	issues, ok := searchResult["issues"].([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("search response missing issues array")
+	if partialErr != nil {
+		// Tickets are still usable; callers decide how to treat the missing subtasks
+		return tickets, partialErr
 	}
 
-	// Convert Jira issues to domain tickets
-	tickets := make([]domain.Ticket, 0, len(issues))
-	for _, issue := range issues {
-		issueMap, ok := issue.(map[string]interface{})
-		if !ok {
-			continue
+	return tickets, nil
+}
+
+// fetchSubtasks fetches the subtasks of the given parent issues with batched
+// `parent in (...)` searches and groups them by parent key. Batches that fail
+// are reported in a PartialResultError naming the affected parents.
+func (j *JiraAdapter) fetchSubtasks(ctx context.Context, parentKeys []string) (map[string][]domain.Task, *ports.PartialResultError) {
+	subtasks := make(map[string][]domain.Task)
+	var partial *ports.PartialResultError
+
+	// Build fields list (same as SearchTickets)
+	fields := append([]string{"key", "summary", "description", "issuetype", "parent", "status"}, j.mappedSearchFields()...)
+
+	for start := 0; start < len(parentKeys) && ctx.Err() == nil; start += subtaskBatchSize {
+		end := start + subtaskBatchSize
+		if end > len(parentKeys) {
+			end = len(parentKeys)
 		}
+		batch := parentKeys[start:end]
 
-		ticket := j.parseJiraIssue(issueMap)
-		tickets = append(tickets, ticket)
-	}
+		quoted := make([]string, len(batch))
+		for i, key := range batch {
+			quoted[i] = fmt.Sprintf("%q", key)
+		}
+		jql := fmt.Sprintf("parent in (%s)", strings.Join(quoted, ", "))
 
-	// Fetch subtasks for each parent ticket
-	for i := range tickets {
-		subtasks, err := j.fetchSubtasks(tickets[i].JiraID)
+		issues, _, err := j.searchIssues(ctx, jql, fields, 0)
 		if err != nil {
-			// Log error but continue processing (non-fatal)
-			// Note: In production, this should use proper logging
+			if partial == nil {
+				partial = &ports.PartialResultError{}
+			}
+			partial.Keys = append(partial.Keys, batch...)
+			partial.Errors = append(partial.Errors, fmt.Errorf("failed to fetch subtasks of %s: %w", strings.Join(batch, ", "), err))
 			continue
 		}
-		tickets[i].Tasks = subtasks
+
+		// Group the subtasks back onto their parents
+		for _, issue := range issues {
+			parentKey := ""
+			if issueFields, ok := issue["fields"].(map[string]interface{}); ok {
+				if parent, ok := issueFields["parent"].(map[string]interface{}); ok {
+					parentKey, _ = parent["key"].(string)
+				}
+			}
+			subtasks[parentKey] = append(subtasks[parentKey], j.parseJiraSubtask(issue))
+		}
 	}
 
-	return tickets, nil
+	return subtasks, partial
 }
 
-// fetchSubtasks fetches all subtasks for a given parent issue key
-func (j *JiraAdapter) fetchSubtasks(parentKey string) ([]domain.Task, error) {
-	// Construct JQL query for subtasks: parent = "PARENT-KEY"
-	jql := fmt.Sprintf(`parent = "%s"`, parentKey)
-
-	// Build fields list (same as SearchTickets)
-	fields := []string{"key", "summary", "description", "issuetype"}
+// mappedSearchFields returns the Jira field IDs from the field mappings that searches should request
+func (j *JiraAdapter) mappedSearchFields() []string {
+	fields := []string{}
 	for _, mapping := range j.fieldMappings {
 		switch m := mapping.(type) {
 		case string:
-			if m != "summary" && m != "description" && m != "issuetype" && m != "project" {
+			if m != "summary" && m != "description" && m != "issuetype" && m != "project" && m != "status" {
 				fields = append(fields, m)
 			}
 		case map[string]interface{}:
@@ -755,68 +953,121 @@ func (j *JiraAdapter) fetchSubtasks(parentKey string) ([]domain.Task, error) {
 			}
 		}
 	}
+	return fields
+}
 
-	// Prepare request payload
-	payload := map[string]interface{}{
-		"jql":        jql,
-		"fields":     fields,
-		"maxResults": 100,
+// searchIssues runs a JQL search and follows pagination until every matching
+// issue has been fetched or maxResults is reached (no cap when zero),
+// reporting whether the cap left matches unfetched. The classic search
+// endpoint is paged with startAt/total, the newer search/jql endpoint with
+// nextPageToken/isLast.
+func (j *JiraAdapter) searchIssues(ctx context.Context, jql string, fields []string, maxResults int) ([]map[string]interface{}, bool, error) {
+	pageSize := j.pageSize
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
 	}
 
-	jsonPayload, err := json.Marshal(payload)
-	if err != nil {
-		return nil, fmt.Errorf("failed to marshal subtask search payload: %w", err)
+	endpoint := j.searchEndpoint
+	if endpoint == "" {
+		endpoint = SearchEndpointClassic
 	}
+	url := j.restURL(endpoint)
 
-	// Execute search request
-	url := fmt.Sprintf("%s/rest/api/2/search", j.baseURL)
-	req, err := http.NewRequest("POST", url, bytes.NewReader(jsonPayload))
-	if err != nil {
-		return nil, fmt.Errorf("failed to create subtask search request: %w", err)
+	issues := []map[string]interface{}{}
+	startAt := 0
+	nextPageToken := ""
+
+	for {
+		limit := pageSize
+		if maxResults > 0 && maxResults-len(issues) < limit {
+			limit = maxResults - len(issues)
+		}
+
+		// Prepare request payload
+		payload := map[string]interface{}{
+			"jql":        jql,
+			"fields":     fields,
+			"maxResults": limit,
+		}
+		if endpoint == SearchEndpointJQL {
+			if nextPageToken != "" {
+				payload["nextPageToken"] = nextPageToken
+			}
+		} else {
+			payload["startAt"] = startAt
+		}
+
+		page, err := j.searchPage(ctx, url, payload)
+		if err != nil {
+			return nil, false, err
+		}
+
+		pageIssues, ok := page["issues"].([]interface{})
+		if !ok {
+			return nil, false, fmt.Errorf("search response missing issues array")
+		}
+
+		for _, issue := range pageIssues {
+			if issueMap, ok := issue.(map[string]interface{}); ok {
+				issues = append(issues, issueMap)
+			}
+		}
+
+		if maxResults > 0 && len(issues) >= maxResults {
+			more := len(issues) > maxResults || hasMorePages(page, endpoint, startAt+len(pageIssues))
+			return issues[:maxResults], more, nil
+		}
+
+		if len(pageIssues) == 0 || !hasMorePages(page, endpoint, startAt+len(pageIssues)) {
+			return issues, false, nil
+		}
+
+		startAt += len(pageIssues)
+		nextPageToken, _ = page["nextPageToken"].(string)
+	}
+}
+
+// hasMorePages reports whether a search response indicates further pages
+func hasMorePages(page map[string]interface{}, endpoint string, fetched int) bool {
+	if endpoint == SearchEndpointJQL {
+		if isLast, ok := page["isLast"].(bool); ok && isLast {
+			return false
+		}
+		token, _ := page["nextPageToken"].(string)
+		return token != ""
 	}
 
-	req.Header.Set("Authorization", fmt.Sprintf("Basic %s", j.getAuthHeader()))
-	req.Header.Set("Content-Type", "application/json")
+	total, ok := page["total"].(float64)
+	if !ok {
+		return false
+	}
+	return fetched < int(total)
+}
 
-	resp, err := j.client.Do(req)
+// searchPage executes a single search request and decodes the response
+func (j *JiraAdapter) searchPage(ctx context.Context, url string, payload map[string]interface{}) (map[string]interface{}, error) {
+	jsonPayload, err := json.Marshal(payload)
 	if err != nil {
-		return nil, fmt.Errorf("failed to execute subtask search request: %w", err)
+		return nil, fmt.Errorf("failed to marshal search payload: %w", err)
 	}
-	defer resp.Body.Close()
 
-	body, err := io.ReadAll(resp.Body)
+	// Execute search request; searches only read, so they are safe to retry
+	resp, err := j.do(ctx, "POST", url, jsonPayload, true)
 	if err != nil {
-		return nil, fmt.Errorf("failed to read subtask search response: %w", err)
+		return nil, fmt.Errorf("search request failed: %w", err)
 	}
 
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("subtask search failed with status %d: %s", resp.StatusCode, string(body))
+		return nil, fmt.Errorf("search failed with status %d: %s", resp.StatusCode, string(resp.Body))
 	}
 
 	// Parse search response
 	var searchResult map[string]interface{}
-	if err := json.Unmarshal(body, &searchResult); err != nil {
-		return nil, fmt.Errorf("failed to parse subtask search response: %w", err)
-	}
-
-	issues, ok := searchResult["issues"].([]interface{})
-	if !ok {
-		return nil, fmt.Errorf("subtask search response missing issues array")
-	}
-
-	// Convert Jira issues to domain tasks
-	tasks := make([]domain.Task, 0, len(issues))
-	for _, issue := range issues {
-		issueMap, ok := issue.(map[string]interface{})
-		if !ok {
-			continue
-		}
-
-		task := j.parseJiraSubtask(issueMap)
-		tasks = append(tasks, task)
+	if err := json.Unmarshal(resp.Body, &searchResult); err != nil {
+		return nil, fmt.Errorf("failed to parse search response: %w", err)
 	}
 
-	return tasks, nil
+	return searchResult, nil
 }
 
 // parseJiraSubtask converts a JIRA subtask response to domain.Task
@@ -841,22 +1092,11 @@ func (j *JiraAdapter) parseJiraSubtask(issue map[string]interface{}) domain.Task
 		task.Title = summary
 	}
 
-	if description, ok := fields["description"].(string); ok {
-		// Extract description without acceptance criteria
-		parts := strings.Split(description, "h3. Acceptance Criteria")
-		task.Description = strings.TrimSpace(parts[0])
+	// Split the description from its acceptance criteria
+	task.Description, task.AcceptanceCriteria = parseDescription(fields["description"])
 
-		// Parse acceptance criteria if present
-		if len(parts) > 1 {
-			acLines := strings.Split(parts[1], "\n")
-			for _, line := range acLines {
-				line = strings.TrimSpace(line)
-				if strings.HasPrefix(line, "* ") {
-					task.AcceptanceCriteria = append(task.AcceptanceCriteria, strings.TrimPrefix(line, "* "))
-				}
-			}
-		}
-	}
+	// Get workflow status
+	task.Status = parseStatus(fields["status"])
 
 	// Get issue type
 	if issueType, ok := fields["issuetype"].(map[string]interface{}); ok {
@@ -868,6 +1108,10 @@ func (j *JiraAdapter) parseJiraSubtask(issue map[string]interface{}) domain.Task
 	// Map JIRA fields back to human-readable names using reverse mapping
 	reverseMapping := j.createReverseFieldMapping()
 	for jiraField, jiraValue := range fields {
+		if jiraField == "summary" || jiraField == "description" || jiraField == "status" || jiraField == "comment" {
+			// Already parsed into the title, description, acceptance criteria, status and comments
+			continue
+		}
 		if humanName, exists := reverseMapping[jiraField]; exists {
 			// Convert JIRA value to string representation
 			switch v := jiraValue.(type) {
@@ -892,10 +1136,8 @@ func (j *JiraAdapter) parseJiraSubtask(issue map[string]interface{}) domain.Task
 				}
 			case map[string]interface{}:
 				// Handle objects (e.g., assignee, reporter, priority)
-				if name, ok := v["name"].(string); ok {
-					task.CustomFields[humanName] = name
-				} else if displayName, ok := v["displayName"].(string); ok {
-					task.CustomFields[humanName] = displayName
+				if value := objectFieldValue(v); value != "" {
+					task.CustomFields[humanName] = value
 				}
 			}
 		}
@@ -926,22 +1168,17 @@ func (j *JiraAdapter) parseJiraIssue(issue map[string]interface{}) domain.Ticket
 		ticket.Title = summary
 	}
 
-	if description, ok := fields["description"].(string); ok {
-		// Extract description without acceptance criteria
-		parts := strings.Split(description, "h3. Acceptance Criteria")
-		ticket.Description = strings.TrimSpace(parts[0])
+	// Split the description from its acceptance criteria
+	ticket.Description, ticket.AcceptanceCriteria = parseDescription(fields["description"])
 
-		// Parse acceptance criteria if present
-		if len(parts) > 1 {
-			acLines := strings.Split(parts[1], "\n")
-			for _, line := range acLines {
-				line = strings.TrimSpace(line)
-				if strings.HasPrefix(line, "* ") {
-					ticket.AcceptanceCriteria = append(ticket.AcceptanceCriteria, strings.TrimPrefix(line, "* "))
-				}
-			}
-		}
-	}
+	// Get links to other issues
+	ticket.Links = parseIssueLinks(fields["issuelinks"])
+
+	// Get workflow status
+	ticket.Status = parseStatus(fields["status"])
+
+	// Get the discussion
+	ticket.Comments = parseComments(fields["comment"])
 
 	// Get issue type
 	if issueType, ok := fields["issuetype"].(map[string]interface{}); ok {
@@ -960,6 +1197,10 @@ func (j *JiraAdapter) parseJiraIssue(issue map[string]interface{}) domain.Ticket
 	// Map JIRA fields back to human-readable names using reverse mapping
 	reverseMapping := j.createReverseFieldMapping()
 	for jiraField, jiraValue := range fields {
+		if jiraField == "summary" || jiraField == "description" || jiraField == "status" || jiraField == "comment" {
+			// Already parsed into the title, description, acceptance criteria, status and comments
+			continue
+		}
 		if humanName, exists := reverseMapping[jiraField]; exists {
 			// Convert JIRA value to string representation
 			switch v := jiraValue.(type) {
@@ -984,10 +1225,8 @@ func (j *JiraAdapter) parseJiraIssue(issue map[string]interface{}) domain.Ticket
 				}
 			case map[string]interface{}:
 				// Handle objects (e.g., assignee, reporter, priority)
-				if name, ok := v["name"].(string); ok {
-					ticket.CustomFields[humanName] = name
-				} else if displayName, ok := v["displayName"].(string); ok {
-					ticket.CustomFields[humanName] = displayName
+				if value := objectFieldValue(v); value != "" {
+					ticket.CustomFields[humanName] = value
 				}
 			}
 		}
@@ -1013,3 +1252,27 @@ func (j *JiraAdapter) createReverseFieldMapping() map[string]string {
 
 	return reverse
 }
+
+// fieldID returns the Jira field ID of a field mapping
+func fieldID(mapping interface{}) string {
+	switch m := mapping.(type) {
+	case string:
+		return m
+	case map[string]interface{}:
+		id, _ := m["id"].(string)
+		return id
+	}
+	return ""
+}
+
+// objectFieldValue returns the value of an object field (e.g. assignee,
+// priority). Users are shown by name on Server and by display name on Cloud,
+// which has no names; push looks display names up to send their accountId.
+func objectFieldValue(v map[string]interface{}) string {
+	for _, key := range []string{"name", "displayName", "accountId"} {
+		if value, ok := v[key].(string); ok && value != "" {
+			return value
+		}
+	}
+	return ""
+}
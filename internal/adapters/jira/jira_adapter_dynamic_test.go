@@ -2,29 +2,38 @@ package jira
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"testing"
 
 	"github.com/karolswdev/ticktr/internal/core/domain"
 )
 
-// MockRoundTripper is a mock for testing HTTP requests
+// MockRoundTripper is a mock for testing HTTP requests. It may be shared by
+// concurrent requests; RoundTripFunc must then be safe for concurrent use.
 type MockRoundTripper struct {
 	RoundTripFunc func(req *http.Request) (*http.Response, error)
 	LastRequest   *http.Request
 	LastBody      []byte
+	mu            sync.Mutex // Guards LastRequest and LastBody
 }
 
 func (m *MockRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	m.mu.Lock()
 	m.LastRequest = req
 	if req.Body != nil {
-		body, _ := io.ReadAll(req.Body)
 		m.LastBody = body
-		req.Body = io.NopCloser(bytes.NewReader(body))
 	}
+	m.mu.Unlock()
 	return m.RoundTripFunc(req)
 }
 
@@ -66,8 +75,7 @@ func TestJiraAdapter_CreateTicket_DynamicPayload(t *testing.T) {
 	// Create adapter with custom field mappings
 	adapter := &JiraAdapter{
 		baseURL:       "https://test.atlassian.net",
-		email:         "test@example.com",
-		apiKey:        "test-key",
+		auth:          BasicAuth{Username: "test@example.com", Password: "test-key"},
 		projectKey:    "TEST",
 		storyType:     "Task",
 		client:        httpClient,
@@ -86,7 +94,7 @@ func TestJiraAdapter_CreateTicket_DynamicPayload(t *testing.T) {
 	}
 
 	// Call CreateTicket
-	key, err := adapter.CreateTicket(ticket)
+	key, err := adapter.CreateTicket(context.Background(), ticket)
 	if err != nil {
 		t.Fatalf("CreateTicket failed: %v", err)
 	}
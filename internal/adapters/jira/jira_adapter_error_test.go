@@ -2,6 +2,7 @@ package jira
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"errors"
 	"io"
@@ -27,8 +28,7 @@ func TestJiraAdapter_CreateTicket_APIError(t *testing.T) {
 
 	adapter := &JiraAdapter{
 		baseURL:       "https://test.atlassian.net",
-		email:         "test@example.com",
-		apiKey:        "test-api-key",
+		auth:          BasicAuth{Username: "test@example.com", Password: "test-api-key"},
 		projectKey:    "PROJ",
 		storyType:     "Task",
 		client:        &http.Client{Transport: mockTransport},
@@ -40,7 +40,7 @@ func TestJiraAdapter_CreateTicket_APIError(t *testing.T) {
 		Description: "Test Description",
 	}
 
-	_, err := adapter.CreateTicket(ticket)
+	_, err := adapter.CreateTicket(context.Background(), ticket)
 
 	if err == nil {
 		t.Fatal("Expected error for 403 response, got nil")
@@ -65,8 +65,7 @@ func TestJiraAdapter_CreateTicket_MalformedResponse(t *testing.T) {
 
 	adapter := &JiraAdapter{
 		baseURL:       "https://test.atlassian.net",
-		email:         "test@example.com",
-		apiKey:        "test-api-key",
+		auth:          BasicAuth{Username: "test@example.com", Password: "test-api-key"},
 		projectKey:    "PROJ",
 		storyType:     "Task",
 		client:        &http.Client{Transport: mockTransport},
@@ -78,7 +77,7 @@ func TestJiraAdapter_CreateTicket_MalformedResponse(t *testing.T) {
 		Description: "Test Description",
 	}
 
-	_, err := adapter.CreateTicket(ticket)
+	_, err := adapter.CreateTicket(context.Background(), ticket)
 
 	if err == nil {
 		t.Fatal("Expected error for malformed JSON, got nil")
@@ -95,8 +94,7 @@ func TestJiraAdapter_CreateTicket_NetworkError(t *testing.T) {
 
 	adapter := &JiraAdapter{
 		baseURL:       "https://test.atlassian.net",
-		email:         "test@example.com",
-		apiKey:        "test-api-key",
+		auth:          BasicAuth{Username: "test@example.com", Password: "test-api-key"},
 		projectKey:    "PROJ",
 		storyType:     "Task",
 		client:        &http.Client{Transport: mockTransport},
@@ -108,7 +106,7 @@ func TestJiraAdapter_CreateTicket_NetworkError(t *testing.T) {
 		Description: "Test Description",
 	}
 
-	_, err := adapter.CreateTicket(ticket)
+	_, err := adapter.CreateTicket(context.Background(), ticket)
 
 	if err == nil {
 		t.Fatal("Expected error for network timeout, got nil")
@@ -133,8 +131,7 @@ func TestJiraAdapter_UpdateTicket_APIError(t *testing.T) {
 
 	adapter := &JiraAdapter{
 		baseURL:       "https://test.atlassian.net",
-		email:         "test@example.com",
-		apiKey:        "test-api-key",
+		auth:          BasicAuth{Username: "test@example.com", Password: "test-api-key"},
 		projectKey:    "PROJ",
 		storyType:     "Task",
 		client:        &http.Client{Transport: mockTransport},
@@ -147,7 +144,7 @@ func TestJiraAdapter_UpdateTicket_APIError(t *testing.T) {
 		Description: "Test Description",
 	}
 
-	err := adapter.UpdateTicket(ticket)
+	err := adapter.UpdateTicket(context.Background(), ticket)
 
 	if err == nil {
 		t.Fatal("Expected error for 404 response, got nil")
@@ -162,8 +159,7 @@ func TestJiraAdapter_UpdateTicket_APIError(t *testing.T) {
 func TestJiraAdapter_UpdateTicket_EmptyJiraID(t *testing.T) {
 	adapter := &JiraAdapter{
 		baseURL:       "https://test.atlassian.net",
-		email:         "test@example.com",
-		apiKey:        "test-api-key",
+		auth:          BasicAuth{Username: "test@example.com", Password: "test-api-key"},
 		projectKey:    "PROJ",
 		storyType:     "Task",
 		client:        &http.Client{},
@@ -176,7 +172,7 @@ func TestJiraAdapter_UpdateTicket_EmptyJiraID(t *testing.T) {
 		Description: "Test Description",
 	}
 
-	err := adapter.UpdateTicket(ticket)
+	err := adapter.UpdateTicket(context.Background(), ticket)
 
 	if err == nil {
 		t.Fatal("Expected error for empty Jira ID, got nil")
@@ -197,8 +193,7 @@ func TestJiraAdapter_SearchTickets_APIError(t *testing.T) {
 
 	adapter := &JiraAdapter{
 		baseURL:       "https://test.atlassian.net",
-		email:         "test@example.com",
-		apiKey:        "test-api-key",
+		auth:          BasicAuth{Username: "test@example.com", Password: "test-api-key"},
 		projectKey:    "PROJ",
 		storyType:     "Task",
 		subTaskType:   "Sub-task",
@@ -206,7 +201,7 @@ func TestJiraAdapter_SearchTickets_APIError(t *testing.T) {
 		fieldMappings: getDefaultFieldMappings(),
 	}
 
-	_, err := adapter.SearchTickets("PROJ", "invalid jql $$")
+	_, err := adapter.SearchTickets(context.Background(), "PROJ", "invalid jql $$")
 
 	if err == nil {
 		t.Fatal("Expected error for invalid JQL, got nil")
@@ -231,8 +226,7 @@ func TestJiraAdapter_SearchTickets_EmptyResponse(t *testing.T) {
 
 	adapter := &JiraAdapter{
 		baseURL:       "https://test.atlassian.net",
-		email:         "test@example.com",
-		apiKey:        "test-api-key",
+		auth:          BasicAuth{Username: "test@example.com", Password: "test-api-key"},
 		projectKey:    "PROJ",
 		storyType:     "Task",
 		subTaskType:   "Sub-task",
@@ -240,7 +234,7 @@ func TestJiraAdapter_SearchTickets_EmptyResponse(t *testing.T) {
 		fieldMappings: getDefaultFieldMappings(),
 	}
 
-	tickets, err := adapter.SearchTickets("PROJ", "status = Done")
+	tickets, err := adapter.SearchTickets(context.Background(), "PROJ", "status = Done")
 
 	if err != nil {
 		t.Fatalf("Expected no error for empty results, got: %v", err)
@@ -265,8 +259,7 @@ func TestJiraAdapter_SearchTickets_MalformedJiraResponse(t *testing.T) {
 
 	adapter := &JiraAdapter{
 		baseURL:       "https://test.atlassian.net",
-		email:         "test@example.com",
-		apiKey:        "test-api-key",
+		auth:          BasicAuth{Username: "test@example.com", Password: "test-api-key"},
 		projectKey:    "PROJ",
 		storyType:     "Task",
 		subTaskType:   "Sub-task",
@@ -275,7 +268,7 @@ func TestJiraAdapter_SearchTickets_MalformedJiraResponse(t *testing.T) {
 	}
 
 	// Should handle null fields gracefully
-	tickets, err := adapter.SearchTickets("PROJ", "")
+	tickets, err := adapter.SearchTickets(context.Background(), "PROJ", "")
 
 	if err != nil {
 		t.Fatalf("Expected no error for null fields, got: %v", err)
@@ -301,15 +294,14 @@ func TestJiraAdapter_Authenticate_InvalidCredentials(t *testing.T) {
 
 	adapter := &JiraAdapter{
 		baseURL:       "https://test.atlassian.net",
-		email:         "wrong@example.com",
-		apiKey:        "wrong-api-key",
+		auth:          BasicAuth{Username: "wrong@example.com", Password: "wrong-api-key"},
 		projectKey:    "PROJ",
 		storyType:     "Task",
 		client:        &http.Client{Transport: mockTransport},
 		fieldMappings: getDefaultFieldMappings(),
 	}
 
-	err := adapter.Authenticate()
+	err := adapter.Authenticate(context.Background())
 
 	if err == nil {
 		t.Fatal("Expected error for invalid credentials, got nil")
@@ -356,8 +348,7 @@ func TestJiraAdapter_FieldMapping_MissingFields(t *testing.T) {
 
 	adapter := &JiraAdapter{
 		baseURL:       "https://test.atlassian.net",
-		email:         "test@example.com",
-		apiKey:        "test-api-key",
+		auth:          BasicAuth{Username: "test@example.com", Password: "test-api-key"},
 		projectKey:    "PROJ",
 		storyType:     "Task",
 		subTaskType:   "Sub-task",
@@ -365,7 +356,7 @@ func TestJiraAdapter_FieldMapping_MissingFields(t *testing.T) {
 		fieldMappings: getDefaultFieldMappings(),
 	}
 
-	tickets, err := adapter.SearchTickets("PROJ", "")
+	tickets, err := adapter.SearchTickets(context.Background(), "PROJ", "")
 
 	if err != nil {
 		t.Fatalf("Expected no error for missing fields, got: %v", err)
@@ -398,8 +389,7 @@ func TestJiraAdapter_RateLimiting(t *testing.T) {
 
 	adapter := &JiraAdapter{
 		baseURL:       "https://test.atlassian.net",
-		email:         "test@example.com",
-		apiKey:        "test-api-key",
+		auth:          BasicAuth{Username: "test@example.com", Password: "test-api-key"},
 		projectKey:    "PROJ",
 		storyType:     "Task",
 		client:        &http.Client{Transport: mockTransport},
@@ -411,7 +401,7 @@ func TestJiraAdapter_RateLimiting(t *testing.T) {
 		Description: "Test Description",
 	}
 
-	_, err := adapter.CreateTicket(ticket)
+	_, err := adapter.CreateTicket(context.Background(), ticket)
 
 	if err == nil {
 		t.Fatal("Expected error for rate limiting, got nil")
@@ -442,8 +432,7 @@ func TestJiraAdapter_CreateTicket_SpecialCharacters(t *testing.T) {
 
 	adapter := &JiraAdapter{
 		baseURL:       "https://test.atlassian.net",
-		email:         "test@example.com",
-		apiKey:        "test-api-key",
+		auth:          BasicAuth{Username: "test@example.com", Password: "test-api-key"},
 		projectKey:    "PROJ",
 		storyType:     "Task",
 		client:        &http.Client{Transport: mockTransport},
@@ -459,7 +448,7 @@ func TestJiraAdapter_CreateTicket_SpecialCharacters(t *testing.T) {
 		},
 	}
 
-	key, err := adapter.CreateTicket(ticket)
+	key, err := adapter.CreateTicket(context.Background(), ticket)
 
 	if err != nil {
 		t.Fatalf("Expected no error for special characters, got: %v", err)
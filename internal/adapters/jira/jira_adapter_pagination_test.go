@@ -0,0 +1,313 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/karolswdev/ticktr/internal/core/ports"
+)
+
+// issuePage builds a search response body containing the given issue keys
+func issuePage(keys []string, extra string) string {
+	issues := make([]string, 0, len(keys))
+	for _, key := range keys {
+		issues = append(issues, fmt.Sprintf(`{"key": %q, "fields": {"summary": "Issue %s", "issuetype": {"name": "Task"}}}`, key, key))
+	}
+	return fmt.Sprintf(`{"issues": [%s]%s}`, strings.Join(issues, ","), extra)
+}
+
+// searchRequest decodes the JSON body of a captured search request
+func searchRequest(t *testing.T, req *http.Request) map[string]interface{} {
+	t.Helper()
+	body, _ := io.ReadAll(req.Body)
+	var payload map[string]interface{}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		t.Fatalf("Failed to decode search payload: %v", err)
+	}
+	return payload
+}
+
+// TestSearchTickets_PaginatesWithStartAt verifies the classic endpoint is paged until total is reached
+func TestSearchTickets_PaginatesWithStartAt(t *testing.T) {
+	allKeys := []string{"PROJ-1", "PROJ-2", "PROJ-3", "PROJ-4", "PROJ-5"}
+	var startAts []int
+
+	mockTransport := &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			payload := searchRequest(t, req)
+			jql := payload["jql"].(string)
+
+			// Subtask searches return nothing
+			if strings.HasPrefix(jql, "parent") {
+				return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"issues": [], "total": 0}`))}, nil
+			}
+
+			startAt := int(payload["startAt"].(float64))
+			maxResults := int(payload["maxResults"].(float64))
+			startAts = append(startAts, startAt)
+
+			end := startAt + maxResults
+			if end > len(allKeys) {
+				end = len(allKeys)
+			}
+			body := issuePage(allKeys[startAt:end], fmt.Sprintf(`, "startAt": %d, "total": %d`, startAt, len(allKeys)))
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(body))}, nil
+		},
+	}
+
+	adapter := &JiraAdapter{
+		baseURL:       "https://test.atlassian.net",
+		projectKey:    "PROJ",
+		client:        &http.Client{Transport: mockTransport},
+		fieldMappings: getDefaultFieldMappings(),
+		pageSize:      2,
+	}
+
+	tickets, err := adapter.SearchTickets(context.Background(), "PROJ", "")
+	if err != nil {
+		t.Fatalf("SearchTickets returned error: %v", err)
+	}
+
+	if len(tickets) != len(allKeys) {
+		t.Fatalf("Expected %d tickets across pages, got %d", len(allKeys), len(tickets))
+	}
+	for i, key := range allKeys {
+		if tickets[i].JiraID != key {
+			t.Errorf("Expected ticket %d to be %s, got %s", i, key, tickets[i].JiraID)
+		}
+	}
+
+	expectedStarts := []int{0, 2, 4}
+	if fmt.Sprint(startAts) != fmt.Sprint(expectedStarts) {
+		t.Errorf("Expected startAt sequence %v, got %v", expectedStarts, startAts)
+	}
+}
+
+// TestSearchTickets_PaginatesWithNextPageToken verifies the search/jql endpoint follows nextPageToken
+func TestSearchTickets_PaginatesWithNextPageToken(t *testing.T) {
+	var tokens []string
+
+	mockTransport := &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			if !strings.HasSuffix(req.URL.Path, "/rest/api/2/search/jql") {
+				t.Errorf("Expected search/jql endpoint, got %s", req.URL.Path)
+			}
+
+			payload := searchRequest(t, req)
+			if _, hasStartAt := payload["startAt"]; hasStartAt {
+				t.Error("search/jql requests must not send startAt")
+			}
+			if strings.HasPrefix(payload["jql"].(string), "parent") {
+				return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"issues": [], "isLast": true}`))}, nil
+			}
+
+			token, _ := payload["nextPageToken"].(string)
+			tokens = append(tokens, token)
+
+			var body string
+			switch token {
+			case "":
+				body = issuePage([]string{"PROJ-1", "PROJ-2"}, `, "nextPageToken": "page-2", "isLast": false`)
+			case "page-2":
+				body = issuePage([]string{"PROJ-3"}, `, "isLast": true`)
+			default:
+				t.Fatalf("Unexpected page token %q", token)
+			}
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(body))}, nil
+		},
+	}
+
+	adapter := &JiraAdapter{
+		baseURL:        "https://test.atlassian.net",
+		projectKey:     "PROJ",
+		client:         &http.Client{Transport: mockTransport},
+		fieldMappings:  getDefaultFieldMappings(),
+		pageSize:       2,
+		searchEndpoint: SearchEndpointJQL,
+	}
+
+	tickets, err := adapter.SearchTickets(context.Background(), "PROJ", "")
+	if err != nil {
+		t.Fatalf("SearchTickets returned error: %v", err)
+	}
+
+	if len(tickets) != 3 {
+		t.Fatalf("Expected 3 tickets across token pages, got %d", len(tickets))
+	}
+	if fmt.Sprint(tokens) != fmt.Sprint([]string{"", "page-2"}) {
+		t.Errorf("Unexpected token sequence: %q", tokens)
+	}
+}
+
+// TestSearchTickets_RespectsMaxResultsCap verifies the total cap stops
+// pagination and is reported as a partial result
+func TestSearchTickets_RespectsMaxResultsCap(t *testing.T) {
+	pages := 0
+	mockTransport := &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			payload := searchRequest(t, req)
+			if strings.HasPrefix(payload["jql"].(string), "parent") {
+				return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"issues": [], "total": 0}`))}, nil
+			}
+
+			pages++
+			maxResults := int(payload["maxResults"].(float64))
+			keys := []string{}
+			for i := 0; i < maxResults; i++ {
+				keys = append(keys, fmt.Sprintf("PROJ-%d", pages*10+i))
+			}
+			body := issuePage(keys, `, "total": 1000`)
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(body))}, nil
+		},
+	}
+
+	adapter := &JiraAdapter{
+		baseURL:       "https://test.atlassian.net",
+		projectKey:    "PROJ",
+		client:        &http.Client{Transport: mockTransport},
+		fieldMappings: getDefaultFieldMappings(),
+		pageSize:      4,
+		maxResults:    6,
+	}
+
+	tickets, err := adapter.SearchTickets(context.Background(), "PROJ", "")
+	var partial *ports.PartialResultError
+	if !errors.As(err, &partial) || len(partial.Keys) != 0 || !strings.Contains(err.Error(), "max_results") {
+		t.Fatalf("Expected a partial result naming the cap, got %v", err)
+	}
+
+	if len(tickets) != 6 {
+		t.Errorf("Expected search to stop at the cap of 6, got %d", len(tickets))
+	}
+	if pages != 2 {
+		t.Errorf("Expected 2 page requests (4 + 2), got %d", pages)
+	}
+}
+
+// TestSearchTickets_MaxResultsCapSkipsSubtasks verifies the cap bounds the
+// tickets searched for but not the subtasks fetched for them
+func TestSearchTickets_MaxResultsCapSkipsSubtasks(t *testing.T) {
+	mockTransport := &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			payload := searchRequest(t, req)
+			if !strings.HasPrefix(payload["jql"].(string), "parent") {
+				return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(issuePage([]string{"PROJ-1"}, `, "total": 1`)))}, nil
+			}
+
+			if int(payload["maxResults"].(float64)) != 4 {
+				t.Errorf("Expected subtask pages of the page size, got %v", payload["maxResults"])
+			}
+			startAt := int(payload["startAt"].(float64))
+			var subtasks []string
+			for i := startAt; i < min(startAt+4, 5); i++ {
+				subtasks = append(subtasks, fmt.Sprintf(`{"key": "PROJ-%d", "fields": {"summary": "Subtask", "parent": {"key": "PROJ-1"}}}`, 10+i))
+			}
+			body := fmt.Sprintf(`{"issues": [%s], "total": 5}`, strings.Join(subtasks, ","))
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(body))}, nil
+		},
+	}
+
+	adapter := &JiraAdapter{
+		baseURL:       "https://test.atlassian.net",
+		projectKey:    "PROJ",
+		client:        &http.Client{Transport: mockTransport},
+		fieldMappings: getDefaultFieldMappings(),
+		pageSize:      4,
+		maxResults:    2,
+	}
+
+	tickets, err := adapter.SearchTickets(context.Background(), "PROJ", "")
+	if err != nil {
+		t.Fatalf("SearchTickets returned error: %v", err)
+	}
+	if len(tickets) != 1 || len(tickets[0].Tasks) != 5 {
+		t.Errorf("Expected one ticket with all 5 subtasks, got %+v", tickets)
+	}
+}
+
+// TestNewJiraAdapterWithOptions_RejectsUnknownSearchEndpoint verifies option validation
+func TestNewJiraAdapterWithOptions_RejectsUnknownSearchEndpoint(t *testing.T) {
+	t.Setenv("JIRA_URL", "https://test.atlassian.net")
+	t.Setenv("JIRA_EMAIL", "test@example.com")
+	t.Setenv("JIRA_API_KEY", "key")
+	t.Setenv("JIRA_PROJECT_KEY", "PROJ")
+
+	if _, err := NewJiraAdapterWithOptions(Options{SearchEndpoint: "graphql"}); err == nil {
+		t.Error("Expected error for unsupported search endpoint")
+	}
+
+	adapter, err := NewJiraAdapterWithOptions(Options{PageSize: 50, SearchEndpoint: SearchEndpointJQL})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if a := adapter.(*JiraAdapter); a.pageSize != 50 || a.searchEndpoint != SearchEndpointJQL {
+		t.Errorf("Options were not applied: %+v", a)
+	}
+}
+
+// TestSearchTickets_BatchesSubtaskQueries verifies subtasks are fetched with chunked parent-in queries
+func TestSearchTickets_BatchesSubtaskQueries(t *testing.T) {
+	parentCount := subtaskBatchSize + 2
+	var subtaskQueries []string
+
+	mockTransport := &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			jql := searchRequest(t, req)["jql"].(string)
+
+			if !strings.HasPrefix(jql, "parent in (") {
+				keys := []string{}
+				for i := 1; i <= parentCount; i++ {
+					keys = append(keys, fmt.Sprintf("PROJ-%d", i))
+				}
+				return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(issuePage(keys, fmt.Sprintf(`, "total": %d`, parentCount))))}, nil
+			}
+
+			subtaskQueries = append(subtaskQueries, jql)
+			body := `{"issues": [], "total": 0}`
+			if strings.Contains(jql, `"PROJ-1",`) {
+				body = `{"issues": [
+					{"key": "PROJ-900", "fields": {"summary": "Sub A", "issuetype": {"name": "Sub-task"}, "parent": {"key": "PROJ-2"}}},
+					{"key": "PROJ-901", "fields": {"summary": "Sub B", "issuetype": {"name": "Sub-task"}, "parent": {"key": "PROJ-1"}}},
+					{"key": "PROJ-902", "fields": {"summary": "Sub C", "issuetype": {"name": "Sub-task"}, "parent": {"key": "PROJ-2"}}}
+				], "total": 3}`
+			}
+			return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(body))}, nil
+		},
+	}
+
+	adapter := &JiraAdapter{
+		baseURL:       "https://test.atlassian.net",
+		projectKey:    "PROJ",
+		client:        &http.Client{Transport: mockTransport},
+		fieldMappings: getDefaultFieldMappings(),
+	}
+
+	tickets, err := adapter.SearchTickets(context.Background(), "PROJ", "")
+	if err != nil {
+		t.Fatalf("SearchTickets returned error: %v", err)
+	}
+
+	if len(subtaskQueries) != 2 {
+		t.Fatalf("Expected %d parents to be fetched in 2 batches, got %d queries", parentCount, len(subtaskQueries))
+	}
+	if !strings.HasPrefix(subtaskQueries[0], `parent in ("PROJ-1", "PROJ-2", `) {
+		t.Errorf("Unexpected batch query: %s", subtaskQueries[0])
+	}
+
+	if len(tickets[0].Tasks) != 1 || tickets[0].Tasks[0].JiraID != "PROJ-901" {
+		t.Errorf("Expected PROJ-1 to get PROJ-901, got %+v", tickets[0].Tasks)
+	}
+	if len(tickets[1].Tasks) != 2 || tickets[1].Tasks[0].JiraID != "PROJ-900" || tickets[1].Tasks[1].JiraID != "PROJ-902" {
+		t.Errorf("Expected PROJ-2 to get PROJ-900 and PROJ-902 in order, got %+v", tickets[1].Tasks)
+	}
+	if tickets[2].Tasks == nil || len(tickets[2].Tasks) != 0 {
+		t.Errorf("Expected PROJ-3 to have an empty task list, got %+v", tickets[2].Tasks)
+	}
+}
@@ -2,7 +2,9 @@ package jira
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"net/http"
 	"os"
@@ -10,6 +12,7 @@ import (
 	"testing"
 
 	"github.com/karolswdev/ticktr/internal/core/domain"
+	"github.com/karolswdev/ticktr/internal/core/ports"
 )
 
 // Test Case TC-2.1: JiraAdapter_NewClient_WithEnvVars_AuthenticatesSuccessfully
@@ -29,7 +32,7 @@ func TestJiraAdapter_NewClient_WithEnvVars_AuthenticatesSuccessfully(t *testing.
 	}
 
 	// Assert: The client authenticates successfully
-	err = adapter.Authenticate()
+	err = adapter.Authenticate(context.Background())
 	if err != nil {
 		t.Errorf("Authentication failed: %v", err)
 	}
@@ -60,7 +63,7 @@ func TestJiraAdapter_CreateStory_ValidStory_ReturnsNewJiraID(t *testing.T) {
 	}
 
 	// Act: Call the CreateTicket method on the Jira adapter
-	jiraID, err := adapter.CreateTicket(ticket)
+	jiraID, err := adapter.CreateTicket(context.Background(), ticket)
 
 	// Assert: The method returns a valid, non-empty Jira Issue Key
 	if err != nil {
@@ -99,7 +102,7 @@ func TestJiraAdapter_UpdateStory_ValidStoryWithID_Succeeds(t *testing.T) {
 		Tasks:        []domain.Task{},
 	}
 
-	jiraID, err := adapter.CreateTicket(initialTicket)
+	jiraID, err := adapter.CreateTicket(context.Background(), initialTicket)
 	if err != nil {
 		t.Fatalf("Failed to create initial ticket: %v", err)
 	}
@@ -119,7 +122,7 @@ func TestJiraAdapter_UpdateStory_ValidStoryWithID_Succeeds(t *testing.T) {
 	}
 
 	// Act: Call the UpdateTicket method on the Jira adapter
-	err = adapter.UpdateTicket(updatedTicket)
+	err = adapter.UpdateTicket(context.Background(), updatedTicket)
 
 	// Assert: The method succeeds and the description in Jira is updated
 	if err != nil {
@@ -155,8 +158,7 @@ func TestJiraAdapter_SearchTickets_ConstructsJql(t *testing.T) {
 	// Create JiraAdapter with mocked client
 	adapter := &JiraAdapter{
 		baseURL:       "https://test.atlassian.net",
-		email:         "test@example.com",
-		apiKey:        "test-api-key",
+		auth:          BasicAuth{Username: "test@example.com", Password: "test-api-key"},
 		projectKey:    "PROJ",
 		storyType:     "Task",
 		subTaskType:   "Sub-task",
@@ -165,7 +167,7 @@ func TestJiraAdapter_SearchTickets_ConstructsJql(t *testing.T) {
 	}
 
 	// Act: Call SearchTickets with a project key "PROJ" and JQL "status=Done"
-	_, err := adapter.SearchTickets("PROJ", "status=Done")
+	_, err := adapter.SearchTickets(context.Background(), "PROJ", "status=Done")
 
 	// Assert: The request sent to Jira's /rest/api/2/search endpoint contains the JQL
 	if err != nil {
@@ -255,7 +257,8 @@ func TestSearchTickets_WithSubtasks(t *testing.T) {
 						"fields": {
 							"summary": "Subtask 1",
 							"description": "Description for subtask 1",
-							"issuetype": {"name": "Sub-task"}
+							"issuetype": {"name": "Sub-task"},
+							"parent": {"key": "PROJ-123"}
 						}
 					},
 					{
@@ -263,7 +266,8 @@ func TestSearchTickets_WithSubtasks(t *testing.T) {
 						"fields": {
 							"summary": "Subtask 2",
 							"description": "Description for subtask 2",
-							"issuetype": {"name": "Sub-task"}
+							"issuetype": {"name": "Sub-task"},
+							"parent": {"key": "PROJ-123"}
 						}
 					}
 				],
@@ -280,8 +284,7 @@ func TestSearchTickets_WithSubtasks(t *testing.T) {
 
 	adapter := &JiraAdapter{
 		baseURL:       "https://test.atlassian.net",
-		email:         "test@example.com",
-		apiKey:        "test-api-key",
+		auth:          BasicAuth{Username: "test@example.com", Password: "test-api-key"},
 		projectKey:    "PROJ",
 		storyType:     "Task",
 		subTaskType:   "Sub-task",
@@ -290,7 +293,7 @@ func TestSearchTickets_WithSubtasks(t *testing.T) {
 	}
 
 	// Act: Call SearchTickets
-	tickets, err := adapter.SearchTickets("PROJ", "")
+	tickets, err := adapter.SearchTickets(context.Background(), "PROJ", "")
 
 	// Assert: Verify parent ticket has 2 subtasks
 	if err != nil {
@@ -367,6 +370,7 @@ func TestSearchTickets_SubtaskFieldMapping(t *testing.T) {
 						"summary": "Subtask with Custom Fields",
 						"description": "Subtask description",
 						"issuetype": {"name": "Sub-task"},
+							"parent": {"key": "PROJ-200"},
 						"customfield_10010": 5,
 						"customfield_10020": "Sprint 23"
 					}
@@ -382,8 +386,7 @@ func TestSearchTickets_SubtaskFieldMapping(t *testing.T) {
 
 	adapter := &JiraAdapter{
 		baseURL:       "https://test.atlassian.net",
-		email:         "test@example.com",
-		apiKey:        "test-api-key",
+		auth:          BasicAuth{Username: "test@example.com", Password: "test-api-key"},
 		projectKey:    "PROJ",
 		storyType:     "Task",
 		subTaskType:   "Sub-task",
@@ -392,7 +395,7 @@ func TestSearchTickets_SubtaskFieldMapping(t *testing.T) {
 	}
 
 	// Act: Call SearchTickets
-	tickets, err := adapter.SearchTickets("PROJ", "")
+	tickets, err := adapter.SearchTickets(context.Background(), "PROJ", "")
 
 	// Assert: Verify subtask custom fields are mapped correctly
 	if err != nil {
@@ -464,8 +467,7 @@ func TestSearchTickets_NoSubtasks(t *testing.T) {
 
 	adapter := &JiraAdapter{
 		baseURL:       "https://test.atlassian.net",
-		email:         "test@example.com",
-		apiKey:        "test-api-key",
+		auth:          BasicAuth{Username: "test@example.com", Password: "test-api-key"},
 		projectKey:    "PROJ",
 		storyType:     "Task",
 		subTaskType:   "Sub-task",
@@ -474,7 +476,7 @@ func TestSearchTickets_NoSubtasks(t *testing.T) {
 	}
 
 	// Act: Call SearchTickets
-	tickets, err := adapter.SearchTickets("PROJ", "")
+	tickets, err := adapter.SearchTickets(context.Background(), "PROJ", "")
 
 	// Assert: Verify ticket has empty tasks array (not nil)
 	if err != nil {
@@ -535,8 +537,7 @@ func TestSearchTickets_SubtaskFetchError(t *testing.T) {
 
 	adapter := &JiraAdapter{
 		baseURL:       "https://test.atlassian.net",
-		email:         "test@example.com",
-		apiKey:        "test-api-key",
+		auth:          BasicAuth{Username: "test@example.com", Password: "test-api-key"},
 		projectKey:    "PROJ",
 		storyType:     "Task",
 		subTaskType:   "Sub-task",
@@ -545,11 +546,15 @@ func TestSearchTickets_SubtaskFetchError(t *testing.T) {
 	}
 
 	// Act: Call SearchTickets
-	tickets, err := adapter.SearchTickets("PROJ", "")
+	tickets, err := adapter.SearchTickets(context.Background(), "PROJ", "")
 
-	// Assert: Verify parent ticket is still returned despite subtask error
-	if err != nil {
-		t.Fatalf("SearchTickets should not fail when subtask fetch fails: %v", err)
+	// Assert: Verify parent ticket is still returned and the subtask failure is surfaced
+	var partial *ports.PartialResultError
+	if !errors.As(err, &partial) {
+		t.Fatalf("Expected a PartialResultError when subtask fetch fails, got: %v", err)
+	}
+	if len(partial.Keys) != 1 || partial.Keys[0] != "PROJ-400" {
+		t.Errorf("Expected PROJ-400 to be reported as incomplete, got %v", partial.Keys)
 	}
 
 	if len(tickets) != 1 {
@@ -561,10 +566,35 @@ func TestSearchTickets_SubtaskFetchError(t *testing.T) {
 		t.Errorf("Expected ticket JiraID PROJ-400, got %s", ticket.JiraID)
 	}
 
-	// Subtask fetch failed, so Tasks should be empty or nil (but not cause error)
-	if ticket.Tasks == nil {
-		ticket.Tasks = []domain.Task{}
+	// Subtask fetch failed, so Tasks should be empty
+	if len(ticket.Tasks) != 0 {
+		t.Errorf("Expected no tasks for a failed subtask batch, got %d", len(ticket.Tasks))
 	}
 
 	t.Logf("Successfully verified non-fatal subtask fetch error")
 }
+
+// TestJiraAdapter_SearchTickets_WithoutProject verifies an empty project key searches by the JQL alone
+func TestJiraAdapter_SearchTickets_WithoutProject(t *testing.T) {
+	var jql string
+	adapter := &JiraAdapter{
+		baseURL: "https://test.atlassian.net",
+		auth:    BasicAuth{Username: "test@example.com", Password: "test-api-key"},
+		client: &http.Client{Transport: &MockRoundTripper{
+			RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+				var body map[string]interface{}
+				json.NewDecoder(req.Body).Decode(&body)
+				jql, _ = body["jql"].(string)
+				return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(`{"issues": [], "total": 0}`))}, nil
+			},
+		}},
+		fieldMappings: getDefaultFieldMappings(),
+	}
+
+	if _, err := adapter.SearchTickets(context.Background(), "", `key in ("PROJ-1")`); err != nil {
+		t.Fatalf("SearchTickets returned error: %v", err)
+	}
+	if jql != `key in ("PROJ-1")` {
+		t.Errorf("Expected the JQL without a project filter, got %s", jql)
+	}
+}
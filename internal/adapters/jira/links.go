@@ -0,0 +1,215 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+)
+
+// linkType is a Jira issue link type, e.g. Blocks with outward "blocks" and
+// inward "is blocked by"
+type linkType struct {
+	Name    string `json:"name"`
+	Inward  string `json:"inward"`
+	Outward string `json:"outward"`
+}
+
+// LinkIssues creates the links from issueKey that Jira does not have yet
+func (j *JiraAdapter) LinkIssues(ctx context.Context, issueKey string, links []domain.Link) error {
+	for _, link := range links {
+		if link.Key == "" {
+			return fmt.Errorf("link %q to %q has no Jira key", link.Type, link.Title)
+		}
+	}
+
+	missing, err := j.DiffLinks(ctx, issueKey, links)
+	if err != nil {
+		return err
+	}
+	types, err := j.getLinkTypes(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, link := range missing {
+		name, outward, err := resolveLinkType(types, link.Type)
+		if err != nil {
+			return err
+		}
+
+		// Jira reads a link as "inwardIssue <outward> outwardIssue"
+		inwardIssue, outwardIssue := issueKey, link.Key
+		if !outward {
+			inwardIssue, outwardIssue = link.Key, issueKey
+		}
+		payload, err := json.Marshal(map[string]interface{}{
+			"type":         map[string]string{"name": name},
+			"inwardIssue":  map[string]string{"key": inwardIssue},
+			"outwardIssue": map[string]string{"key": outwardIssue},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal payload: %w", err)
+		}
+
+		resp, err := j.do(ctx, "POST", j.restURL("issueLink"), payload, false)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusCreated && resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("failed to link %s %s %s with status %d: %s", issueKey, link.Type, link.Key, resp.StatusCode, string(resp.Body))
+		}
+	}
+
+	return nil
+}
+
+// DiffLinks reports the links LinkIssues would create: those Jira does not
+// have yet, once each. It fails on a link type Jira does not know.
+func (j *JiraAdapter) DiffLinks(ctx context.Context, issueKey string, links []domain.Link) ([]domain.Link, error) {
+	if len(links) == 0 {
+		return nil, nil
+	}
+
+	types, err := j.getLinkTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var existing []domain.Link
+	if issueKey != "" {
+		if existing, err = j.getIssueLinks(ctx, issueKey); err != nil {
+			return nil, err
+		}
+	}
+
+	var missing []domain.Link
+	for _, link := range links {
+		if link.Key != "" && hasLink(existing, link) {
+			continue
+		}
+		if _, _, err := resolveLinkType(types, link.Type); err != nil {
+			return nil, err
+		}
+		missing = append(missing, link)
+		existing = append(existing, link)
+	}
+	return missing, nil
+}
+
+// getLinkTypes fetches the issue link types configured in Jira, once per adapter
+func (j *JiraAdapter) getLinkTypes(ctx context.Context) ([]linkType, error) {
+	j.linkTypesMu.Lock()
+	defer j.linkTypesMu.Unlock()
+	if j.linkTypes != nil {
+		return j.linkTypes, nil
+	}
+
+	resp, err := j.do(ctx, "GET", j.restURL("issueLinkType"), nil, true)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get issue link types with status %d: %s", resp.StatusCode, string(resp.Body))
+	}
+
+	var result struct {
+		IssueLinkTypes []linkType `json:"issueLinkTypes"`
+	}
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	j.linkTypes = result.IssueLinkTypes
+	return j.linkTypes, nil
+}
+
+// getIssueLinks fetches the links Jira has for an issue
+func (j *JiraAdapter) getIssueLinks(ctx context.Context, issueKey string) ([]domain.Link, error) {
+	resp, err := j.do(ctx, "GET", j.restURL(fmt.Sprintf("issue/%s?fields=issuelinks", issueKey)), nil, true)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get issue %s with status %d: %s", issueKey, resp.StatusCode, string(resp.Body))
+	}
+
+	var issue map[string]interface{}
+	if err := json.Unmarshal(resp.Body, &issue); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	fields, _ := issue["fields"].(map[string]interface{})
+	return parseIssueLinks(fields["issuelinks"]), nil
+}
+
+// resolveLinkType finds the link type a relationship names, matching its
+// outward or inward description, or its name, case-insensitively. outward
+// reports whether the relationship reads from the issue to the other one.
+func resolveLinkType(types []linkType, relationship string) (name string, outward bool, err error) {
+	relationship = strings.TrimSpace(relationship)
+	for _, t := range types {
+		switch {
+		case strings.EqualFold(t.Outward, relationship), strings.EqualFold(t.Name, relationship):
+			return t.Name, true, nil
+		case strings.EqualFold(t.Inward, relationship):
+			return t.Name, false, nil
+		}
+	}
+
+	var known []string
+	for _, t := range types {
+		known = append(known, t.Outward, t.Inward)
+	}
+	return "", false, fmt.Errorf("unknown link type %q. Available: %s", relationship, strings.Join(known, ", "))
+}
+
+// parseIssueLinks converts a Jira issuelinks field into links written from the issue's side
+func parseIssueLinks(value interface{}) []domain.Link {
+	entries, ok := value.([]interface{})
+	if !ok {
+		return nil
+	}
+
+	var links []domain.Link
+	for _, e := range entries {
+		entry, ok := e.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		t, _ := entry["type"].(map[string]interface{})
+
+		// The entry holds the other issue on the side the relationship points to
+		var other map[string]interface{}
+		var description string
+		if issue, ok := entry["outwardIssue"].(map[string]interface{}); ok {
+			other, description = issue, stringValue(t["outward"])
+		} else if issue, ok := entry["inwardIssue"].(map[string]interface{}); ok {
+			other, description = issue, stringValue(t["inward"])
+		}
+
+		if key := stringValue(other["key"]); key != "" && description != "" {
+			links = append(links, domain.Link{Type: description, Key: key})
+		}
+	}
+	return links
+}
+
+// hasLink reports whether links contain the same relationship to the same issue
+func hasLink(links []domain.Link, link domain.Link) bool {
+	for _, l := range links {
+		if l.Key == link.Key && strings.EqualFold(l.Type, link.Type) {
+			return true
+		}
+	}
+	return false
+}
+
+// stringValue returns v if it is a string, or ""
+func stringValue(v interface{}) string {
+	s, _ := v.(string)
+	return s
+}
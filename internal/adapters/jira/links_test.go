@@ -0,0 +1,175 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+)
+
+const linkTypesResponse = `{"issueLinkTypes": [
+	{"name": "Blocks", "inward": "is blocked by", "outward": "blocks"},
+	{"name": "Relates", "inward": "relates to", "outward": "relates to"}
+]}`
+
+// TestLinkIssues_CreatesMissingLinks verifies links are created in the right direction and existing ones are skipped
+func TestLinkIssues_CreatesMissingLinks(t *testing.T) {
+	var created []map[string]interface{}
+	typeRequests := 0
+	mockTransport := &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.Path == "/rest/api/2/issueLinkType":
+				typeRequests++
+				return response(200, nil, linkTypesResponse), nil
+			case req.URL.Path == "/rest/api/2/issue/PROJ-1":
+				return response(200, nil, `{"fields": {"issuelinks": [
+					{"type": {"name": "Relates", "inward": "relates to", "outward": "relates to"}, "inwardIssue": {"key": "PROJ-7"}}
+				]}}`), nil
+			case req.Method == "POST" && req.URL.Path == "/rest/api/2/issueLink":
+				body, _ := io.ReadAll(req.Body)
+				var payload map[string]interface{}
+				json.Unmarshal(body, &payload)
+				created = append(created, payload)
+				return response(201, nil, ``), nil
+			}
+			return response(404, nil, `{}`), nil
+		},
+	}
+
+	adapter := &JiraAdapter{
+		baseURL: "https://test.atlassian.net",
+		client:  &http.Client{Transport: mockTransport},
+	}
+
+	links := []domain.Link{
+		{Type: "blocks", Key: "PROJ-2"},
+		{Type: "Is Blocked By", Key: "PROJ-3"},
+		{Type: "relates to", Key: "PROJ-7"},
+	}
+	if err := adapter.LinkIssues(context.Background(), "PROJ-1", links); err != nil {
+		t.Fatalf("LinkIssues failed: %v", err)
+	}
+
+	if len(created) != 2 {
+		t.Fatalf("Expected 2 links created (the relates to link exists), got %v", created)
+	}
+	check := func(payload map[string]interface{}, inward, outward string) {
+		t.Helper()
+		if payload["type"].(map[string]interface{})["name"] != "Blocks" ||
+			payload["inwardIssue"].(map[string]interface{})["key"] != inward ||
+			payload["outwardIssue"].(map[string]interface{})["key"] != outward {
+			t.Errorf("Expected %s blocks %s, got %v", inward, outward, payload)
+		}
+	}
+	check(created[0], "PROJ-1", "PROJ-2")
+	check(created[1], "PROJ-3", "PROJ-1")
+
+	// Link types are fetched once per adapter
+	if err := adapter.LinkIssues(context.Background(), "PROJ-1", links[:1]); err != nil {
+		t.Fatalf("LinkIssues failed: %v", err)
+	}
+	if typeRequests != 1 {
+		t.Errorf("Expected link types to be fetched once, got %d requests", typeRequests)
+	}
+}
+
+// TestLinkIssues_UnknownType verifies an unknown relationship fails with the available ones
+func TestLinkIssues_UnknownType(t *testing.T) {
+	mockTransport := &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path == "/rest/api/2/issueLinkType" {
+				return response(200, nil, linkTypesResponse), nil
+			}
+			return response(200, nil, `{"fields": {}}`), nil
+		},
+	}
+	adapter := &JiraAdapter{baseURL: "https://test.atlassian.net", client: &http.Client{Transport: mockTransport}}
+
+	err := adapter.LinkIssues(context.Background(), "PROJ-1", []domain.Link{{Type: "depends on", Key: "PROJ-2"}})
+	if err == nil || !strings.Contains(err.Error(), "is blocked by") {
+		t.Errorf("Expected an error listing the link types, got %v", err)
+	}
+}
+
+// TestDiffLinks_ReportsMissingLinks verifies DiffLinks reports the links LinkIssues would create without creating them
+func TestDiffLinks_ReportsMissingLinks(t *testing.T) {
+	posts := 0
+	mockTransport := &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			switch {
+			case req.URL.Path == "/rest/api/2/issueLinkType":
+				return response(200, nil, linkTypesResponse), nil
+			case req.URL.Path == "/rest/api/2/issue/PROJ-1":
+				return response(200, nil, `{"fields": {"issuelinks": [
+					{"type": {"name": "Blocks", "inward": "is blocked by", "outward": "blocks"}, "outwardIssue": {"key": "PROJ-2"}}
+				]}}`), nil
+			case req.Method == "POST":
+				posts++
+			}
+			return response(404, nil, `{}`), nil
+		},
+	}
+	adapter := &JiraAdapter{
+		baseURL: "https://test.atlassian.net",
+		client:  &http.Client{Transport: mockTransport},
+	}
+
+	missing, err := adapter.DiffLinks(context.Background(), "PROJ-1", []domain.Link{
+		{Type: "blocks", Key: "PROJ-2"},
+		{Type: "relates to", Key: "PROJ-3"},
+		{Type: "relates to", Key: "PROJ-3"},
+		{Type: "blocks", Title: "Checkout"},
+	})
+	if err != nil {
+		t.Fatalf("DiffLinks failed: %v", err)
+	}
+	want := []domain.Link{{Type: "relates to", Key: "PROJ-3"}, {Type: "blocks", Title: "Checkout"}}
+	if len(missing) != 2 || missing[0] != want[0] || missing[1] != want[1] {
+		t.Errorf("Expected %v, got %v", want, missing)
+	}
+	if posts != 0 {
+		t.Errorf("DiffLinks must not create links, got %d POST requests", posts)
+	}
+
+	// An issue not created yet has no links, but its link types are still checked
+	if _, err := adapter.DiffLinks(context.Background(), "", []domain.Link{{Type: "duplicates", Key: "PROJ-4"}}); err == nil {
+		t.Error("Expected an error for an unknown link type")
+	}
+}
+
+// TestParseJiraIssue_Links verifies pulled links are written from the issue's side
+func TestParseJiraIssue_Links(t *testing.T) {
+	adapter := &JiraAdapter{fieldMappings: getDefaultFieldMappings()}
+
+	ticket := adapter.parseJiraIssue(map[string]interface{}{
+		"key": "PROJ-1",
+		"fields": map[string]interface{}{
+			"summary": "Checkout",
+			"issuelinks": []interface{}{
+				map[string]interface{}{
+					"type":         map[string]interface{}{"name": "Blocks", "inward": "is blocked by", "outward": "blocks"},
+					"outwardIssue": map[string]interface{}{"key": "PROJ-2"},
+				},
+				map[string]interface{}{
+					"type":        map[string]interface{}{"name": "Duplicate", "inward": "is duplicated by", "outward": "duplicates"},
+					"inwardIssue": map[string]interface{}{"key": "PROJ-3"},
+				},
+			},
+		},
+	})
+
+	expected := []domain.Link{{Type: "blocks", Key: "PROJ-2"}, {Type: "is duplicated by", Key: "PROJ-3"}}
+	if len(ticket.Links) != len(expected) {
+		t.Fatalf("Expected %v, got %v", expected, ticket.Links)
+	}
+	for i := range expected {
+		if ticket.Links[i] != expected[i] {
+			t.Errorf("Link %d: expected %+v, got %+v", i, expected[i], ticket.Links[i])
+		}
+	}
+}
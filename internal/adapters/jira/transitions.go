@@ -0,0 +1,227 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// transition is a workflow transition available from an issue's status
+type transition struct {
+	ID   string
+	Name string
+	To   string // Status the transition leads to
+}
+
+// TransitionIssue moves an issue to the named status. When no transition
+// leads there directly, it follows the shortest chain of transitions, learning
+// the transitions of other statuses from issues of the same type that are in them.
+func (j *JiraAdapter) TransitionIssue(ctx context.Context, issueKey string, status string) error {
+	path, err := j.TransitionPath(ctx, issueKey, status)
+	if err != nil {
+		return err
+	}
+
+	for _, next := range path {
+		transitions, err := j.getTransitions(ctx, issueKey)
+		if err != nil {
+			return err
+		}
+		t, ok := findTransitionTo(transitions, next)
+		if !ok {
+			return fmt.Errorf("transition to '%s' is no longer available for %s", next, issueKey)
+		}
+
+		payload, err := json.Marshal(map[string]interface{}{
+			"transition": map[string]string{"id": t.ID},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to marshal payload: %w", err)
+		}
+
+		resp, err := j.do(ctx, "POST", j.restURL(fmt.Sprintf("issue/%s/transitions", issueKey)), payload, false)
+		if err != nil {
+			return err
+		}
+		if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("failed to transition %s to '%s' with status %d: %s", issueKey, next, resp.StatusCode, string(resp.Body))
+		}
+	}
+
+	return nil
+}
+
+// TransitionPath returns the statuses TransitionIssue would move an issue
+// through to reach status, without transitioning it
+func (j *JiraAdapter) TransitionPath(ctx context.Context, issueKey string, status string) ([]string, error) {
+	current, issueType, err := j.getIssueStatus(ctx, issueKey)
+	if err != nil {
+		return nil, err
+	}
+	if strings.EqualFold(current, status) {
+		return nil, nil
+	}
+	return j.findTransitionPath(ctx, issueKey, issueType, current, status)
+}
+
+// findTransitionPath returns the statuses to pass through, in order, to get
+// an issue from its current status to the target status
+func (j *JiraAdapter) findTransitionPath(ctx context.Context, issueKey, issueType, current, target string) ([]string, error) {
+	start, err := j.getTransitions(ctx, issueKey)
+	if err != nil {
+		return nil, err
+	}
+	if t, ok := findTransitionTo(start, target); ok {
+		return []string{t.To}, nil
+	}
+
+	// Breadth-first search over statuses; previous links each reached status to
+	// the one it was reached from
+	previous := map[string]string{strings.ToLower(current): ""}
+	names := map[string]string{strings.ToLower(current): current}
+	queue := []string{strings.ToLower(current)}
+
+	for len(queue) > 0 {
+		status := queue[0]
+		queue = queue[1:]
+
+		transitions := start
+		if status != strings.ToLower(current) {
+			if transitions, err = j.getStatusTransitions(ctx, issueType, names[status]); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, t := range transitions {
+			to := strings.ToLower(t.To)
+			if _, seen := previous[to]; seen {
+				continue
+			}
+			previous[to] = status
+			names[to] = t.To
+
+			if to == strings.ToLower(target) {
+				var path []string
+				for s := to; s != strings.ToLower(current); s = previous[s] {
+					path = append([]string{names[s]}, path...)
+				}
+				return path, nil
+			}
+			queue = append(queue, to)
+		}
+	}
+
+	var available []string
+	for _, t := range start {
+		available = append(available, fmt.Sprintf("'%s' (to %s)", t.Name, t.To))
+	}
+	return nil, fmt.Errorf("no workflow path from '%s' to '%s' for %s. Transitions available now: %s",
+		current, target, issueKey, strings.Join(available, ", "))
+}
+
+// getStatusTransitions returns the transitions out of a status for an issue
+// type, read from an issue of that type in that status. Results are cached per
+// adapter; a status no issue is in has no known transitions.
+func (j *JiraAdapter) getStatusTransitions(ctx context.Context, issueType, status string) ([]transition, error) {
+	cacheKey := strings.ToLower(issueType + "\x00" + status)
+
+	j.transitionsMu.Lock()
+	cached, ok := j.statusTransitions[cacheKey]
+	j.transitionsMu.Unlock()
+	if ok {
+		return cached, nil
+	}
+
+	jql := fmt.Sprintf(`project = %q AND issuetype = %q AND status = %q`, j.projectKey, issueType, status)
+	issues, _, err := j.searchIssues(ctx, jql, []string{"key"}, 1)
+	if err != nil {
+		return nil, err
+	}
+
+	var transitions []transition
+	if len(issues) > 0 {
+		if key := stringValue(issues[0]["key"]); key != "" {
+			if transitions, err = j.getTransitions(ctx, key); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	j.transitionsMu.Lock()
+	if j.statusTransitions == nil {
+		j.statusTransitions = make(map[string][]transition)
+	}
+	j.statusTransitions[cacheKey] = transitions
+	j.transitionsMu.Unlock()
+
+	return transitions, nil
+}
+
+// getIssueStatus fetches an issue's status and issue type names
+func (j *JiraAdapter) getIssueStatus(ctx context.Context, issueKey string) (string, string, error) {
+	resp, err := j.do(ctx, "GET", j.restURL(fmt.Sprintf("issue/%s?fields=status,issuetype", issueKey)), nil, true)
+	if err != nil {
+		return "", "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", "", fmt.Errorf("failed to get issue %s with status %d: %s", issueKey, resp.StatusCode, string(resp.Body))
+	}
+
+	var issue map[string]interface{}
+	if err := json.Unmarshal(resp.Body, &issue); err != nil {
+		return "", "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	fields, _ := issue["fields"].(map[string]interface{})
+	status, _ := fields["status"].(map[string]interface{})
+	issueType, _ := fields["issuetype"].(map[string]interface{})
+	return stringValue(status["name"]), stringValue(issueType["name"]), nil
+}
+
+// getTransitions fetches the transitions available from an issue's current status
+func (j *JiraAdapter) getTransitions(ctx context.Context, issueKey string) ([]transition, error) {
+	resp, err := j.do(ctx, "GET", j.restURL(fmt.Sprintf("issue/%s/transitions", issueKey)), nil, true)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get transitions for %s with status %d: %s", issueKey, resp.StatusCode, string(resp.Body))
+	}
+
+	var result struct {
+		Transitions []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+			To   struct {
+				Name string `json:"name"`
+			} `json:"to"`
+		} `json:"transitions"`
+	}
+	if err := json.Unmarshal(resp.Body, &result); err != nil {
+		return nil, fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	transitions := make([]transition, 0, len(result.Transitions))
+	for _, t := range result.Transitions {
+		transitions = append(transitions, transition{ID: t.ID, Name: t.Name, To: t.To.Name})
+	}
+	return transitions, nil
+}
+
+// findTransitionTo returns the transition leading to a status
+func findTransitionTo(transitions []transition, status string) (transition, bool) {
+	for _, t := range transitions {
+		if strings.EqualFold(t.To, status) {
+			return t, true
+		}
+	}
+	return transition{}, false
+}
+
+// parseStatus returns the name of a Jira status field value
+func parseStatus(value interface{}) string {
+	status, _ := value.(map[string]interface{})
+	return stringValue(status["name"])
+}
@@ -0,0 +1,214 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+// workflowServer simulates a To Do -> In Progress -> In Review -> Done workflow.
+// PROJ-1 moves through it; PROJ-5 and PROJ-6 sit in the middle statuses.
+type workflowServer struct {
+	status      string   // Current status of PROJ-1
+	searches    []string // JQL of the sample issue searches
+	retired     bool     // The classic /search endpoint is gone, as on Jira Cloud
+	transitions []string // IDs of the transitions performed on PROJ-1
+}
+
+var workflow = map[string][]map[string]interface{}{
+	"To Do":       {{"id": "11", "name": "Start", "to": map[string]string{"name": "In Progress"}}},
+	"In Progress": {{"id": "21", "name": "Review", "to": map[string]string{"name": "In Review"}}, {"id": "22", "name": "Stop", "to": map[string]string{"name": "To Do"}}},
+	"In Review":   {{"id": "31", "name": "Approve", "to": map[string]string{"name": "Done"}}},
+	"Done":        {},
+}
+
+func (w *workflowServer) adapter() *JiraAdapter {
+	samples := map[string]string{"In Progress": "PROJ-5", "In Review": "PROJ-6"}
+	sampleStatus := map[string]string{"PROJ-5": "In Progress", "PROJ-6": "In Review"}
+
+	mockTransport := &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			var body []byte
+			if req.Body != nil {
+				body, _ = io.ReadAll(req.Body)
+			}
+			switch {
+			case req.URL.Path == "/rest/api/2/issue/PROJ-1" && req.Method == "GET":
+				return response(200, nil, fmt.Sprintf(`{"fields": {"status": {"name": %q}, "issuetype": {"name": "Story"}}}`, w.status)), nil
+
+			case req.URL.Path == "/rest/api/2/search" || req.URL.Path == "/rest/api/2/search/jql":
+				if w.retired && req.URL.Path == "/rest/api/2/search" {
+					return response(410, nil, `{"errorMessages": ["The requested API has been removed"]}`), nil
+				}
+				var payload map[string]interface{}
+				json.Unmarshal(body, &payload)
+				jql, _ := payload["jql"].(string)
+				w.searches = append(w.searches, jql)
+				for status, key := range samples {
+					if strings.Contains(jql, fmt.Sprintf("status = %q", status)) {
+						return response(200, nil, fmt.Sprintf(`{"issues": [{"key": %q}], "total": 1}`, key)), nil
+					}
+				}
+				return response(200, nil, `{"issues": [], "total": 0}`), nil
+
+			case strings.HasSuffix(req.URL.Path, "/transitions") && req.Method == "GET":
+				key := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, "/rest/api/2/issue/"), "/transitions")
+				status := sampleStatus[key]
+				if key == "PROJ-1" {
+					status = w.status
+				}
+				encoded, _ := json.Marshal(map[string]interface{}{"transitions": workflow[status]})
+				return response(200, nil, string(encoded)), nil
+
+			case req.URL.Path == "/rest/api/2/issue/PROJ-1/transitions" && req.Method == "POST":
+				var payload struct {
+					Transition struct {
+						ID string `json:"id"`
+					} `json:"transition"`
+				}
+				json.Unmarshal(body, &payload)
+				for _, t := range workflow[w.status] {
+					if t["id"] == payload.Transition.ID {
+						w.transitions = append(w.transitions, payload.Transition.ID)
+						w.status = t["to"].(map[string]string)["name"]
+						return response(204, nil, ``), nil
+					}
+				}
+				return response(400, nil, `{"errorMessages": ["Transition id is not valid"]}`), nil
+			}
+			return response(404, nil, `{}`), nil
+		},
+	}
+
+	return &JiraAdapter{
+		baseURL:    "https://test.atlassian.net",
+		projectKey: "PROJ",
+		client:     &http.Client{Transport: mockTransport},
+	}
+}
+
+// TestTransitionIssue_FollowsMultiStepPath verifies a status several transitions away is reached through the shortest path
+func TestTransitionIssue_FollowsMultiStepPath(t *testing.T) {
+	server := &workflowServer{status: "To Do"}
+	adapter := server.adapter()
+
+	if err := adapter.TransitionIssue(context.Background(), "PROJ-1", "done"); err != nil {
+		t.Fatalf("TransitionIssue failed: %v", err)
+	}
+	if server.status != "Done" {
+		t.Errorf("Expected PROJ-1 to be Done, got %s", server.status)
+	}
+	if got := strings.Join(server.transitions, ","); got != "11,21,31" {
+		t.Errorf("Expected transitions 11,21,31, got %s", got)
+	}
+	if len(server.searches) == 0 || !strings.Contains(server.searches[0], `issuetype = "Story"`) {
+		t.Errorf("Expected sample searches for the same issue type, got %v", server.searches)
+	}
+
+	// Already in the status: nothing to do, and learned transitions are cached
+	searches := len(server.searches)
+	if err := adapter.TransitionIssue(context.Background(), "PROJ-1", "Done"); err != nil {
+		t.Fatalf("TransitionIssue failed: %v", err)
+	}
+	server.status = "To Do"
+	if err := adapter.TransitionIssue(context.Background(), "PROJ-1", "In Review"); err != nil {
+		t.Fatalf("TransitionIssue failed: %v", err)
+	}
+	if len(server.searches) != searches {
+		t.Errorf("Expected cached transitions to be reused, got searches %v", server.searches[searches:])
+	}
+}
+
+// TestTransitionPath_DoesNotTransition verifies the path is reported without moving the issue
+func TestTransitionPath_DoesNotTransition(t *testing.T) {
+	server := &workflowServer{status: "To Do"}
+	adapter := server.adapter()
+
+	path, err := adapter.TransitionPath(context.Background(), "PROJ-1", "Done")
+	if err != nil {
+		t.Fatalf("TransitionPath failed: %v", err)
+	}
+	if got := strings.Join(path, ","); got != "In Progress,In Review,Done" {
+		t.Errorf("Expected path In Progress,In Review,Done, got %s", got)
+	}
+	if server.status != "To Do" || len(server.transitions) != 0 {
+		t.Errorf("Expected PROJ-1 to stay in To Do, got %s after %v", server.status, server.transitions)
+	}
+
+	if path, err := adapter.TransitionPath(context.Background(), "PROJ-1", "to do"); err != nil || len(path) != 0 {
+		t.Errorf("Expected no path to the current status, got %v, %v", path, err)
+	}
+}
+
+// TestTransitionIssue_ConfiguredSearchEndpoint verifies the path search uses the configured search endpoint
+func TestTransitionIssue_ConfiguredSearchEndpoint(t *testing.T) {
+	server := &workflowServer{status: "To Do", retired: true}
+	adapter := server.adapter()
+	adapter.searchEndpoint = SearchEndpointJQL
+
+	if err := adapter.TransitionIssue(context.Background(), "PROJ-1", "Done"); err != nil {
+		t.Fatalf("TransitionIssue failed: %v", err)
+	}
+	if server.status != "Done" {
+		t.Errorf("Expected PROJ-1 to be Done, got %s", server.status)
+	}
+}
+
+// TestGetStatusTransitions_QuotesNames verifies quotes in issue type and status names are escaped in the JQL
+func TestGetStatusTransitions_QuotesNames(t *testing.T) {
+	server := &workflowServer{status: "To Do"}
+	adapter := server.adapter()
+
+	if _, err := adapter.getStatusTransitions(context.Background(), `Bug "P1"`, `Won't "Fix"`); err != nil {
+		t.Fatalf("getStatusTransitions failed: %v", err)
+	}
+	want := `project = "PROJ" AND issuetype = "Bug \"P1\"" AND status = "Won't \"Fix\""`
+	if len(server.searches) != 1 || server.searches[0] != want {
+		t.Errorf("Expected JQL %s, got %v", want, server.searches)
+	}
+}
+
+// TestTransitionIssue_NoPath verifies an unreachable status fails with the transitions available
+func TestTransitionIssue_NoPath(t *testing.T) {
+	server := &workflowServer{status: "To Do"}
+	adapter := server.adapter()
+
+	err := adapter.TransitionIssue(context.Background(), "PROJ-1", "Won't Do")
+	if err == nil {
+		t.Fatal("Expected an error for an unreachable status")
+	}
+	for _, want := range []string{"'To Do'", "'Won't Do'", "'Start' (to In Progress)"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("Expected the error to mention %s, got: %v", want, err)
+		}
+	}
+	if len(server.transitions) != 0 {
+		t.Errorf("Expected no transitions when there is no path, got %v", server.transitions)
+	}
+}
+
+// TestParseJiraIssue_Status verifies pulled tickets and subtasks carry their status, not a custom field
+func TestParseJiraIssue_Status(t *testing.T) {
+	adapter := &JiraAdapter{fieldMappings: map[string]interface{}{"Status": "status"}}
+
+	issue := map[string]interface{}{
+		"key":    "PROJ-1",
+		"fields": map[string]interface{}{"summary": "Title", "status": map[string]interface{}{"name": "In Progress"}},
+	}
+
+	ticket := adapter.parseJiraIssue(issue)
+	if ticket.Status != "In Progress" {
+		t.Errorf("Expected ticket status 'In Progress', got %q", ticket.Status)
+	}
+	if _, ok := ticket.CustomFields["Status"]; ok {
+		t.Errorf("Status should not be a custom field, got %v", ticket.CustomFields)
+	}
+
+	if task := adapter.parseJiraSubtask(issue); task.Status != "In Progress" {
+		t.Errorf("Expected task status 'In Progress', got %q", task.Status)
+	}
+}
@@ -0,0 +1,263 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// RetryPolicy controls how the adapter retries requests that fail transiently
+type RetryPolicy struct {
+	// MaxRetries is the number of retries after the first attempt (0 disables retries)
+	MaxRetries int
+	// InitialBackoff is the base delay before the first retry
+	InitialBackoff time.Duration
+	// MaxBackoff caps a single delay, including server-requested Retry-After waits
+	MaxBackoff time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used when none is configured
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxRetries:     4,
+		InitialBackoff: 500 * time.Millisecond,
+		MaxBackoff:     30 * time.Second,
+	}
+}
+
+// apiResponse is the final response of a request after any retries
+type apiResponse struct {
+	StatusCode int
+	Body       []byte
+}
+
+// do sends a request to Jira, retrying transient failures according to the
+// adapter's retry policy. Idempotent requests (GET, PUT and searches) are
+// retried on network errors and 429/5xx responses. Non-idempotent requests
+// (issue creation) are only retried when Jira signals the request was not
+// processed: a 429, or a 503 carrying Retry-After. Non-2xx responses that are
+// not retried are returned to the caller, which owns the error message.
+//
+// Each attempt is bounded by the adapter's request timeout; cancelling ctx
+// aborts the request in flight as well as any backoff wait.
+func (j *JiraAdapter) do(ctx context.Context, method, url string, payload []byte, idempotent bool) (*apiResponse, error) {
+	for attempt := 0; ; attempt++ {
+		if err := j.waitForRateLimit(ctx); err != nil {
+			return nil, err
+		}
+
+		status, header, respBody, err := j.attempt(ctx, method, url, payload)
+		if err != nil {
+			// A cancelled or expired caller context is final, not transient
+			if ctx.Err() != nil {
+				return nil, fmt.Errorf("failed to execute request: %w", ctx.Err())
+			}
+			if idempotent && attempt < j.retry.MaxRetries {
+				delay := j.backoff(attempt)
+				log.Printf("Jira request %s %s failed: %v; retrying in %s (attempt %d/%d)", method, url, err, delay, attempt+1, j.retry.MaxRetries)
+				if err := j.pause(ctx, delay); err != nil {
+					return nil, err
+				}
+				continue
+			}
+			return nil, err
+		}
+
+		j.recordRateLimit(header)
+
+		if attempt < j.retry.MaxRetries && isRetryable(status, header, idempotent) {
+			if delay, ok := j.retryDelay(attempt, header); ok {
+				log.Printf("Jira request %s %s returned status %d; retrying in %s (attempt %d/%d)", method, url, status, delay, attempt+1, j.retry.MaxRetries)
+				if err := j.pause(ctx, delay); err != nil {
+					return nil, err
+				}
+				continue
+			}
+		}
+
+		return &apiResponse{StatusCode: status, Body: respBody}, nil
+	}
+}
+
+// attempt sends a single request bounded by the adapter's request timeout and
+// reads the whole response
+func (j *JiraAdapter) attempt(ctx context.Context, method, url string, payload []byte) (int, http.Header, []byte, error) {
+	if j.requestTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, j.requestTimeout)
+		defer cancel()
+	}
+
+	var body io.Reader
+	if payload != nil {
+		body = bytes.NewReader(payload)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, body)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to create request: %w", err)
+	}
+
+	if j.auth != nil {
+		j.auth.Authorize(req)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := j.client.Do(req)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to execute request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+
+	return resp.StatusCode, resp.Header, respBody, nil
+}
+
+// isRetryable reports whether a response status may be retried for the request kind
+func isRetryable(status int, header http.Header, idempotent bool) bool {
+	switch status {
+	case http.StatusTooManyRequests:
+		return true
+	case http.StatusServiceUnavailable:
+		return idempotent || header.Get("Retry-After") != ""
+	case http.StatusInternalServerError, http.StatusBadGateway, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
+
+// retryDelay returns how long to wait before the next attempt. A Retry-After
+// header takes precedence over the exponential backoff; when it asks for a
+// longer wait than MaxBackoff the request is not retried.
+func (j *JiraAdapter) retryDelay(attempt int, header http.Header) (time.Duration, bool) {
+	if delay, ok := parseRetryAfter(header.Get("Retry-After"), time.Now()); ok {
+		if delay > j.retry.MaxBackoff {
+			return 0, false
+		}
+		return delay, true
+	}
+	return j.backoff(attempt), true
+}
+
+// backoff returns the exponential backoff for an attempt with equal jitter,
+// so that concurrent clients do not retry in lockstep
+func (j *JiraAdapter) backoff(attempt int) time.Duration {
+	delay := j.retry.InitialBackoff
+	for i := 0; i < attempt && delay < j.retry.MaxBackoff; i++ {
+		delay *= 2
+	}
+	if delay > j.retry.MaxBackoff {
+		delay = j.retry.MaxBackoff
+	}
+	if delay <= 1 {
+		return delay
+	}
+	half := delay / 2
+	return half + time.Duration(rand.Int63n(int64(delay-half)))
+}
+
+// parseRetryAfter parses a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string, now time.Time) (time.Duration, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		if seconds < 0 {
+			return 0, false
+		}
+		return time.Duration(seconds) * time.Second, true
+	}
+	if date, err := http.ParseTime(value); err == nil {
+		delay := date.Sub(now)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+	return 0, false
+}
+
+// recordRateLimit remembers when the rate limit window resets once Jira
+// reports that no requests remain in it, so the next request waits instead
+// of being rejected with a 429
+func (j *JiraAdapter) recordRateLimit(header http.Header) {
+	if header.Get("X-RateLimit-Remaining") != "0" {
+		return
+	}
+	reset, ok := parseRateLimitReset(header.Get("X-RateLimit-Reset"))
+	if !ok {
+		return
+	}
+
+	j.rateMu.Lock()
+	defer j.rateMu.Unlock()
+	if reset.After(j.throttleUntil) {
+		j.throttleUntil = reset
+	}
+}
+
+// waitForRateLimit blocks until a recorded rate limit window has reset, waiting
+// at most MaxBackoff. The reset time is left in place until it passes, so
+// every concurrent request waits for it, not just the first.
+func (j *JiraAdapter) waitForRateLimit(ctx context.Context) error {
+	j.rateMu.Lock()
+	until := j.throttleUntil
+	j.rateMu.Unlock()
+
+	wait := time.Until(until)
+	if wait > j.retry.MaxBackoff {
+		wait = j.retry.MaxBackoff
+	}
+	if wait <= 0 {
+		return nil
+	}
+	log.Printf("Jira rate limit exhausted; waiting %s for the window to reset", wait)
+	return j.pause(ctx, wait)
+}
+
+// parseRateLimitReset parses an X-RateLimit-Reset header given as an ISO 8601
+// timestamp or as Unix epoch seconds
+func parseRateLimitReset(value string) (time.Time, bool) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, false
+	}
+	if epoch, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(epoch, 0), true
+	}
+	for _, layout := range []string{time.RFC3339, "2006-01-02T15:04Z07:00"} {
+		if reset, err := time.Parse(layout, value); err == nil {
+			return reset, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// pause waits for the given duration using the adapter's sleep function, or
+// until ctx is done
+func (j *JiraAdapter) pause(ctx context.Context, d time.Duration) error {
+	if j.sleep != nil {
+		j.sleep(d)
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
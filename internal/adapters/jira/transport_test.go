@@ -0,0 +1,336 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+)
+
+// newRetryingAdapter builds an adapter with the default retry policy whose
+// waits are recorded instead of slept
+func newRetryingAdapter(transport http.RoundTripper, sleeps *[]time.Duration) *JiraAdapter {
+	return &JiraAdapter{
+		baseURL:       "https://test.atlassian.net",
+		auth:          BasicAuth{Username: "test@example.com", Password: "test-api-key"},
+		projectKey:    "PROJ",
+		storyType:     "Task",
+		subTaskType:   "Sub-task",
+		client:        &http.Client{Transport: transport},
+		fieldMappings: getDefaultFieldMappings(),
+		retry:         DefaultRetryPolicy(),
+		sleep: func(d time.Duration) {
+			*sleeps = append(*sleeps, d)
+		},
+	}
+}
+
+// response builds an HTTP response with the given status, headers and body
+func response(status int, header http.Header, body string) *http.Response {
+	if header == nil {
+		header = http.Header{}
+	}
+	return &http.Response{
+		StatusCode: status,
+		Header:     header,
+		Body:       io.NopCloser(bytes.NewBufferString(body)),
+	}
+}
+
+// TestUpdateTicket_RetriesServiceUnavailable verifies idempotent PUTs are retried with the full payload
+func TestUpdateTicket_RetriesServiceUnavailable(t *testing.T) {
+	var calls int
+	var sleeps []time.Duration
+	mockTransport := &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			body, _ := io.ReadAll(req.Body)
+			if !strings.Contains(string(body), "Retried") {
+				t.Errorf("Attempt %d sent an incomplete payload: %s", calls, body)
+			}
+			if calls < 3 {
+				return response(503, nil, "unavailable"), nil
+			}
+			return response(204, nil, ""), nil
+		},
+	}
+
+	adapter := newRetryingAdapter(mockTransport, &sleeps)
+	err := adapter.UpdateTicket(context.Background(), domain.Ticket{JiraID: "PROJ-1", Title: "Retried"})
+	if err != nil {
+		t.Fatalf("Expected update to succeed after retries, got: %v", err)
+	}
+	if calls != 3 {
+		t.Errorf("Expected 3 attempts, got %d", calls)
+	}
+	if len(sleeps) != 2 {
+		t.Errorf("Expected 2 backoff waits, got %v", sleeps)
+	}
+}
+
+// TestCreateTicket_DoesNotRetryServerError verifies POST creates are not retried when the outcome is unknown
+func TestCreateTicket_DoesNotRetryServerError(t *testing.T) {
+	var calls int
+	var sleeps []time.Duration
+	mockTransport := &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			return response(500, nil, "internal error"), nil
+		},
+	}
+
+	adapter := newRetryingAdapter(mockTransport, &sleeps)
+	_, err := adapter.CreateTicket(context.Background(), domain.Ticket{Title: "New"})
+	if err == nil || !strings.Contains(err.Error(), "500") {
+		t.Fatalf("Expected status 500 error, got: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected a single attempt for a non-idempotent create, got %d", calls)
+	}
+}
+
+// TestCreateTicket_RetriesRateLimitHonoringRetryAfter verifies a 429 on create waits for Retry-After and retries
+func TestCreateTicket_RetriesRateLimitHonoringRetryAfter(t *testing.T) {
+	var calls int
+	var sleeps []time.Duration
+	mockTransport := &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return response(429, http.Header{"Retry-After": []string{"7"}}, "rate limited"), nil
+			}
+			return response(201, nil, `{"key": "PROJ-9"}`), nil
+		},
+	}
+
+	adapter := newRetryingAdapter(mockTransport, &sleeps)
+	key, err := adapter.CreateTicket(context.Background(), domain.Ticket{Title: "New"})
+	if err != nil {
+		t.Fatalf("Expected create to succeed after rate limit, got: %v", err)
+	}
+	if key != "PROJ-9" {
+		t.Errorf("Expected PROJ-9, got %s", key)
+	}
+	if len(sleeps) != 1 || sleeps[0] != 7*time.Second {
+		t.Errorf("Expected a single 7s wait from Retry-After, got %v", sleeps)
+	}
+}
+
+// TestDo_RetryAfterBeyondMaxBackoffGivesUp verifies the request fails instead of waiting longer than MaxBackoff
+func TestDo_RetryAfterBeyondMaxBackoffGivesUp(t *testing.T) {
+	var calls int
+	var sleeps []time.Duration
+	mockTransport := &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			return response(429, http.Header{"Retry-After": []string{"3600"}}, "rate limited"), nil
+		},
+	}
+
+	adapter := newRetryingAdapter(mockTransport, &sleeps)
+	err := adapter.Authenticate(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "429") {
+		t.Fatalf("Expected status 429 error, got: %v", err)
+	}
+	if calls != 1 || len(sleeps) != 0 {
+		t.Errorf("Expected no retries, got %d calls and waits %v", calls, sleeps)
+	}
+}
+
+// TestDo_StopsAfterMaxRetries verifies persistent failures are returned once retries are exhausted
+func TestDo_StopsAfterMaxRetries(t *testing.T) {
+	var calls int
+	var sleeps []time.Duration
+	mockTransport := &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			return response(502, nil, "bad gateway"), nil
+		},
+	}
+
+	adapter := newRetryingAdapter(mockTransport, &sleeps)
+	_, err := adapter.GetProjectIssueTypes(context.Background())
+	if err == nil || !strings.Contains(err.Error(), "502") {
+		t.Fatalf("Expected status 502 error, got: %v", err)
+	}
+
+	policy := DefaultRetryPolicy()
+	if calls != policy.MaxRetries+1 {
+		t.Errorf("Expected %d attempts, got %d", policy.MaxRetries+1, calls)
+	}
+	for i, d := range sleeps {
+		if d <= 0 || d > policy.MaxBackoff {
+			t.Errorf("Wait %d out of bounds: %s", i, d)
+		}
+	}
+}
+
+// TestDo_RetriesNetworkErrorsForIdempotentRequests verifies GETs survive a dropped connection
+func TestDo_RetriesNetworkErrorsForIdempotentRequests(t *testing.T) {
+	var calls int
+	var sleeps []time.Duration
+	mockTransport := &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				return nil, errors.New("connection reset by peer")
+			}
+			return response(200, nil, `{"accountId": "abc"}`), nil
+		},
+	}
+
+	adapter := newRetryingAdapter(mockTransport, &sleeps)
+	if err := adapter.Authenticate(context.Background()); err != nil {
+		t.Fatalf("Expected authentication to succeed after retry, got: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 attempts, got %d", calls)
+	}
+}
+
+// TestDo_WaitsForRateLimitReset verifies an exhausted X-RateLimit window delays the next request
+func TestDo_WaitsForRateLimitReset(t *testing.T) {
+	reset := time.Now().Add(10 * time.Second).UTC().Format(time.RFC3339)
+	var sleeps []time.Duration
+	mockTransport := &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			header := http.Header{
+				"X-Ratelimit-Remaining": []string{"0"},
+				"X-Ratelimit-Reset":     []string{reset},
+			}
+			return response(200, header, `{}`), nil
+		},
+	}
+
+	adapter := newRetryingAdapter(mockTransport, &sleeps)
+	if err := adapter.Authenticate(context.Background()); err != nil {
+		t.Fatalf("First request failed: %v", err)
+	}
+	if len(sleeps) != 0 {
+		t.Fatalf("First request should not wait, got %v", sleeps)
+	}
+
+	if err := adapter.Authenticate(context.Background()); err != nil {
+		t.Fatalf("Second request failed: %v", err)
+	}
+	if len(sleeps) != 1 || sleeps[0] < 8*time.Second || sleeps[0] > 10*time.Second {
+		t.Errorf("Expected a wait of roughly 10s before the second request, got %v", sleeps)
+	}
+}
+
+// TestDo_ConcurrentRequestsAllWaitForRateLimitReset verifies every request
+// started before the window resets waits for it, not only the first
+func TestDo_ConcurrentRequestsAllWaitForRateLimitReset(t *testing.T) {
+	mockTransport := &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			return response(200, nil, `{}`), nil
+		},
+	}
+
+	var mu sync.Mutex
+	var sleeps []time.Duration
+	adapter := newRetryingAdapter(mockTransport, new([]time.Duration))
+	adapter.sleep = func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		sleeps = append(sleeps, d)
+	}
+	adapter.recordRateLimit(http.Header{
+		"X-Ratelimit-Remaining": []string{"0"},
+		"X-Ratelimit-Reset":     []string{time.Now().Add(10 * time.Second).UTC().Format(time.RFC3339)},
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := adapter.Authenticate(context.Background()); err != nil {
+				t.Errorf("Request failed: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if len(sleeps) != 2 {
+		t.Fatalf("Expected both requests to wait, got %v", sleeps)
+	}
+	for _, wait := range sleeps {
+		if wait < 8*time.Second || wait > 10*time.Second {
+			t.Errorf("Expected waits of roughly 10s, got %v", sleeps)
+		}
+	}
+}
+
+// TestParseRetryAfter verifies both Retry-After formats are understood
+func TestParseRetryAfter(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	if d, ok := parseRetryAfter("5", now); !ok || d != 5*time.Second {
+		t.Errorf("Expected 5s from seconds form, got %s (ok=%v)", d, ok)
+	}
+	if d, ok := parseRetryAfter("Mon, 01 Jan 2024 12:00:30 GMT", now); !ok || d != 30*time.Second {
+		t.Errorf("Expected 30s from HTTP date form, got %s (ok=%v)", d, ok)
+	}
+	if _, ok := parseRetryAfter("soon", now); ok {
+		t.Error("Expected invalid Retry-After to be ignored")
+	}
+}
+
+// TestDo_CancelledContextStopsRetrying verifies cancellation during a backoff wait ends the request
+func TestDo_CancelledContextStopsRetrying(t *testing.T) {
+	var calls int
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	mockTransport := &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			return response(503, nil, "unavailable"), nil
+		},
+	}
+
+	adapter := newRetryingAdapter(mockTransport, new([]time.Duration))
+	adapter.sleep = func(time.Duration) { cancel() }
+
+	err := adapter.UpdateTicket(ctx, domain.Ticket{JiraID: "PROJ-1", Title: "Cancelled"})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got: %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected no attempts after cancellation, got %d", calls)
+	}
+}
+
+// TestDo_RetriesAttemptThatExceedsRequestTimeout verifies a hung attempt is abandoned and retried
+func TestDo_RetriesAttemptThatExceedsRequestTimeout(t *testing.T) {
+	var calls int
+	mockTransport := &MockRoundTripper{
+		RoundTripFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			if calls == 1 {
+				// Simulate a hung connection
+				<-req.Context().Done()
+				return nil, req.Context().Err()
+			}
+			return response(200, nil, `{}`), nil
+		},
+	}
+
+	adapter := newRetryingAdapter(mockTransport, new([]time.Duration))
+	adapter.requestTimeout = 10 * time.Millisecond
+
+	if err := adapter.Authenticate(context.Background()); err != nil {
+		t.Fatalf("Expected the retry to succeed, got: %v", err)
+	}
+	if calls != 2 {
+		t.Errorf("Expected 2 attempts, got %d", calls)
+	}
+}
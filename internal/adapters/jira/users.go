@@ -0,0 +1,103 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// accountIDRegex matches Jira Cloud account IDs: 24 hex digits, or a numeric
+// prefix and a UUID
+var accountIDRegex = regexp.MustCompile(`^([0-9a-f]{24}|\d+:[0-9a-f]{8}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{4}-[0-9a-f]{12})$`)
+
+// jiraUser is a user returned by the user search
+type jiraUser struct {
+	AccountID    string `json:"accountId"`
+	DisplayName  string `json:"displayName"`
+	EmailAddress string `json:"emailAddress"`
+}
+
+// resolveUsers returns customFields with the values of user fields replaced by
+// the account IDs Jira Cloud expects, so a ticket can name users as they are
+// pulled: by display name, or by email address. Account IDs are kept as they
+// are. On Server users are sent by name, and customFields is returned as is.
+func (j *JiraAdapter) resolveUsers(ctx context.Context, customFields map[string]string) (map[string]string, error) {
+	if j.isServer() {
+		return customFields, nil
+	}
+
+	var resolved map[string]string
+	for name, value := range customFields {
+		mapping, exists := j.fieldMappings[name]
+		if !exists || !isUserField(mapping) || value == "" || accountIDRegex.MatchString(value) {
+			continue
+		}
+		accountID, err := j.accountID(ctx, value)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if resolved == nil {
+			resolved = make(map[string]string, len(customFields))
+			for k, v := range customFields {
+				resolved[k] = v
+			}
+		}
+		resolved[name] = accountID
+	}
+
+	if resolved == nil {
+		return customFields, nil
+	}
+	return resolved, nil
+}
+
+// accountID finds the account ID of the one user whose display name or email
+// address is user, remembering it for later tickets
+func (j *JiraAdapter) accountID(ctx context.Context, user string) (string, error) {
+	j.usersMu.Lock()
+	defer j.usersMu.Unlock()
+	if accountID, ok := j.accountIDs[user]; ok {
+		return accountID, nil
+	}
+
+	resp, err := j.do(ctx, "GET", j.restURL("user/search?query="+url.QueryEscape(user)), nil, true)
+	if err != nil {
+		return "", err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to search for user '%s' with status %d: %s", user, resp.StatusCode, string(resp.Body))
+	}
+
+	var users []jiraUser
+	if err := json.Unmarshal(resp.Body, &users); err != nil {
+		return "", fmt.Errorf("failed to parse response: %w", err)
+	}
+
+	var matches []jiraUser
+	for _, candidate := range users {
+		if strings.EqualFold(candidate.DisplayName, user) || strings.EqualFold(candidate.EmailAddress, user) {
+			matches = append(matches, candidate)
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return "", fmt.Errorf("no Jira user is named '%s'; use their display name, email address or account ID", user)
+	case 1:
+	default:
+		ids := make([]string, len(matches))
+		for i, match := range matches {
+			ids[i] = match.AccountID
+		}
+		return "", fmt.Errorf("%d Jira users are named '%s'; use the account ID of one of them (%s)", len(matches), user, strings.Join(ids, ", "))
+	}
+
+	if j.accountIDs == nil {
+		j.accountIDs = make(map[string]string)
+	}
+	j.accountIDs[user] = matches[0].AccountID
+	return matches[0].AccountID, nil
+}
@@ -0,0 +1,93 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+)
+
+// usersTransport serves a user search over users and records created issues
+type usersTransport struct {
+	users    string
+	searches []string
+	created  []map[string]interface{}
+}
+
+func (u *usersTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if strings.Contains(req.URL.Path, "/user/search") {
+		u.searches = append(u.searches, req.URL.Query().Get("query"))
+		return &http.Response{StatusCode: 200, Body: io.NopCloser(bytes.NewBufferString(u.users))}, nil
+	}
+	var payload map[string]interface{}
+	json.NewDecoder(req.Body).Decode(&payload)
+	u.created = append(u.created, payload["fields"].(map[string]interface{}))
+	return &http.Response{StatusCode: 201, Body: io.NopCloser(bytes.NewBufferString(`{"key": "PROJ-1"}`))}, nil
+}
+
+// TestCreateTicket_ResolvesUserNamesToAccountIDs verifies Cloud user fields
+// named by display name or email are sent as the matching accountId
+func TestCreateTicket_ResolvesUserNamesToAccountIDs(t *testing.T) {
+	transport := &usersTransport{users: `[
+		{"accountId": "5b10ac8d82e05b22cc7d4ef5", "displayName": "Jane Doe", "emailAddress": "jane@example.com"},
+		{"accountId": "5b10ac8d82e05b22cc7d4ef6", "displayName": "Jane Doe-Smith"}
+	]`}
+	adapter := &JiraAdapter{
+		baseURL:       "https://test.atlassian.net",
+		projectKey:    "PROJ",
+		storyType:     "Task",
+		client:        &http.Client{Transport: transport},
+		fieldMappings: getDefaultFieldMappings(),
+	}
+
+	for _, assignee := range []string{"Jane Doe", "jane doe", "5b10ac8d82e05b22cc7d4ef6"} {
+		ticket := domain.Ticket{Title: "Login", CustomFields: map[string]string{"Assignee": assignee}}
+		if _, err := adapter.CreateTicket(context.Background(), ticket); err != nil {
+			t.Fatalf("CreateTicket(%s) failed: %v", assignee, err)
+		}
+		if ticket.CustomFields["Assignee"] != assignee {
+			t.Errorf("CreateTicket changed the caller's fields to %v", ticket.CustomFields)
+		}
+	}
+
+	want := []string{"5b10ac8d82e05b22cc7d4ef5", "5b10ac8d82e05b22cc7d4ef5", "5b10ac8d82e05b22cc7d4ef6"}
+	for i, fields := range transport.created {
+		if assignee, _ := fields["assignee"].(map[string]interface{}); assignee["accountId"] != want[i] {
+			t.Errorf("Ticket %d assignee = %#v, want accountId %s", i, fields["assignee"], want[i])
+		}
+	}
+	// Account IDs are sent as they are; each name is looked up once
+	if len(transport.searches) != 2 {
+		t.Errorf("Expected a search for each distinct name, got %q", transport.searches)
+	}
+}
+
+// TestCreateTicket_RejectsUnknownOrAmbiguousUsers verifies a name matching no
+// user or several users fails before anything is created
+func TestCreateTicket_RejectsUnknownOrAmbiguousUsers(t *testing.T) {
+	transport := &usersTransport{users: `[
+		{"accountId": "5b10ac8d82e05b22cc7d4ef5", "displayName": "Jane Doe"},
+		{"accountId": "5b10ac8d82e05b22cc7d4ef6", "displayName": "Jane Doe"}
+	]`}
+	adapter := &JiraAdapter{
+		baseURL:       "https://test.atlassian.net",
+		projectKey:    "PROJ",
+		client:        &http.Client{Transport: transport},
+		fieldMappings: getDefaultFieldMappings(),
+	}
+
+	for assignee, want := range map[string]string{"Jane Doe": "2 Jira users", "John Roe": "no Jira user"} {
+		ticket := domain.Ticket{Title: "Login", CustomFields: map[string]string{"Assignee": assignee}}
+		if _, err := adapter.CreateTicket(context.Background(), ticket); err == nil || !strings.Contains(err.Error(), want) {
+			t.Errorf("CreateTicket(%s) error = %v, want %q", assignee, err, want)
+		}
+	}
+	if len(transport.created) != 0 {
+		t.Errorf("Expected nothing created, got %v", transport.created)
+	}
+}
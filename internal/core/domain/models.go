@@ -4,17 +4,54 @@ type Ticket struct {
 	Title              string
 	Description        string
 	CustomFields       map[string]string
+	FieldOrder         []string // Field names in the order the Markdown file lists them, Status included
 	AcceptanceCriteria []string
 	JiraID             string
+	Status             string // Workflow status, e.g. "In Progress"; changed in Jira through transitions
+	Links              []Link
+	Comments           []Comment
 	Tasks              []Task
 	SourceLine         int
+	FieldLines         map[string]int // Source line of each field in ## Fields, by name
 }
 
 type Task struct {
 	Title              string
 	Description        string
 	CustomFields       map[string]string // Task-specific overrides
+	FieldOrder         []string          // Field names in the order the Markdown file lists them, Status included
 	AcceptanceCriteria []string
 	JiraID             string
+	Status             string // Workflow status, e.g. "In Progress"; changed in Jira through transitions
 	SourceLine         int
+	FieldLines         map[string]int // Source line of each field in ## Fields, by name
+}
+
+// StatusField is the field in a ticket or task's ## Fields section that holds
+// its workflow status
+const StatusField = "Status"
+
+// Link is a relationship from a ticket to another issue, written from the
+// ticket's side, e.g. "blocks PROJ-12". The other issue is either a Jira key
+// or, before it has been pushed, the title of a ticket in the same file.
+type Link struct {
+	Type  string // Relationship as Jira words it, e.g. "blocks", "is blocked by", "relates to"
+	Key   string // Jira key of the other issue
+	Title string // Title of a local ticket without a key yet
+}
+
+// Comment is a Jira comment on a ticket. Comments pulled from Jira have an ID;
+// one without an ID was written locally and is posted on the next push.
+type Comment struct {
+	ID      string
+	Author  string
+	Created string // Timestamp as Jira reports it
+	Body    string // Markdown
+}
+
+// FieldChange describes a single field that a push would change in Jira
+type FieldChange struct {
+	Field    string `json:"field"`
+	Current  string `json:"current"`
+	Proposed string `json:"proposed"`
 }
@@ -1,30 +1,87 @@
 package ports
 
-import "github.com/karolswdev/ticktr/internal/core/domain"
+import (
+	"context"
+	"fmt"
 
-// JiraPort defines the interface for Jira integration operations
+	"github.com/karolswdev/ticktr/internal/core/domain"
+)
+
+// PartialResultError is returned together with usable results when a search
+// succeeded but some follow-up lookups (such as subtask batches) failed
+type PartialResultError struct {
+	Keys   []string // Issue keys whose results are incomplete
+	Errors []error
+}
+
+func (e *PartialResultError) Error() string {
+	if len(e.Errors) == 0 {
+		return "partial result"
+	}
+	return fmt.Sprintf("partial result (%d error(s)): %v", len(e.Errors), e.Errors[0])
+}
+
+// Unwrap exposes the individual errors to errors.Is and errors.As
+func (e *PartialResultError) Unwrap() []error {
+	return e.Errors
+}
+
+// JiraPort defines the interface for Jira integration operations. Every method
+// stops waiting on Jira when ctx is cancelled or its deadline passes.
 type JiraPort interface {
 	// Authenticate verifies the connection to Jira with the provided credentials
-	Authenticate() error
+	Authenticate(ctx context.Context) error
 
 	// CreateTask creates a new sub-task in Jira under the specified parent
-	CreateTask(task domain.Task, parentID string) (string, error)
+	CreateTask(ctx context.Context, task domain.Task, parentID string) (string, error)
 
 	// UpdateTask updates an existing task in Jira
-	UpdateTask(task domain.Task) error
+	UpdateTask(ctx context.Context, task domain.Task) error
 
 	// GetProjectIssueTypes fetches available issue types for the configured project
-	GetProjectIssueTypes() (map[string][]string, error)
+	GetProjectIssueTypes(ctx context.Context) (map[string][]string, error)
 
 	// GetIssueTypeFields fetches field requirements for a specific issue type
-	GetIssueTypeFields(issueTypeName string) (map[string]interface{}, error)
+	GetIssueTypeFields(ctx context.Context, issueTypeName string) (map[string]interface{}, error)
 
 	// CreateTicket creates a new ticket in Jira with dynamic field mapping
-	CreateTicket(ticket domain.Ticket) (string, error)
+	CreateTicket(ctx context.Context, ticket domain.Ticket) (string, error)
 
 	// UpdateTicket updates an existing ticket in Jira with dynamic field mapping
-	UpdateTicket(ticket domain.Ticket) error
+	UpdateTicket(ctx context.Context, ticket domain.Ticket) error
+
+	// SearchTickets searches for tickets in Jira using JQL query, within the project
+	// unless projectKey is empty. When subtasks could not be fetched for some
+	// tickets, or a configured cap on results left matches unfetched, the
+	// tickets are returned with a *PartialResultError.
+	SearchTickets(ctx context.Context, projectKey string, jql string) ([]domain.Ticket, error)
+
+	// LinkIssues creates the links from issueKey that Jira does not have yet.
+	// Every link must have a Key; existing links are left alone.
+	LinkIssues(ctx context.Context, issueKey string, links []domain.Link) error
+
+	// TransitionIssue moves an issue to the named workflow status, following
+	// several transitions when no single one leads there. It does nothing when
+	// the issue is already in that status.
+	TransitionIssue(ctx context.Context, issueKey string, status string) error
+
+	// AddComment posts a comment on an issue and returns it with the ID, author
+	// and timestamp Jira gave it
+	AddComment(ctx context.Context, issueKey string, comment domain.Comment) (domain.Comment, error)
+
+	// DiffTicket reports the fields CreateTicket or UpdateTicket would change, without writing to Jira
+	DiffTicket(ctx context.Context, ticket domain.Ticket) ([]domain.FieldChange, error)
+
+	// DiffTask reports the fields CreateTask or UpdateTask would change, without writing to Jira
+	DiffTask(ctx context.Context, task domain.Task, parentID string) ([]domain.FieldChange, error)
+
+	// DiffLinks reports the links LinkIssues would create, without writing to
+	// Jira. issueKey is empty for an issue not created yet, and links to local
+	// tickets not created yet have a Title instead of a Key.
+	DiffLinks(ctx context.Context, issueKey string, links []domain.Link) ([]domain.Link, error)
 
-	// SearchTickets searches for tickets in Jira using JQL query
-	SearchTickets(projectKey string, jql string) ([]domain.Ticket, error)
+	// TransitionPath reports the statuses TransitionIssue would move an issue
+	// through, in order, without transitioning it; none when the issue is
+	// already in that status
+	TransitionPath(ctx context.Context, issueKey string, status string) ([]string, error)
 }
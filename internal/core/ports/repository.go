@@ -1,7 +1,9 @@
 package ports
 
 import (
+	"context"
 	"errors"
+
 	"github.com/karolswdev/ticktr/internal/core/domain"
 )
 
@@ -13,7 +15,17 @@ var (
 // Repository defines the interface for ticket persistence operations
 type Repository interface {
 	// GetTickets reads and parses tickets from a file
-	GetTickets(filepath string) ([]domain.Ticket, error)
+	GetTickets(ctx context.Context, filepath string) ([]domain.Ticket, error)
 	// SaveTickets writes tickets to a file in the custom Markdown format
-	SaveTickets(filepath string, tickets []domain.Ticket) error
+	SaveTickets(ctx context.Context, filepath string, tickets []domain.Ticket) error
+}
+
+// ConflictRepository is a Repository that can write conflict markers into a
+// file, for pull to leave conflicts for the user to resolve by hand
+type ConflictRepository interface {
+	Repository
+	// SaveTicketsWithConflicts saves tickets like SaveTickets and, for each
+	// ticket whose Jira ID is in theirs, wraps the lines where the ticket and
+	// theirs differ in conflict markers holding both versions
+	SaveTicketsWithConflicts(ctx context.Context, filepath string, tickets []domain.Ticket, theirs map[string]domain.Ticket) error
 }
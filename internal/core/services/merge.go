@@ -0,0 +1,342 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+)
+
+// FieldConflict is a field that both the Markdown file and Jira changed, to
+// different values, since they were last in sync
+type FieldConflict struct {
+	JiraID string // Ticket the field belongs to
+	TaskID string // Jira key of the task the field belongs to, or "" for the ticket
+	Field  string // Title, Description, Status, Acceptance Criteria, Task, or a custom field name
+	Base   string
+	Local  string
+	Remote string
+}
+
+func (c FieldConflict) String() string {
+	field := c.Field
+	if c.TaskID != "" {
+		field = fmt.Sprintf("task %s %s", c.TaskID, c.Field)
+	}
+	return fmt.Sprintf("%s: %s (local %q, Jira %q)", c.JiraID, field, c.Local, c.Remote)
+}
+
+// Fields that are merged as a whole rather than by value
+const (
+	conflictTitle              = "Title"
+	conflictDescription        = "Description"
+	conflictStatus             = "Status"
+	conflictAcceptanceCriteria = "Acceptance Criteria"
+	conflictTask               = "Task"
+)
+
+// ConflictResolver picks the value of each field that a pull finds changed on
+// both sides, for interactive pulls
+type ConflictResolver interface {
+	// ResolveConflict returns the value to keep: the conflict's Local or
+	// Remote, or an edited one. Lists such as acceptance criteria come and go
+	// one item per line, and "" for a Task conflict drops the task.
+	ResolveConflict(conflict FieldConflict) (string, error)
+}
+
+// merger merges one ticket, collecting the fields that conflict
+type merger struct {
+	jiraID    string
+	resolver  ConflictResolver
+	conflicts []FieldConflict
+	err       error // First error from the resolver
+}
+
+// keepSide resolves every conflict with the local value, or Jira's with remote
+type keepSide struct {
+	remote bool
+}
+
+func (k keepSide) ResolveConflict(conflict FieldConflict) (string, error) {
+	if k.remote {
+		return conflict.Remote, nil
+	}
+	return conflict.Local, nil
+}
+
+// mergeTicket merges the changes made locally and in Jira since base, field
+// by field: the title, description, status, each custom field, each
+// acceptance criterion and link, and each task. A field changed on one side
+// takes that side's value; a field changed on both sides to different values
+// is a conflict and keeps the local value, or Jira's with preferRemote.
+// Comments are Jira's plus the local ones not posted yet.
+func mergeTicket(base, local, remote domain.Ticket, preferRemote bool) (domain.Ticket, []FieldConflict) {
+	merged, conflicts, _ := resolveTicket(base, local, remote, keepSide{remote: preferRemote})
+	return merged, conflicts
+}
+
+// resolveTicket merges like mergeTicket, asking resolver for the value of each
+// conflicting field. It stops asking after the first error, which it returns.
+func resolveTicket(base, local, remote domain.Ticket, resolver ConflictResolver) (domain.Ticket, []FieldConflict, error) {
+	m := &merger{jiraID: remote.JiraID, resolver: resolver}
+
+	merged := local
+	merged.Title = m.value("", conflictTitle, base.Title, local.Title, remote.Title)
+	merged.Description = m.value("", conflictDescription, base.Description, local.Description, remote.Description)
+	merged.Status = m.value("", conflictStatus, base.Status, local.Status, remote.Status)
+	merged.CustomFields = m.fields("", base.CustomFields, local.CustomFields, remote.CustomFields)
+	merged.AcceptanceCriteria = m.lines("", conflictAcceptanceCriteria, base.AcceptanceCriteria, local.AcceptanceCriteria, remote.AcceptanceCriteria)
+	merged.Links = mergeLinks(base.Links, local.Links, remote.Links)
+	merged.Comments = mergeComments(local.Comments, remote.Comments)
+	merged.Tasks = m.tasks(base.Tasks, local.Tasks, remote.Tasks)
+
+	return merged, m.conflicts, m.err
+}
+
+// value merges one value. taskID is "" for the ticket's own fields.
+func (m *merger) value(taskID, field, base, local, remote string) string {
+	switch {
+	case local == remote || remote == base:
+		return local
+	case local == base:
+		return remote
+	}
+	return m.conflict(taskID, field, base, local, remote)
+}
+
+// conflict records a conflict and returns the value the resolver picks for
+// it, or the local value once the resolver has failed
+func (m *merger) conflict(taskID, field, base, local, remote string) string {
+	conflict := FieldConflict{JiraID: m.jiraID, TaskID: taskID, Field: field, Base: base, Local: local, Remote: remote}
+	m.conflicts = append(m.conflicts, conflict)
+	if m.err != nil {
+		return local
+	}
+	value, err := m.resolver.ResolveConflict(conflict)
+	if err != nil {
+		m.err = err
+		return local
+	}
+	return value
+}
+
+// fields merges custom fields one by one; a field that is missing counts as
+// empty, and a field merged to empty is dropped
+func (m *merger) fields(taskID string, base, local, remote map[string]string) map[string]string {
+	names := make(map[string]bool)
+	for _, fields := range []map[string]string{base, local, remote} {
+		for name := range fields {
+			names[name] = true
+		}
+	}
+	sorted := make([]string, 0, len(names))
+	for name := range names {
+		sorted = append(sorted, name)
+	}
+	sort.Strings(sorted)
+
+	merged := make(map[string]string)
+	for _, name := range sorted {
+		if value := m.value(taskID, name, base[name], local[name], remote[name]); value != "" {
+			merged[name] = value
+		}
+	}
+	return merged
+}
+
+// lines merges lists such as acceptance criteria line by line: lines one side
+// removed are removed, lines one side added are added after the local ones.
+// A line both sides replaced with different lines is a conflict, and the
+// whole list of the winning side is kept.
+func (m *merger) lines(taskID, field string, base, local, remote []string) []string {
+	if equalLines(local, remote) || equalLines(remote, base) {
+		return local
+	}
+	if equalLines(local, base) {
+		return remote
+	}
+
+	removedLocally := difference(base, local)
+	removedRemotely := difference(base, remote)
+	addedLocally := difference(local, base)
+	addedRemotely := difference(difference(remote, base), local)
+	drop := setOf(removedRemotely)
+	for _, line := range removedLocally {
+		if drop[line] && len(addedLocally) > 0 && len(addedRemotely) > 0 {
+			// Both sides rewrote the same line
+			resolved := m.conflict(taskID, field, strings.Join(base, "\n"), strings.Join(local, "\n"), strings.Join(remote, "\n"))
+			if resolved == "" {
+				return nil
+			}
+			return strings.Split(resolved, "\n")
+		}
+	}
+
+	var merged []string
+	for _, line := range local {
+		if !drop[line] {
+			merged = append(merged, line)
+		}
+	}
+	return append(merged, addedRemotely...)
+}
+
+// tasks merges tasks matched by Jira key, keeping the local order and adding
+// tasks new in Jira after them. A task deleted on one side and changed on the
+// other is kept and reported as a conflict; a task deleted on one side and
+// unchanged on the other is dropped.
+func (m *merger) tasks(base, local, remote []domain.Task) []domain.Task {
+	baseByID := tasksByID(base)
+	remoteByID := tasksByID(remote)
+	localByID := tasksByID(local)
+
+	var merged []domain.Task
+	for _, task := range local {
+		remoteTask, inRemote := remoteByID[task.JiraID]
+		baseTask, inBase := baseByID[task.JiraID]
+		switch {
+		case task.JiraID == "":
+			// Not pushed yet
+			merged = append(merged, task)
+		case inRemote:
+			if !inBase {
+				// Synced without a base; differences are conflicts
+				baseTask = domain.Task{JiraID: task.JiraID}
+			}
+			merged = append(merged, m.task(baseTask, task, remoteTask))
+		case !inBase:
+			merged = append(merged, task)
+		case !equalTasks(task, baseTask):
+			// Deleted in Jira, changed locally
+			if title := m.conflict(task.JiraID, conflictTask, baseTask.Title, task.Title, ""); title != "" {
+				task.Title = title
+				merged = append(merged, task)
+			}
+		}
+	}
+
+	for _, task := range remote {
+		if _, inLocal := localByID[task.JiraID]; inLocal {
+			continue
+		}
+		baseTask, inBase := baseByID[task.JiraID]
+		if inBase && equalTasks(task, baseTask) {
+			// Deleted locally, unchanged in Jira
+			continue
+		}
+		merged = append(merged, task)
+	}
+	return merged
+}
+
+// task merges one task field by field
+func (m *merger) task(base, local, remote domain.Task) domain.Task {
+	merged := local
+	merged.Title = m.value(local.JiraID, conflictTitle, base.Title, local.Title, remote.Title)
+	merged.Description = m.value(local.JiraID, conflictDescription, base.Description, local.Description, remote.Description)
+	merged.Status = m.value(local.JiraID, conflictStatus, base.Status, local.Status, remote.Status)
+	merged.CustomFields = m.fields(local.JiraID, base.CustomFields, local.CustomFields, remote.CustomFields)
+	merged.AcceptanceCriteria = m.lines(local.JiraID, conflictAcceptanceCriteria, base.AcceptanceCriteria, local.AcceptanceCriteria, remote.AcceptanceCriteria)
+	return merged
+}
+
+// mergeLinks merges links as a set: links one side removed are removed and
+// links either side added are kept
+func mergeLinks(base, local, remote []domain.Link) []domain.Link {
+	key := func(link domain.Link) string { return link.Type + "\x00" + link.Key + "\x00" + link.Title }
+	keys := func(links []domain.Link) []string {
+		var keyed []string
+		for _, link := range links {
+			keyed = append(keyed, key(link))
+		}
+		return keyed
+	}
+
+	drop := setOf(difference(keys(base), keys(remote)))
+	have := setOf(keys(local))
+	inBase := setOf(keys(base))
+	var merged []domain.Link
+	for _, link := range local {
+		if !drop[key(link)] {
+			merged = append(merged, link)
+		}
+	}
+	for _, link := range remote {
+		if !have[key(link)] && !inBase[key(link)] {
+			merged = append(merged, link)
+		}
+	}
+	return merged
+}
+
+// mergeComments returns Jira's comments followed by the local comments that
+// have not been posted yet
+func mergeComments(local, remote []domain.Comment) []domain.Comment {
+	merged := append([]domain.Comment(nil), remote...)
+	for _, comment := range local {
+		if comment.ID == "" {
+			merged = append(merged, comment)
+		}
+	}
+	return merged
+}
+
+// tasksByID indexes the tasks that have a Jira key
+func tasksByID(tasks []domain.Task) map[string]domain.Task {
+	byID := make(map[string]domain.Task)
+	for _, task := range tasks {
+		if task.JiraID != "" {
+			byID[task.JiraID] = task
+		}
+	}
+	return byID
+}
+
+// equalTasks reports whether two tasks have the same content
+func equalTasks(a, b domain.Task) bool {
+	if a.Title != b.Title || a.Description != b.Description || a.Status != b.Status ||
+		!equalLines(a.AcceptanceCriteria, b.AcceptanceCriteria) || len(a.CustomFields) != len(b.CustomFields) {
+		return false
+	}
+	for name, value := range a.CustomFields {
+		if b.CustomFields[name] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// equalLines reports whether two lists hold the same lines in the same order
+func equalLines(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// difference returns the lines of a that are not in b, in the order of a
+func difference(a, b []string) []string {
+	inB := setOf(b)
+	var diff []string
+	for _, line := range a {
+		if !inB[line] {
+			diff = append(diff, line)
+		}
+	}
+	return diff
+}
+
+// setOf returns the lines as a set
+func setOf(lines []string) map[string]bool {
+	set := make(map[string]bool, len(lines))
+	for _, line := range lines {
+		set[line] = true
+	}
+	return set
+}
@@ -0,0 +1,328 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+	"github.com/karolswdev/ticktr/internal/state"
+)
+
+func TestMergeTicket_NonOverlappingChanges(t *testing.T) {
+	base := domain.Ticket{
+		JiraID:             "PROJ-1",
+		Title:              "Login",
+		Description:        "Users log in",
+		CustomFields:       map[string]string{"Priority": "Low", "Story Points": "3"},
+		AcceptanceCriteria: []string{"Email works", "Password works"},
+		Tasks: []domain.Task{
+			{JiraID: "PROJ-2", Title: "Form", Status: "To Do"},
+			{JiraID: "PROJ-3", Title: "API"},
+		},
+	}
+	local := domain.Ticket{
+		JiraID:             "PROJ-1",
+		Title:              "Login page",
+		Description:        "Users log in",
+		CustomFields:       map[string]string{"Priority": "Low", "Story Points": "5"},
+		AcceptanceCriteria: []string{"Email works", "Password works", "Remember me"},
+		Tasks: []domain.Task{
+			{JiraID: "PROJ-2", Title: "Login form", Status: "To Do"},
+			{JiraID: "PROJ-3", Title: "API"},
+			{Title: "Docs"},
+		},
+	}
+	remote := domain.Ticket{
+		JiraID:             "PROJ-1",
+		Title:              "Login",
+		Description:        "Users log in with SSO",
+		CustomFields:       map[string]string{"Priority": "High", "Story Points": "3"},
+		AcceptanceCriteria: []string{"Email works", "SSO works"},
+		Tasks: []domain.Task{
+			{JiraID: "PROJ-2", Title: "Form", Status: "Done"},
+			{JiraID: "PROJ-4", Title: "Tests"},
+		},
+		Comments: []domain.Comment{{ID: "10", Body: "Looks good"}},
+	}
+	local.Comments = []domain.Comment{{Body: "Not posted yet"}}
+
+	merged, conflicts := mergeTicket(base, local, remote, false)
+
+	if len(conflicts) != 0 {
+		t.Fatalf("Expected no conflicts, got %v", conflicts)
+	}
+	want := domain.Ticket{
+		JiraID:             "PROJ-1",
+		Title:              "Login page",
+		Description:        "Users log in with SSO",
+		CustomFields:       map[string]string{"Priority": "High", "Story Points": "5"},
+		AcceptanceCriteria: []string{"Email works", "Remember me", "SSO works"},
+		Tasks: []domain.Task{
+			{JiraID: "PROJ-2", Title: "Login form", Status: "Done", CustomFields: map[string]string{}},
+			{Title: "Docs"},
+			{JiraID: "PROJ-4", Title: "Tests"},
+		},
+		Comments: []domain.Comment{{ID: "10", Body: "Looks good"}, {Body: "Not posted yet"}},
+	}
+	if !reflect.DeepEqual(merged, want) {
+		t.Errorf("Merged ticket:\n got %+v\nwant %+v", merged, want)
+	}
+}
+
+func TestMergeTicket_SameFieldConflicts(t *testing.T) {
+	base := domain.Ticket{
+		JiraID:       "PROJ-1",
+		Title:        "Login",
+		CustomFields: map[string]string{"Priority": "Low"},
+		Tasks:        []domain.Task{{JiraID: "PROJ-2", Title: "Form"}},
+	}
+	local := domain.Ticket{
+		JiraID:       "PROJ-1",
+		Title:        "Login page",
+		CustomFields: map[string]string{"Priority": "Medium"},
+		Tasks:        []domain.Task{{JiraID: "PROJ-2", Title: "Login form"}},
+	}
+	remote := domain.Ticket{
+		JiraID:       "PROJ-1",
+		Title:        "Login page",
+		CustomFields: map[string]string{"Priority": "High"},
+		Tasks:        []domain.Task{{JiraID: "PROJ-2", Title: "Sign-in form"}},
+	}
+
+	merged, conflicts := mergeTicket(base, local, remote, false)
+
+	wantConflicts := []FieldConflict{
+		{JiraID: "PROJ-1", Field: "Priority", Base: "Low", Local: "Medium", Remote: "High"},
+		{JiraID: "PROJ-1", TaskID: "PROJ-2", Field: "Title", Base: "Form", Local: "Login form", Remote: "Sign-in form"},
+	}
+	if !reflect.DeepEqual(conflicts, wantConflicts) {
+		t.Errorf("Conflicts:\n got %+v\nwant %+v", conflicts, wantConflicts)
+	}
+	if merged.Title != "Login page" {
+		t.Errorf("Title changed the same way on both sides should merge, got %q", merged.Title)
+	}
+	if merged.CustomFields["Priority"] != "Medium" || merged.Tasks[0].Title != "Login form" {
+		t.Errorf("Conflicting fields should keep the local value, got %q and %q", merged.CustomFields["Priority"], merged.Tasks[0].Title)
+	}
+
+	merged, _ = mergeTicket(base, local, remote, true)
+	if merged.CustomFields["Priority"] != "High" || merged.Tasks[0].Title != "Sign-in form" {
+		t.Errorf("Conflicting fields should take Jira's value when preferred, got %q and %q", merged.CustomFields["Priority"], merged.Tasks[0].Title)
+	}
+}
+
+func TestMergeTicket_AcceptanceCriteriaRewrittenOnBothSides(t *testing.T) {
+	base := domain.Ticket{JiraID: "PROJ-1", AcceptanceCriteria: []string{"Fast", "Secure"}}
+	local := domain.Ticket{JiraID: "PROJ-1", AcceptanceCriteria: []string{"Under 200ms", "Secure"}}
+	remote := domain.Ticket{JiraID: "PROJ-1", AcceptanceCriteria: []string{"Under 1s", "Secure"}}
+
+	merged, conflicts := mergeTicket(base, local, remote, false)
+
+	if len(conflicts) != 1 || conflicts[0].Field != "Acceptance Criteria" {
+		t.Fatalf("Expected an Acceptance Criteria conflict, got %v", conflicts)
+	}
+	if !reflect.DeepEqual(merged.AcceptanceCriteria, local.AcceptanceCriteria) {
+		t.Errorf("Expected the local criteria to be kept, got %v", merged.AcceptanceCriteria)
+	}
+}
+
+func TestMergeTicket_TaskDeletedOnOneSide(t *testing.T) {
+	base := domain.Ticket{JiraID: "PROJ-1", Tasks: []domain.Task{
+		{JiraID: "PROJ-2", Title: "Form"},
+		{JiraID: "PROJ-3", Title: "API"},
+	}}
+	// PROJ-2 deleted locally, PROJ-3 deleted in Jira but renamed locally
+	local := domain.Ticket{JiraID: "PROJ-1", Tasks: []domain.Task{{JiraID: "PROJ-3", Title: "REST API"}}}
+	remote := domain.Ticket{JiraID: "PROJ-1", Tasks: []domain.Task{{JiraID: "PROJ-2", Title: "Form"}}}
+
+	merged, conflicts := mergeTicket(base, local, remote, false)
+
+	if len(conflicts) != 1 || conflicts[0].TaskID != "PROJ-3" || conflicts[0].Field != "Task" {
+		t.Fatalf("Expected a Task conflict for PROJ-3, got %v", conflicts)
+	}
+	if len(merged.Tasks) != 1 || merged.Tasks[0].JiraID != "PROJ-3" {
+		t.Errorf("Expected only the changed PROJ-3 to be kept, got %+v", merged.Tasks)
+	}
+}
+
+func TestPullService_MergesAgainstBase(t *testing.T) {
+	stateManager := state.NewStateManager(filepath.Join(t.TempDir(), "test.state"))
+	base := domain.Ticket{JiraID: "PROJ-1", Title: "Login", Description: "Users log in", CustomFields: map[string]string{}}
+	stateManager.UpdateHash(base)
+
+	local := base
+	local.Title = "Login page"
+	remote := base
+	remote.Description = "Users log in with SSO"
+
+	repo := &MockRepositoryForPull{tickets: []domain.Ticket{local}}
+	pullService := NewPullService(&MockJiraPortForPull{searchResult: []domain.Ticket{remote}}, repo, stateManager)
+
+	result, err := pullService.Pull(context.Background(), "test.md", PullOptions{ProjectKey: "PROJ"})
+	if err != nil {
+		t.Fatalf("Expected changes to merge, got %v", err)
+	}
+
+	if result.TicketsMerged != 1 || len(result.Conflicts) != 0 {
+		t.Errorf("Expected 1 merged ticket and no conflicts, got %d and %v", result.TicketsMerged, result.Conflicts)
+	}
+	if len(repo.saveTickets) != 1 || repo.saveTickets[0].Title != "Login page" || repo.saveTickets[0].Description != "Users log in with SSO" {
+		t.Fatalf("Expected the file to hold both changes, got %+v", repo.saveTickets)
+	}
+
+	// The local title change still has to be pushed
+	stored, _ := stateManager.GetStoredState("PROJ-1")
+	if stored.RemoteHash != stateManager.CalculateHash(remote) {
+		t.Error("Expected the remote hash to be Jira's version")
+	}
+	if !stateManager.HasChanged(repo.saveTickets[0]) {
+		t.Error("Expected the merged ticket to count as changed for push")
+	}
+	if stored.Base == nil || stored.Base.Description != "Users log in with SSO" || stored.Base.Title != "Login" {
+		t.Errorf("Expected Jira's version as the new base, got %+v", stored.Base)
+	}
+}
+
+func TestPullService_ReportsFieldConflicts(t *testing.T) {
+	stateManager := state.NewStateManager(filepath.Join(t.TempDir(), "test.state"))
+	base := domain.Ticket{JiraID: "PROJ-1", Title: "Login", Description: "Users log in"}
+	stateManager.UpdateHash(base)
+	before, _ := stateManager.GetStoredState("PROJ-1")
+
+	local := base
+	local.Title = "Login page"
+	local.Description = "Users log in quickly"
+	remote := base
+	remote.Title = "Sign in"
+
+	repo := &MockRepositoryForPull{tickets: []domain.Ticket{local}}
+	pullService := NewPullService(&MockJiraPortForPull{searchResult: []domain.Ticket{remote}}, repo, stateManager)
+
+	result, err := pullService.Pull(context.Background(), "test.md", PullOptions{ProjectKey: "PROJ"})
+	if !errors.Is(err, ErrConflictDetected) {
+		t.Fatalf("Expected ErrConflictDetected, got %v", err)
+	}
+
+	want := []FieldConflict{{JiraID: "PROJ-1", Field: "Title", Base: "Login", Local: "Login page", Remote: "Sign in"}}
+	if !reflect.DeepEqual(result.FieldConflicts, want) {
+		t.Errorf("Field conflicts:\n got %+v\nwant %+v", result.FieldConflicts, want)
+	}
+	if len(repo.saveTickets) != 1 || repo.saveTickets[0].Title != "Login page" || repo.saveTickets[0].Description != "Users log in quickly" {
+		t.Errorf("Expected local values to be kept, got %+v", repo.saveTickets)
+	}
+	after, _ := stateManager.GetStoredState("PROJ-1")
+	if after.RemoteHash != before.RemoteHash {
+		t.Error("Expected the state to stay unchanged until the conflict is resolved")
+	}
+}
+
+// conflictRepository records the Jira side of the conflicts it is asked to mark
+type conflictRepository struct {
+	MockRepositoryForPull
+	theirs map[string]domain.Ticket
+}
+
+func (r *conflictRepository) SaveTicketsWithConflicts(ctx context.Context, filePath string, tickets []domain.Ticket, theirs map[string]domain.Ticket) error {
+	r.theirs = theirs
+	return r.SaveTickets(ctx, filePath, tickets)
+}
+
+func TestPullService_ConflictMarkers(t *testing.T) {
+	stateManager := state.NewStateManager(filepath.Join(t.TempDir(), "test.state"))
+	base := domain.Ticket{JiraID: "PROJ-1", Title: "Login", Description: "Users log in"}
+	stateManager.UpdateHash(base)
+
+	local := base
+	local.Title = "Login page"
+	remote := base
+	remote.Title = "Sign in"
+	remote.Description = "Users log in with SSO"
+
+	repo := &conflictRepository{MockRepositoryForPull: MockRepositoryForPull{tickets: []domain.Ticket{local}}}
+	pullService := NewPullService(&MockJiraPortForPull{searchResult: []domain.Ticket{remote}}, repo, stateManager)
+
+	_, err := pullService.Pull(context.Background(), "test.md", PullOptions{ProjectKey: "PROJ", ConflictMarkers: true})
+	if !errors.Is(err, ErrConflictDetected) {
+		t.Fatalf("Expected ErrConflictDetected, got %v", err)
+	}
+
+	theirs, ok := repo.theirs["PROJ-1"]
+	if !ok || theirs.Title != "Sign in" || theirs.Description != "Users log in with SSO" {
+		t.Errorf("Expected Jira's side of the conflict with the merged description, got %+v", repo.theirs)
+	}
+	if ours := repo.saveTickets[0]; ours.Title != "Login page" || ours.Description != "Users log in with SSO" {
+		t.Errorf("Expected the local side with the merged description, got %+v", ours)
+	}
+	if conflicts := stateManager.Conflicts(); len(conflicts) != 1 || conflicts[0] != "PROJ-1" {
+		t.Errorf("Expected the conflict to be recorded, got %v", conflicts)
+	}
+
+	// Repositories that cannot write markers are refused up front
+	plain := NewPullService(&MockJiraPortForPull{}, &MockRepositoryForPull{}, stateManager)
+	if _, err := plain.Pull(context.Background(), "test.md", PullOptions{ConflictMarkers: true}); err == nil {
+		t.Error("Expected an error for a repository without conflict markers")
+	}
+}
+
+// scriptedResolver answers conflicts in order, failing once it runs out
+type scriptedResolver struct {
+	answers []string
+	asked   []FieldConflict
+}
+
+func (r *scriptedResolver) ResolveConflict(conflict FieldConflict) (string, error) {
+	r.asked = append(r.asked, conflict)
+	if len(r.answers) == 0 {
+		return "", errors.New("no more answers")
+	}
+	answer := r.answers[0]
+	r.answers = r.answers[1:]
+	return answer, nil
+}
+
+func TestPullService_Resolver(t *testing.T) {
+	stateManager := state.NewStateManager(filepath.Join(t.TempDir(), "test.state"))
+	base := domain.Ticket{JiraID: "PROJ-1", Title: "Login", Description: "Users log in", CustomFields: map[string]string{"Priority": "Low"}}
+	stateManager.UpdateHash(base)
+
+	local := base
+	local.Title = "Login page"
+	local.CustomFields = map[string]string{"Priority": "Medium"}
+	remote := base
+	remote.Title = "Sign in"
+	remote.Description = "Users log in with SSO"
+	remote.CustomFields = map[string]string{"Priority": "High"}
+
+	// Running out of answers aborts without writing anything
+	repo := &MockRepositoryForPull{tickets: []domain.Ticket{local}}
+	pullService := NewPullService(&MockJiraPortForPull{searchResult: []domain.Ticket{remote}}, repo, stateManager)
+	if _, err := pullService.Pull(context.Background(), "test.md", PullOptions{ProjectKey: "PROJ", Resolver: &scriptedResolver{answers: []string{"Sign in"}}}); err == nil {
+		t.Fatal("Expected the resolver's error")
+	}
+	if repo.saveTickets != nil {
+		t.Errorf("Expected nothing to be written, got %+v", repo.saveTickets)
+	}
+
+	resolver := &scriptedResolver{answers: []string{"Sign in page", "High"}}
+	result, err := pullService.Pull(context.Background(), "test.md", PullOptions{ProjectKey: "PROJ", Resolver: resolver})
+	if err != nil {
+		t.Fatalf("Expected every conflict to be resolved, got %v", err)
+	}
+
+	if len(resolver.asked) != 2 || resolver.asked[0].Field != "Title" || resolver.asked[1].Field != "Priority" {
+		t.Errorf("Expected to be asked about Title and Priority, got %+v", resolver.asked)
+	}
+	if len(result.Conflicts) != 0 || result.TicketsMerged != 1 {
+		t.Errorf("Expected 1 merged ticket and no conflicts, got %d and %v", result.TicketsMerged, result.Conflicts)
+	}
+	saved := repo.saveTickets[0]
+	if saved.Title != "Sign in page" || saved.CustomFields["Priority"] != "High" || saved.Description != "Users log in with SSO" {
+		t.Errorf("Expected the chosen values and the merged description, got %+v", saved)
+	}
+	if stored, _ := stateManager.GetStoredState("PROJ-1"); stored.RemoteHash != stateManager.CalculateHash(remote) {
+		t.Error("Expected Jira's version to be recorded as synced")
+	}
+}
@@ -0,0 +1,250 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"log"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+)
+
+// PlanAction describes what a push would do with a ticket or task
+type PlanAction string
+
+const (
+	// PlanCreate means the item has no Jira ID and would be created
+	PlanCreate PlanAction = "create"
+	// PlanUpdate means the item exists in Jira and would be updated
+	PlanUpdate PlanAction = "update"
+	// PlanSkip means the item is unchanged since the last push and would be skipped
+	PlanSkip PlanAction = "skip"
+)
+
+// PlanItem describes the planned operation for a single ticket or task
+type PlanItem struct {
+	Action     PlanAction           `json:"action"`
+	Title      string               `json:"title"`
+	JiraID     string               `json:"jira_id,omitempty"`
+	ParentID   string               `json:"parent_id,omitempty"`
+	Line       int                  `json:"line,omitempty"`
+	Changes    []domain.FieldChange `json:"changes,omitempty"`
+	Transition *PlanTransition      `json:"transition,omitempty"`
+	Links      []PlanLink           `json:"links,omitempty"`    // Links that would be created
+	Comments   []string             `json:"comments,omitempty"` // Bodies of the comments that would be posted
+	Tasks      []PlanItem           `json:"tasks,omitempty"`
+}
+
+// PlanTransition is the workflow status change a push would make
+type PlanTransition struct {
+	To   string   `json:"to"`
+	Path []string `json:"path,omitempty"` // Statuses passed through, ending with To; unknown until the issue exists
+}
+
+// PlanLink is a link a push would create from a ticket
+type PlanLink struct {
+	Type  string `json:"type"`
+	Key   string `json:"key,omitempty"`
+	Title string `json:"title,omitempty"` // Local ticket the push creates first
+}
+
+// PlanSummary counts planned operations across tickets and tasks
+type PlanSummary struct {
+	Create      int `json:"create"`
+	Update      int `json:"update"`
+	Skip        int `json:"skip"`
+	Transitions int `json:"transitions"`
+	Links       int `json:"links"`
+	Comments    int `json:"comments"`
+}
+
+// Plan is a dry-run description of what PushTickets would do for a file
+type Plan struct {
+	File    string      `json:"file"`
+	Tickets []PlanItem  `json:"tickets"`
+	Summary PlanSummary `json:"summary"`
+}
+
+// Plan computes what PushTickets would do for the given file without writing
+// to Jira, the Markdown file or the state file
+func (s *PushService) Plan(ctx context.Context, filePath string) (*Plan, error) {
+	plan := &Plan{
+		File:    filePath,
+		Tickets: []PlanItem{},
+	}
+
+	// Load the current state
+	if err := s.stateManager.Load(); err != nil {
+		log.Printf("Warning: Could not load state file: %v", err)
+		// Continue anyway - we'll treat everything as changed
+	}
+
+	// Read tickets from the file
+	tickets, err := s.repository.GetTickets(ctx, filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read tickets from file: %w", err)
+	}
+
+	// Links to local tickets resolve by title, as in pushLinks
+	keys := make(map[string]string)
+	titles := make(map[string]bool)
+	for _, ticket := range tickets {
+		titles[ticket.Title] = true
+		if ticket.JiraID != "" {
+			keys[ticket.Title] = ticket.JiraID
+		}
+	}
+
+	for _, ticket := range tickets {
+		item := PlanItem{
+			Title:  ticket.Title,
+			JiraID: ticket.JiraID,
+			Line:   ticket.SourceLine,
+		}
+
+		// Unchanged tickets are skipped together with their tasks
+		if !s.stateManager.HasChanged(ticket) {
+			item.Action = PlanSkip
+			plan.Summary.Skip++
+			for _, task := range ticket.Tasks {
+				item.Tasks = append(item.Tasks, PlanItem{
+					Action:   PlanSkip,
+					Title:    task.Title,
+					JiraID:   task.JiraID,
+					ParentID: ticket.JiraID,
+					Line:     task.SourceLine,
+				})
+				plan.Summary.Skip++
+			}
+			plan.Tickets = append(plan.Tickets, item)
+			continue
+		}
+
+		item.Action = PlanUpdate
+		if ticket.JiraID == "" {
+			item.Action = PlanCreate
+		}
+		plan.Summary.count(item.Action)
+
+		changes, err := s.jiraClient.DiffTicket(ctx, ticket)
+		if err != nil {
+			return nil, fmt.Errorf("failed to plan ticket '%s': %w", ticket.Title, err)
+		}
+		item.Changes = changes
+
+		if item.Transition, err = s.planTransition(ctx, ticket.JiraID, ticket.Status); err != nil {
+			return nil, fmt.Errorf("failed to plan status of ticket '%s': %w", ticket.Title, err)
+		}
+		if item.Links, err = s.planLinks(ctx, ticket, keys, titles); err != nil {
+			return nil, fmt.Errorf("failed to plan links of ticket '%s': %w", ticket.Title, err)
+		}
+		for _, comment := range ticket.Comments {
+			if comment.ID == "" {
+				item.Comments = append(item.Comments, comment.Body)
+			}
+		}
+		plan.Summary.countExtras(item)
+
+		for _, task := range ticket.Tasks {
+			// Diff the task with the same inherited fields PushTickets would send
+			taskWithFields := task
+			taskWithFields.CustomFields = s.calculateFinalFields(ticket, task)
+
+			taskItem := PlanItem{
+				Action:   PlanUpdate,
+				Title:    task.Title,
+				JiraID:   task.JiraID,
+				ParentID: ticket.JiraID,
+				Line:     task.SourceLine,
+			}
+			if task.JiraID == "" {
+				taskItem.Action = PlanCreate
+			}
+			plan.Summary.count(taskItem.Action)
+
+			taskChanges, err := s.jiraClient.DiffTask(ctx, taskWithFields, ticket.JiraID)
+			if err != nil {
+				return nil, fmt.Errorf("failed to plan task '%s': %w", task.Title, err)
+			}
+			taskItem.Changes = taskChanges
+
+			if taskItem.Transition, err = s.planTransition(ctx, task.JiraID, task.Status); err != nil {
+				return nil, fmt.Errorf("failed to plan status of task '%s': %w", task.Title, err)
+			}
+			plan.Summary.countExtras(taskItem)
+
+			item.Tasks = append(item.Tasks, taskItem)
+		}
+
+		plan.Tickets = append(plan.Tickets, item)
+	}
+
+	return plan, nil
+}
+
+// planTransition returns the status change a push would make, or nil when
+// there is none. The path of an issue not created yet is left out.
+func (s *PushService) planTransition(ctx context.Context, issueKey, status string) (*PlanTransition, error) {
+	if status == "" {
+		return nil, nil
+	}
+	if issueKey == "" {
+		return &PlanTransition{To: status}, nil
+	}
+
+	path, err := s.jiraClient.TransitionPath(ctx, issueKey, status)
+	if err != nil || len(path) == 0 {
+		return nil, err
+	}
+	return &PlanTransition{To: status, Path: path}, nil
+}
+
+// planLinks returns the links a push would create for a ticket, resolving
+// local tickets by title like pushLinks does
+func (s *PushService) planLinks(ctx context.Context, ticket domain.Ticket, keys map[string]string, titles map[string]bool) ([]PlanLink, error) {
+	resolved := make([]domain.Link, len(ticket.Links))
+	for i, link := range ticket.Links {
+		if link.Key == "" {
+			if !titles[link.Title] {
+				return nil, fmt.Errorf("no ticket titled '%s'", link.Title)
+			}
+			link.Key = keys[link.Title]
+		}
+		resolved[i] = link
+	}
+
+	missing, err := s.jiraClient.DiffLinks(ctx, ticket.JiraID, resolved)
+	if err != nil {
+		return nil, err
+	}
+
+	var links []PlanLink
+	for _, link := range missing {
+		planned := PlanLink{Type: link.Type, Key: link.Key}
+		if link.Key == "" {
+			planned.Title = link.Title
+		}
+		links = append(links, planned)
+	}
+	return links, nil
+}
+
+// countExtras counts the transition, links and comments of an item
+func (ps *PlanSummary) countExtras(item PlanItem) {
+	if item.Transition != nil {
+		ps.Transitions++
+	}
+	ps.Links += len(item.Links)
+	ps.Comments += len(item.Comments)
+}
+
+// count increments the counter matching the action
+func (ps *PlanSummary) count(action PlanAction) {
+	switch action {
+	case PlanCreate:
+		ps.Create++
+	case PlanUpdate:
+		ps.Update++
+	case PlanSkip:
+		ps.Skip++
+	}
+}
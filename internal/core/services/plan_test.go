@@ -0,0 +1,186 @@
+package services
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+	"github.com/karolswdev/ticktr/internal/state"
+)
+
+// TestPushService_Plan_ClassifiesTicketsAndTasks verifies create/update/skip decisions mirror PushTickets
+func TestPushService_Plan_ClassifiesTicketsAndTasks(t *testing.T) {
+	stateManager := state.NewStateManager(filepath.Join(t.TempDir(), "test.state"))
+
+	unchanged := domain.Ticket{
+		Title:  "Unchanged ticket",
+		JiraID: "PROJ-1",
+		Tasks:  []domain.Task{{Title: "Unchanged task", JiraID: "PROJ-2"}},
+	}
+	stateManager.UpdateHash(unchanged)
+
+	changed := domain.Ticket{
+		Title:        "Changed ticket",
+		JiraID:       "PROJ-3",
+		CustomFields: map[string]string{"Priority": "High"},
+		Tasks: []domain.Task{
+			{Title: "Existing task", JiraID: "PROJ-4"},
+			{Title: "New task"},
+		},
+	}
+	newTicket := domain.Ticket{Title: "New ticket"}
+
+	repo := &MockRepository{tickets: []domain.Ticket{unchanged, changed, newTicket}}
+	jiraClient := &MockJiraPort{
+		TicketChanges: map[string][]domain.FieldChange{
+			"PROJ-3": {{Field: "priority", Current: "Low", Proposed: "High"}},
+		},
+	}
+
+	service := NewPushService(repo, jiraClient, stateManager)
+	plan, err := service.Plan(context.Background(), "test.md")
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+
+	if len(plan.Tickets) != 3 {
+		t.Fatalf("Expected 3 planned tickets, got %d", len(plan.Tickets))
+	}
+
+	if plan.Tickets[0].Action != PlanSkip || plan.Tickets[0].Tasks[0].Action != PlanSkip {
+		t.Errorf("Expected unchanged ticket and its task to be skipped, got %+v", plan.Tickets[0])
+	}
+
+	if plan.Tickets[1].Action != PlanUpdate {
+		t.Errorf("Expected changed ticket to be updated, got %s", plan.Tickets[1].Action)
+	}
+	if len(plan.Tickets[1].Changes) != 1 || plan.Tickets[1].Changes[0].Proposed != "High" {
+		t.Errorf("Expected priority change in plan, got %+v", plan.Tickets[1].Changes)
+	}
+	if plan.Tickets[1].Tasks[0].Action != PlanUpdate || plan.Tickets[1].Tasks[1].Action != PlanCreate {
+		t.Errorf("Expected task update then create, got %+v", plan.Tickets[1].Tasks)
+	}
+
+	if plan.Tickets[2].Action != PlanCreate {
+		t.Errorf("Expected new ticket to be created, got %s", plan.Tickets[2].Action)
+	}
+
+	expected := PlanSummary{Create: 2, Update: 2, Skip: 2}
+	if plan.Summary != expected {
+		t.Errorf("Expected summary %+v, got %+v", expected, plan.Summary)
+	}
+}
+
+// TestPushService_Plan_TransitionsLinksAndComments verifies the plan lists the status changes, links and comments push would send
+func TestPushService_Plan_TransitionsLinksAndComments(t *testing.T) {
+	stateManager := state.NewStateManager(filepath.Join(t.TempDir(), "test.state"))
+
+	repo := &MockRepository{tickets: []domain.Ticket{
+		{
+			Title:  "Payments",
+			JiraID: "PROJ-1",
+			Status: "Done",
+			Links: []domain.Link{
+				{Type: "blocks", Key: "PROJ-9"},
+				{Type: "relates to", Key: "PROJ-7"},
+				{Type: "blocks", Title: "Checkout"},
+			},
+			Comments: []domain.Comment{
+				{ID: "10001", Body: "Already in Jira"},
+				{Body: "Ready for review"},
+			},
+			Tasks: []domain.Task{{Title: "Docs", JiraID: "PROJ-2", Status: "In Progress"}},
+		},
+		{Title: "Checkout", Status: "In Progress", Links: []domain.Link{{Type: "relates to", Title: "Payments"}}},
+	}}
+	jiraClient := &MockJiraPort{
+		Links:           map[string][]domain.Link{"PROJ-1": {{Type: "relates to", Key: "PROJ-7"}}},
+		TransitionPaths: map[string][]string{"PROJ-1": {"In Review", "Done"}},
+	}
+
+	service := NewPushService(repo, jiraClient, stateManager)
+	plan, err := service.Plan(context.Background(), "test.md")
+	if err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+
+	payments := plan.Tickets[0]
+	if payments.Transition == nil || strings.Join(payments.Transition.Path, ",") != "In Review,Done" {
+		t.Errorf("Expected the transition path In Review,Done, got %+v", payments.Transition)
+	}
+	wantLinks := []PlanLink{{Type: "blocks", Key: "PROJ-9"}, {Type: "blocks", Title: "Checkout"}}
+	if !reflect.DeepEqual(payments.Links, wantLinks) {
+		t.Errorf("Expected links %+v, got %+v", wantLinks, payments.Links)
+	}
+	if !reflect.DeepEqual(payments.Comments, []string{"Ready for review"}) {
+		t.Errorf("Expected the unsent comment, got %v", payments.Comments)
+	}
+	if payments.Tasks[0].Transition != nil {
+		t.Errorf("Expected no transition for a task already in its status, got %+v", payments.Tasks[0].Transition)
+	}
+
+	checkout := plan.Tickets[1]
+	if checkout.Transition == nil || checkout.Transition.To != "In Progress" || checkout.Transition.Path != nil {
+		t.Errorf("Expected a transition to In Progress with its path unknown, got %+v", checkout.Transition)
+	}
+	if !reflect.DeepEqual(checkout.Links, []PlanLink{{Type: "relates to", Key: "PROJ-1"}}) {
+		t.Errorf("Expected the link to resolve to PROJ-1, got %+v", checkout.Links)
+	}
+
+	if plan.Summary.Transitions != 2 || plan.Summary.Links != 3 || plan.Summary.Comments != 1 {
+		t.Errorf("Unexpected summary %+v", plan.Summary)
+	}
+	if len(jiraClient.Transitions)+len(jiraClient.Comments) != 0 || len(jiraClient.Links["PROJ-1"]) != 1 {
+		t.Error("Plan must not transition, comment or link in Jira")
+	}
+}
+
+// TestPushService_Plan_UnknownLinkTitle verifies a link to a title no ticket has fails the plan, as it would fail push
+func TestPushService_Plan_UnknownLinkTitle(t *testing.T) {
+	stateManager := state.NewStateManager(filepath.Join(t.TempDir(), "test.state"))
+	repo := &MockRepository{tickets: []domain.Ticket{
+		{Title: "Payments", Links: []domain.Link{{Type: "blocks", Title: "Nowhere"}}},
+	}}
+
+	service := NewPushService(repo, &MockJiraPort{}, stateManager)
+	if _, err := service.Plan(context.Background(), "test.md"); err == nil || !strings.Contains(err.Error(), "Nowhere") {
+		t.Errorf("Expected an error naming the missing ticket, got %v", err)
+	}
+}
+
+// TestPushService_Plan_DoesNotWrite verifies that planning never writes to Jira, the file or the state
+func TestPushService_Plan_DoesNotWrite(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "test.state")
+	stateManager := state.NewStateManager(stateFile)
+
+	repo := &MockRepository{tickets: []domain.Ticket{
+		{
+			Title:        "New ticket",
+			CustomFields: map[string]string{"Sprint": "Sprint 1"},
+			Tasks:        []domain.Task{{Title: "New task"}},
+		},
+	}}
+	jiraClient := &MockJiraPort{}
+
+	service := NewPushService(repo, jiraClient, stateManager)
+	if _, err := service.Plan(context.Background(), "test.md"); err != nil {
+		t.Fatalf("Plan returned error: %v", err)
+	}
+
+	if jiraClient.CreateTicketCalled+jiraClient.UpdateTicketCalled+jiraClient.CreateTaskCalled+jiraClient.UpdateTaskCalled != 0 {
+		t.Error("Plan must not create or update anything in Jira")
+	}
+	if jiraClient.DiffTicketCalled != 1 || jiraClient.DiffTaskCalled != 1 {
+		t.Errorf("Expected one ticket and one task diff, got %d and %d", jiraClient.DiffTicketCalled, jiraClient.DiffTaskCalled)
+	}
+	if repo.savedTickets != nil {
+		t.Error("Plan must not save tickets back to the file")
+	}
+	if _, err := os.Stat(stateFile); !os.IsNotExist(err) {
+		t.Error("Plan must not write the state file")
+	}
+}
@@ -1,8 +1,10 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"path/filepath"
 
 	"github.com/karolswdev/ticktr/internal/core/domain"
 	"github.com/karolswdev/ticktr/internal/core/ports"
@@ -36,21 +38,48 @@ type PullOptions struct {
 	JQL        string
 	EpicKey    string
 	Force      bool // Force overwrite even if conflicts exist
+	// ConflictMarkers writes conflicts into the file between "<<<<<<< local"
+	// and ">>>>>>> jira" markers; the repository must be a ports.ConflictRepository
+	ConflictMarkers bool
+	// Resolver, when set, is asked for the value of every field changed on
+	// both sides, so no conflicts are left; Force takes precedence
+	Resolver ConflictResolver
 }
 
 // PullResult contains the results of a pull operation
 type PullResult struct {
+	IssuesFetched  int // Tickets and subtasks fetched from JIRA across all result pages
 	TicketsPulled  int
 	TicketsUpdated int
 	TicketsSkipped int
+	TicketsMerged  int // Tickets changed on both sides whose changes were merged
 	Conflicts      []string
+	FieldConflicts []FieldConflict // Fields changed on both sides, of the tickets in Conflicts
 	Errors         []error
+	Files          []string // Markdown files written
 }
 
-// Pull fetches tickets from JIRA and updates the local file
-func (ps *PullService) Pull(filePath string, options PullOptions) (*PullResult, error) {
+// Pull fetches tickets from JIRA and updates the local file. Cancelling ctx
+// aborts the fetch; once the remote tickets are in, the merged file and state
+// are written even if ctx is cancelled so the two never disagree.
+func (ps *PullService) Pull(ctx context.Context, filePath string, options PullOptions) (*PullResult, error) {
+	router, _ := NewRouter(nil, filePath)
+	return ps.PullRouted(ctx, router, []string{filePath}, options)
+}
+
+// PullRouted fetches tickets from JIRA and merges them into several local
+// files. localFiles are the files tickets may already live in; a ticket found
+// in one of them stays there, and the router picks the file of every other
+// ticket, creating files for new groups. Every file read or routed to is
+// written, and the state once after them.
+func (ps *PullService) PullRouted(ctx context.Context, router *Router, localFiles []string, options PullOptions) (*PullResult, error) {
 	result := &PullResult{}
 
+	conflictRepository, canMarkConflicts := ps.repository.(ports.ConflictRepository)
+	if options.ConflictMarkers && !canMarkConflicts {
+		return nil, fmt.Errorf("conflict markers are not supported by this repository")
+	}
+
 	// Load current state
 	if err := ps.stateManager.Load(); err != nil {
 		return nil, fmt.Errorf("failed to load state: %w", err)
@@ -60,17 +89,107 @@ func (ps *PullService) Pull(filePath string, options PullOptions) (*PullResult,
 	jql := ps.buildJQL(options)
 
 	// Fetch tickets from JIRA
-	remoteTickets, err := ps.jiraAdapter.SearchTickets(options.ProjectKey, jql)
-	if err != nil {
+	remoteTickets, err := ps.jiraAdapter.SearchTickets(ctx, options.ProjectKey, jql)
+	incomplete := make(map[string]bool)
+	var partial *ports.PartialResultError
+	if errors.As(err, &partial) {
+		// Some subtasks could not be fetched - report them and avoid
+		// overwriting those tickets with incomplete remote data
+		result.Errors = append(result.Errors, partial.Errors...)
+		for _, key := range partial.Keys {
+			incomplete[key] = true
+		}
+	} else if err != nil {
 		return nil, fmt.Errorf("failed to fetch tickets from JIRA: %w", err)
 	}
+	for _, ticket := range remoteTickets {
+		result.IssuesFetched += 1 + len(ticket.Tasks)
+	}
+
+	// Load local tickets, noting the file each Jira key lives in
+	var files []string
+	localTickets := make(map[string][]domain.Ticket)
+	location := make(map[string]string)
+	load := func(filePath string) error {
+		filePath = filepath.Clean(filePath)
+		if _, read := localTickets[filePath]; read {
+			return nil
+		}
+		tickets, err := ps.repository.GetTickets(ctx, filePath)
+		if err != nil && !errors.Is(err, ports.ErrFileNotFound) {
+			return fmt.Errorf("failed to load local tickets from %s: %w", filePath, err)
+		}
+		files = append(files, filePath)
+		localTickets[filePath] = tickets
+		for _, ticket := range tickets {
+			if _, found := location[ticket.JiraID]; ticket.JiraID != "" && !found {
+				location[ticket.JiraID] = filePath
+			}
+		}
+		return nil
+	}
+	for _, filePath := range localFiles {
+		if err := load(filePath); err != nil {
+			return nil, err
+		}
+	}
 
-	// Load local tickets
-	localTickets, err := ps.repository.GetTickets(filePath)
-	if err != nil && !errors.Is(err, ports.ErrFileNotFound) {
-		return nil, fmt.Errorf("failed to load local tickets: %w", err)
+	// Route each remote ticket to the file it lives in, or a new one
+	routed := make(map[string][]domain.Ticket)
+	for _, remoteTicket := range remoteTickets {
+		filePath, found := location[remoteTicket.JiraID]
+		if !found {
+			if filePath, err = router.Route(remoteTicket); err != nil {
+				return nil, err
+			}
+			// A file outside localFiles may exist too; its tickets are kept
+			if err := load(filePath); err != nil {
+				return nil, err
+			}
+		}
+		routed[filePath] = append(routed[filePath], remoteTicket)
+	}
+
+	// Merge every file before saving any, so a failed merge writes nothing
+	merged := make([][]domain.Ticket, len(files))
+	theirs := make([]map[string]domain.Ticket, len(files))
+	for i, filePath := range files {
+		if merged[i], theirs[i], err = ps.merge(routed[filePath], localTickets[filePath], incomplete, options, result); err != nil {
+			return nil, err
+		}
+	}
+
+	// Save each file
+	saveCtx := context.WithoutCancel(ctx)
+	for i, filePath := range files {
+		save := ps.repository.SaveTickets
+		if options.ConflictMarkers && len(theirs[i]) > 0 {
+			save = func(ctx context.Context, filePath string, tickets []domain.Ticket) error {
+				return conflictRepository.SaveTicketsWithConflicts(ctx, filePath, tickets, theirs[i])
+			}
+		}
+		if err := save(saveCtx, filePath, merged[i]); err != nil {
+			return nil, fmt.Errorf("failed to save tickets to %s: %w", filePath, err)
+		}
+		result.Files = append(result.Files, filePath)
+	}
+
+	// Save updated state
+	if err := ps.stateManager.Save(); err != nil {
+		return nil, fmt.Errorf("failed to save state: %w", err)
 	}
 
+	// Return specific error if conflicts were detected
+	if len(result.Conflicts) > 0 && !options.Force {
+		return result, fmt.Errorf("%w: tickets %v have local and remote changes", ErrConflictDetected, result.Conflicts)
+	}
+
+	return result, nil
+}
+
+// merge merges remote tickets into the local tickets of one file, updating
+// the state and result. Local tickets Jira did not return are kept.
+func (ps *PullService) merge(remoteTickets, localTickets []domain.Ticket, incomplete map[string]bool, options PullOptions, result *PullResult) ([]domain.Ticket, map[string]domain.Ticket, error) {
 	// Create a map of local tickets by JiraID for easier lookup
 	localTicketMap := make(map[string]*domain.Ticket)
 	for i := range localTickets {
@@ -79,15 +198,27 @@ func (ps *PullService) Pull(filePath string, options PullOptions) (*PullResult,
 		}
 	}
 
-	// Process each remote ticket
+	// Process each remote ticket, keeping Jira's side of each conflict
 	mergedTickets := []domain.Ticket{}
+	theirs := make(map[string]domain.Ticket)
 	for _, remoteTicket := range remoteTickets {
 		remoteHash := ps.stateManager.CalculateHash(remoteTicket)
 
 		// Check if ticket exists locally
 		localTicket, existsLocally := localTicketMap[remoteTicket.JiraID]
 
-		if !existsLocally {
+		if incomplete[remoteTicket.JiraID] {
+			// Subtasks are missing from the remote data - keep the local version if
+			// there is one and leave the state untouched so the next pull retries
+			if existsLocally {
+				mergedTickets = append(mergedTickets, *localTicket)
+				delete(localTicketMap, remoteTicket.JiraID)
+				result.TicketsSkipped++
+			} else {
+				mergedTickets = append(mergedTickets, remoteTicket)
+				result.TicketsPulled++
+			}
+		} else if !existsLocally {
 			// New ticket from remote
 			mergedTickets = append(mergedTickets, remoteTicket)
 			ps.stateManager.UpdateHash(remoteTicket)
@@ -108,8 +239,40 @@ func (ps *PullService) Pull(filePath string, options PullOptions) (*PullResult,
 				localChanged := localHash != storedState.LocalHash
 				remoteChanged := remoteHash != storedState.RemoteHash
 
-				if localChanged && remoteChanged {
-					// Conflict detected!
+				if localChanged && remoteChanged && options.Resolver != nil && !options.Force {
+					// Both changed - merge field by field, asking about each conflict.
+					// Without a base every field that differs is asked about.
+					base := domain.Ticket{JiraID: remoteTicket.JiraID}
+					if storedState.Base != nil {
+						base = storedState.Base.Ticket(remoteTicket.JiraID)
+					}
+					mergedTicket, _, err := resolveTicket(base, *localTicket, remoteTicket, options.Resolver)
+					if err != nil {
+						return nil, nil, fmt.Errorf("failed to resolve conflicts in %s: %w", remoteTicket.JiraID, err)
+					}
+					mergedTickets = append(mergedTickets, mergedTicket)
+					ps.stateManager.UpdateRemote(remoteTicket)
+					result.TicketsMerged++
+				} else if localChanged && remoteChanged && storedState.Base != nil {
+					// Both changed - merge field by field against the last synced version
+					base := storedState.Base.Ticket(remoteTicket.JiraID)
+					mergedTicket, conflicts := mergeTicket(base, *localTicket, remoteTicket, options.Force)
+					mergedTickets = append(mergedTickets, mergedTicket)
+					if len(conflicts) > 0 {
+						result.Conflicts = append(result.Conflicts, remoteTicket.JiraID)
+						result.FieldConflicts = append(result.FieldConflicts, conflicts...)
+					}
+					if len(conflicts) == 0 || options.Force {
+						// Jira's version is the new base; the local changes
+						// merged into it are still pushed
+						ps.stateManager.UpdateRemote(remoteTicket)
+						result.TicketsMerged++
+					} else {
+						theirs[remoteTicket.JiraID], _ = mergeTicket(base, *localTicket, remoteTicket, true)
+						ps.stateManager.MarkConflict(remoteTicket)
+					}
+				} else if localChanged && remoteChanged {
+					// Conflict detected, and no base to merge against
 					result.Conflicts = append(result.Conflicts, remoteTicket.JiraID)
 
 					if options.Force {
@@ -120,20 +283,19 @@ func (ps *PullService) Pull(filePath string, options PullOptions) (*PullResult,
 					} else {
 						// Keep local version but note the conflict
 						mergedTickets = append(mergedTickets, *localTicket)
+						theirs[remoteTicket.JiraID] = remoteTicket
+						ps.stateManager.MarkConflict(remoteTicket)
 						result.TicketsSkipped++
 					}
 				} else if remoteChanged && !localChanged {
 					// Only remote changed - safe to update
 					mergedTickets = append(mergedTickets, remoteTicket)
-					ps.stateManager.SetStoredState(remoteTicket.JiraID, state.TicketState{
-						LocalHash:  remoteHash,
-						RemoteHash: remoteHash,
-					})
+					ps.stateManager.UpdateHash(remoteTicket)
 					result.TicketsUpdated++
 				} else if localChanged && !remoteChanged {
-					// Only local changed - keep local version
+					// Only local changed - keep local version, leaving the state
+					// alone so push still sends the changes
 					mergedTickets = append(mergedTickets, *localTicket)
-					ps.stateManager.UpdateLocalHash(*localTicket)
 					result.TicketsSkipped++
 				} else {
 					// No changes - keep as is
@@ -147,27 +309,15 @@ func (ps *PullService) Pull(filePath string, options PullOptions) (*PullResult,
 		}
 	}
 
-	// Add any remaining local-only tickets
-	for _, localTicket := range localTicketMap {
-		mergedTickets = append(mergedTickets, *localTicket)
-	}
-
-	// Save merged tickets to file
-	if err := ps.repository.SaveTickets(filePath, mergedTickets); err != nil {
-		return nil, fmt.Errorf("failed to save tickets: %w", err)
-	}
-
-	// Save updated state
-	if err := ps.stateManager.Save(); err != nil {
-		return nil, fmt.Errorf("failed to save state: %w", err)
-	}
-
-	// Return specific error if conflicts were detected
-	if len(result.Conflicts) > 0 && !options.Force {
-		return result, fmt.Errorf("%w: tickets %v have local and remote changes", ErrConflictDetected, result.Conflicts)
+	// Add the local-only tickets in file order: drafts without a Jira key yet
+	// and tickets Jira did not return
+	for _, localTicket := range localTickets {
+		if _, matched := localTicketMap[localTicket.JiraID]; localTicket.JiraID == "" || matched {
+			mergedTickets = append(mergedTickets, localTicket)
+		}
 	}
 
-	return result, nil
+	return mergedTickets, theirs, nil
 }
 
 // buildJQL constructs the JQL query from options
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"path/filepath"
 	"testing"
@@ -53,7 +54,7 @@ func TestPullService_DetectsConflictState(t *testing.T) {
 	pullService := NewPullService(mockJira, mockRepo, stateManager)
 
 	// Act: Run the pull service
-	result, err := pullService.Pull("test.md", PullOptions{
+	result, err := pullService.Pull(context.Background(), "test.md", PullOptions{
 		ProjectKey: "TEST",
 	})
 
@@ -99,14 +100,14 @@ type MockRepositoryForPull struct {
 	saveTicketsFunc func(string, []domain.Ticket) error
 }
 
-func (m *MockRepositoryForPull) GetTickets(filePath string) ([]domain.Ticket, error) {
+func (m *MockRepositoryForPull) GetTickets(ctx context.Context, filePath string) ([]domain.Ticket, error) {
 	if m.getTicketsFunc != nil {
 		return m.getTicketsFunc(filePath)
 	}
 	return m.tickets, nil
 }
 
-func (m *MockRepositoryForPull) SaveTickets(filePath string, tickets []domain.Ticket) error {
+func (m *MockRepositoryForPull) SaveTickets(ctx context.Context, filePath string, tickets []domain.Ticket) error {
 	if m.saveTicketsFunc != nil {
 		return m.saveTicketsFunc(filePath, tickets)
 	}
@@ -120,41 +121,69 @@ type MockJiraPortForPull struct {
 	searchTicketsFunc func(projectKey string, jql string) ([]domain.Ticket, error)
 }
 
-func (m *MockJiraPortForPull) Authenticate() error {
+func (m *MockJiraPortForPull) Authenticate(ctx context.Context) error {
 	return nil
 }
 
-func (m *MockJiraPortForPull) CreateTask(task domain.Task, parentID string) (string, error) {
+func (m *MockJiraPortForPull) CreateTask(ctx context.Context, task domain.Task, parentID string) (string, error) {
 	return "", nil
 }
 
-func (m *MockJiraPortForPull) UpdateTask(task domain.Task) error {
+func (m *MockJiraPortForPull) UpdateTask(ctx context.Context, task domain.Task) error {
 	return nil
 }
 
-func (m *MockJiraPortForPull) GetProjectIssueTypes() (map[string][]string, error) {
+func (m *MockJiraPortForPull) GetProjectIssueTypes(ctx context.Context) (map[string][]string, error) {
 	return nil, nil
 }
 
-func (m *MockJiraPortForPull) GetIssueTypeFields(issueTypeName string) (map[string]interface{}, error) {
+func (m *MockJiraPortForPull) GetIssueTypeFields(ctx context.Context, issueTypeName string) (map[string]interface{}, error) {
 	return nil, nil
 }
 
-func (m *MockJiraPortForPull) CreateTicket(ticket domain.Ticket) (string, error) {
+func (m *MockJiraPortForPull) CreateTicket(ctx context.Context, ticket domain.Ticket) (string, error) {
 	return "", nil
 }
 
-func (m *MockJiraPortForPull) UpdateTicket(ticket domain.Ticket) error {
+func (m *MockJiraPortForPull) UpdateTicket(ctx context.Context, ticket domain.Ticket) error {
 	return nil
 }
 
-func (m *MockJiraPortForPull) SearchTickets(projectKey string, jql string) ([]domain.Ticket, error) {
+func (m *MockJiraPortForPull) SearchTickets(ctx context.Context, projectKey string, jql string) ([]domain.Ticket, error) {
 	if m.searchTicketsFunc != nil {
 		return m.searchTicketsFunc(projectKey, jql)
 	}
 	return m.searchResult, m.searchError
 }
 
+func (m *MockJiraPortForPull) DiffTicket(ctx context.Context, ticket domain.Ticket) ([]domain.FieldChange, error) {
+	return nil, nil
+}
+
+func (m *MockJiraPortForPull) LinkIssues(ctx context.Context, issueKey string, links []domain.Link) error {
+	return nil
+}
+
+func (m *MockJiraPortForPull) TransitionIssue(ctx context.Context, issueKey string, status string) error {
+	return nil
+}
+
+func (m *MockJiraPortForPull) AddComment(ctx context.Context, issueKey string, comment domain.Comment) (domain.Comment, error) {
+	return comment, nil
+}
+
+func (m *MockJiraPortForPull) DiffTask(ctx context.Context, task domain.Task, parentID string) ([]domain.FieldChange, error) {
+	return nil, nil
+}
+
+func (m *MockJiraPortForPull) DiffLinks(ctx context.Context, issueKey string, links []domain.Link) ([]domain.Link, error) {
+	return links, nil
+}
+
+func (m *MockJiraPortForPull) TransitionPath(ctx context.Context, issueKey string, status string) ([]string, error) {
+	return nil, nil
+}
+
 // Test Case TC-303.2: TestPullService_ConflictResolvedWithForce
 func TestPullService_ConflictResolvedWithForce(t *testing.T) {
 	// Arrange: Create a pull_service and a StateManager with a conflict scenario
@@ -207,7 +236,7 @@ func TestPullService_ConflictResolvedWithForce(t *testing.T) {
 	pullService := NewPullService(mockJira, mockRepo, stateManager)
 
 	// Act: Run the pull service with Force: true
-	result, err := pullService.Pull(outputFile, PullOptions{
+	result, err := pullService.Pull(context.Background(), outputFile, PullOptions{
 		ProjectKey: "TEST",
 		Force:      true,
 	})
@@ -284,7 +313,7 @@ func TestPullService_FirstRunWithoutLocalFile(t *testing.T) {
 	pullService := NewPullService(mockJira, mockFileRepo, stateManager)
 
 	// Execute pull
-	result, err := pullService.Pull(filepath.Join(tmpDir, "test.md"), PullOptions{
+	result, err := pullService.Pull(context.Background(), filepath.Join(tmpDir, "test.md"), PullOptions{
 		ProjectKey: "PROJ",
 	})
 
@@ -335,7 +364,7 @@ func TestPullService_FirstRunEmptyLocal(t *testing.T) {
 	pullService := NewPullService(mockJira, mockFileRepo, stateManager)
 
 	// Execute pull
-	result, err := pullService.Pull(filepath.Join(tmpDir, "test_empty.md"), PullOptions{
+	result, err := pullService.Pull(context.Background(), filepath.Join(tmpDir, "test_empty.md"), PullOptions{
 		ProjectKey: "PROJ",
 	})
 
@@ -391,7 +420,7 @@ func TestPullService_FirstRunWithExistingLocal(t *testing.T) {
 	pullService := NewPullService(mockJira, mockFileRepo, stateManager)
 
 	// Execute pull
-	result, err := pullService.Pull(filepath.Join(tmpDir, "test_existing.md"), PullOptions{
+	result, err := pullService.Pull(context.Background(), filepath.Join(tmpDir, "test_existing.md"), PullOptions{
 		ProjectKey: "PROJ",
 	})
 
@@ -413,3 +442,78 @@ func TestPullService_FirstRunWithExistingLocal(t *testing.T) {
 		t.Errorf("Expected no conflicts on first run with existing local, got %d", len(result.Conflicts))
 	}
 }
+
+// TestPullService_ReportsIssuesFetched verifies tickets and subtasks are counted in the result
+func TestPullService_ReportsIssuesFetched(t *testing.T) {
+	mockFileRepo := &MockRepositoryForPull{
+		getTicketsFunc: func(path string) ([]domain.Ticket, error) {
+			return nil, ports.ErrFileNotFound
+		},
+	}
+
+	mockJira := &MockJiraPortForPull{
+		searchResult: []domain.Ticket{
+			{JiraID: "PROJ-1", Title: "First", Tasks: []domain.Task{{JiraID: "PROJ-3"}, {JiraID: "PROJ-4"}}},
+			{JiraID: "PROJ-2", Title: "Second"},
+		},
+	}
+
+	tmpDir := t.TempDir()
+	stateManager := state.NewStateManager(filepath.Join(tmpDir, "test.state"))
+	pullService := NewPullService(mockJira, mockFileRepo, stateManager)
+
+	result, err := pullService.Pull(context.Background(), filepath.Join(tmpDir, "test.md"), PullOptions{ProjectKey: "PROJ"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if result.IssuesFetched != 4 {
+		t.Errorf("Expected 4 issues fetched (2 tickets + 2 subtasks), got %d", result.IssuesFetched)
+	}
+}
+
+// TestPullService_SurfacesSubtaskErrors verifies partial search failures land in PullResult.Errors
+// and do not clobber the local copy of the affected ticket
+func TestPullService_SurfacesSubtaskErrors(t *testing.T) {
+	localTicket := domain.Ticket{
+		JiraID: "PROJ-1",
+		Title:  "Local title",
+		Tasks:  []domain.Task{{JiraID: "PROJ-10", Title: "Local task"}},
+	}
+	mockFileRepo := &MockRepositoryForPull{tickets: []domain.Ticket{localTicket}}
+
+	subtaskErr := errors.New("failed to fetch subtasks of PROJ-1: search failed with status 500")
+	mockJira := &MockJiraPortForPull{
+		searchTicketsFunc: func(projectKey string, jql string) ([]domain.Ticket, error) {
+			return []domain.Ticket{
+				{JiraID: "PROJ-1", Title: "Remote title", Tasks: []domain.Task{}},
+				{JiraID: "PROJ-2", Title: "Complete ticket", Tasks: []domain.Task{}},
+			}, &ports.PartialResultError{
+				Keys:   []string{"PROJ-1"},
+				Errors: []error{subtaskErr},
+			}
+		},
+	}
+
+	tmpDir := t.TempDir()
+	stateManager := state.NewStateManager(filepath.Join(tmpDir, "test.state"))
+	stateManager.UpdateHash(localTicket)
+	pullService := NewPullService(mockJira, mockFileRepo, stateManager)
+
+	result, err := pullService.Pull(context.Background(), filepath.Join(tmpDir, "test.md"), PullOptions{ProjectKey: "PROJ"})
+	if err != nil {
+		t.Fatalf("Partial search results should not fail the pull: %v", err)
+	}
+
+	if len(result.Errors) != 1 || !errors.Is(result.Errors[0], subtaskErr) {
+		t.Errorf("Expected subtask error in result, got %v", result.Errors)
+	}
+	if result.TicketsSkipped != 1 || result.TicketsPulled != 1 {
+		t.Errorf("Expected 1 skipped and 1 pulled ticket, got %+v", result)
+	}
+
+	saved := mockFileRepo.saveTickets
+	if len(saved) != 2 || saved[0].Title != "Local title" || len(saved[0].Tasks) != 1 {
+		t.Errorf("Expected local PROJ-1 to be preserved, got %+v", saved)
+	}
+}
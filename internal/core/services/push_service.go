@@ -1,8 +1,11 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"log"
+	"strings"
+	"sync"
 
 	"github.com/karolswdev/ticktr/internal/core/domain"
 	"github.com/karolswdev/ticktr/internal/core/ports"
@@ -41,26 +44,90 @@ func (s *PushService) calculateFinalFields(parent domain.Ticket, task domain.Tas
 	return finalFields
 }
 
-// PushTickets processes tickets with state management to avoid redundant updates
-func (s *PushService) PushTickets(filePath string, options ProcessOptions) (*ProcessResult, error) {
-	result := &ProcessResult{
-		Errors: []string{},
-	}
-
+// PushTickets processes tickets with state management to avoid redundant updates.
+// When ctx is cancelled, no further tickets are sent to Jira, but the Markdown
+// file and state file are still saved for the tickets already pushed.
+func (s *PushService) PushTickets(ctx context.Context, filePath string, options ProcessOptions) (*ProcessResult, error) {
 	// Load the current state
 	if err := s.stateManager.Load(); err != nil {
 		log.Printf("Warning: Could not load state file: %v", err)
 		// Continue anyway - we'll treat everything as changed
 	}
 
+	result, err := s.pushFile(ctx, filePath)
+	if result == nil {
+		return nil, err
+	}
+
+	// Save the state file
+	if err := s.stateManager.Save(); err != nil {
+		log.Printf("Warning: Could not save state file: %v", err)
+	}
+	return result, err
+}
+
+// FileResult is the outcome of pushing one file
+type FileResult struct {
+	File   string
+	Result *ProcessResult // nil when the file could not be read
+	Err    error
+}
+
+// PushFiles pushes several files, up to concurrency of them at a time, against
+// one state: the state file is loaded once before the first file and saved
+// once after the last. The results are in the order of filePaths. Files must
+// not share Jira keys, or they would overwrite each other's issues.
+func (s *PushService) PushFiles(ctx context.Context, filePaths []string, options ProcessOptions, concurrency int) []FileResult {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	if err := s.stateManager.Load(); err != nil {
+		log.Printf("Warning: Could not load state file: %v", err)
+	}
+
+	results := make([]FileResult, len(filePaths))
+	slots := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, filePath := range filePaths {
+		wg.Add(1)
+		go func(i int, filePath string) {
+			defer wg.Done()
+			slots <- struct{}{}
+			defer func() { <-slots }()
+
+			result, err := s.pushFile(ctx, filePath)
+			results[i] = FileResult{File: filePath, Result: result, Err: err}
+		}(i, filePath)
+	}
+	wg.Wait()
+
+	if err := s.stateManager.Save(); err != nil {
+		log.Printf("Warning: Could not save state file: %v", err)
+	}
+	return results
+}
+
+// pushFile pushes the tickets of one file and saves their Jira IDs back to
+// it, recording what was pushed in the loaded state
+func (s *PushService) pushFile(ctx context.Context, filePath string) (*ProcessResult, error) {
+	result := &ProcessResult{
+		Errors: []string{},
+	}
+
 	// Read tickets from the file
-	tickets, err := s.repository.GetTickets(filePath)
+	tickets, err := s.repository.GetTickets(ctx, filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read tickets from file: %w", err)
 	}
 
 	// Process each ticket
+	pushed := make(map[int]bool)       // Indexes of tickets created or updated
+	statusUnsent := make(map[int]bool) // Indexes of tickets with a failed transition
 	for i := range tickets {
+		if ctx.Err() != nil {
+			break
+		}
 		ticket := &tickets[i]
 
 		// Check if ticket has changed
@@ -72,7 +139,7 @@ func (s *PushService) PushTickets(filePath string, options ProcessOptions) (*Pro
 		// Check if ticket needs to be created or updated
 		if ticket.JiraID != "" {
 			// Update existing ticket in Jira
-			err := s.jiraClient.UpdateTicket(*ticket)
+			err := s.jiraClient.UpdateTicket(ctx, *ticket)
 			if err != nil {
 				errMsg := fmt.Sprintf("Failed to update ticket '%s' (%s): %v", ticket.Title, ticket.JiraID, err)
 				result.Errors = append(result.Errors, errMsg)
@@ -84,7 +151,7 @@ func (s *PushService) PushTickets(filePath string, options ProcessOptions) (*Pro
 			log.Printf("Updated ticket '%s' with Jira ID: %s\n", ticket.Title, ticket.JiraID)
 		} else {
 			// Create new ticket in Jira
-			jiraID, err := s.jiraClient.CreateTicket(*ticket)
+			jiraID, err := s.jiraClient.CreateTicket(ctx, *ticket)
 			if err != nil {
 				errMsg := fmt.Sprintf("Failed to create ticket '%s': %v", ticket.Title, err)
 				result.Errors = append(result.Errors, errMsg)
@@ -99,8 +166,17 @@ func (s *PushService) PushTickets(filePath string, options ProcessOptions) (*Pro
 			log.Printf("Created ticket '%s' with Jira ID: %s\n", ticket.Title, jiraID)
 		}
 
+		// Status changes go through the workflow, not the fields update
+		if !s.transition(ctx, "ticket", ticket.Title, ticket.JiraID, ticket.Status, result) {
+			statusUnsent[i] = true
+		}
+		s.postComments(ctx, ticket, result)
+
 		// Process tasks for this ticket
 		for j := range ticket.Tasks {
+			if ctx.Err() != nil {
+				break
+			}
 			task := &ticket.Tasks[j]
 
 			// Calculate final fields for task (inherit from parent + task overrides)
@@ -113,7 +189,7 @@ func (s *PushService) PushTickets(filePath string, options ProcessOptions) (*Pro
 			// Check if task needs to be created or updated
 			if task.JiraID != "" {
 				// Update existing task in Jira
-				err := s.jiraClient.UpdateTask(taskWithFields)
+				err := s.jiraClient.UpdateTask(ctx, taskWithFields)
 				if err != nil {
 					errMsg := fmt.Sprintf("  Failed to update task '%s' (%s): %v", task.Title, task.JiraID, err)
 					result.Errors = append(result.Errors, errMsg)
@@ -131,7 +207,7 @@ func (s *PushService) PushTickets(filePath string, options ProcessOptions) (*Pro
 					continue
 				}
 
-				taskJiraID, err := s.jiraClient.CreateTask(taskWithFields, ticket.JiraID)
+				taskJiraID, err := s.jiraClient.CreateTask(ctx, taskWithFields, ticket.JiraID)
 				if err != nil {
 					errMsg := fmt.Sprintf("  Failed to create task '%s': %v", task.Title, err)
 					result.Errors = append(result.Errors, errMsg)
@@ -144,22 +220,35 @@ func (s *PushService) PushTickets(filePath string, options ProcessOptions) (*Pro
 				result.TasksCreated++
 				log.Printf("  Created task '%s' with Jira ID: %s\n", task.Title, taskJiraID)
 			}
+
+			if !s.transition(ctx, "task", task.Title, task.JiraID, task.Status, result) {
+				statusUnsent[i] = true
+			}
 		}
 
 		// Update the hash after processing tasks too
-		s.stateManager.UpdateHash(*ticket)
+		s.stateManager.UpdateHash(recordedTicket(*ticket, !statusUnsent[i], true))
+		pushed[i] = true
 	}
 
-	// Save the updated tickets back to the file
-	err = s.repository.SaveTickets(filePath, tickets)
+	// Links go out once every ticket has a key, so they can point at tickets
+	// created further down the file
+	s.pushLinks(ctx, tickets, pushed, statusUnsent, result)
+
+	// Record the pushed tickets as Jira stores them
+	s.recordRemoteCopies(ctx, tickets, pushed)
+
+	// Save the updated tickets back to the file, even after cancellation, so
+	// the Jira IDs of everything created so far are not lost
+	saveCtx := context.WithoutCancel(ctx)
+	err = s.repository.SaveTickets(saveCtx, filePath, tickets)
 	if err != nil {
 		// This is not critical - we've already created the items in Jira
 		log.Printf("Warning: Failed to save updated tickets back to file: %v\n", err)
 	}
 
-	// Save the state file
-	if err := s.stateManager.Save(); err != nil {
-		log.Printf("Warning: Could not save state file: %v", err)
+	if err := ctx.Err(); err != nil {
+		return result, fmt.Errorf("push interrupted: %w", err)
 	}
 
 	// Return error if any tickets failed
@@ -169,3 +258,164 @@ func (s *PushService) PushTickets(filePath string, options ProcessOptions) (*Pro
 
 	return result, nil
 }
+
+// transition moves a pushed issue to the status its Markdown names, if any,
+// and reports whether that succeeded. kind is "ticket" or "task".
+func (s *PushService) transition(ctx context.Context, kind, title, issueKey, status string, result *ProcessResult) bool {
+	if status == "" {
+		return true
+	}
+
+	if err := s.jiraClient.TransitionIssue(ctx, issueKey, status); err != nil {
+		errMsg := fmt.Sprintf("Failed to transition %s '%s' (%s) to '%s': %v", kind, title, issueKey, status, err)
+		if kind == "task" {
+			errMsg = "  " + errMsg
+		}
+		result.Errors = append(result.Errors, errMsg)
+		log.Println(errMsg)
+		return false
+	}
+	return true
+}
+
+// postComments posts the ticket's unsent comments, replacing each with the
+// comment Jira stored. Comments that fail stay unsent.
+func (s *PushService) postComments(ctx context.Context, ticket *domain.Ticket, result *ProcessResult) {
+	for i := range ticket.Comments {
+		comment := &ticket.Comments[i]
+		if comment.ID != "" || ctx.Err() != nil {
+			continue
+		}
+
+		posted, err := s.jiraClient.AddComment(ctx, ticket.JiraID, *comment)
+		if err != nil {
+			errMsg := fmt.Sprintf("Failed to comment on ticket '%s' (%s): %v", ticket.Title, ticket.JiraID, err)
+			result.Errors = append(result.Errors, errMsg)
+			log.Println(errMsg)
+			continue
+		}
+		*comment = posted
+		log.Printf("Commented on ticket '%s' (%s)\n", ticket.Title, ticket.JiraID)
+	}
+}
+
+// recordedTicket returns the ticket as it should be recorded in state after a
+// push. Statuses, links and comments that could not be sent are left out, so
+// the ticket still reads as changed and the next push retries them.
+func recordedTicket(ticket domain.Ticket, statusSent, linksSent bool) domain.Ticket {
+	if !statusSent {
+		ticket.Status = ""
+		tasks := make([]domain.Task, len(ticket.Tasks))
+		for i, task := range ticket.Tasks {
+			task.Status = ""
+			tasks[i] = task
+		}
+		ticket.Tasks = tasks
+	}
+	if !linksSent {
+		ticket.Links = nil
+	}
+
+	// Comments without an ID were not posted
+	var comments []domain.Comment
+	for _, comment := range ticket.Comments {
+		if comment.ID != "" {
+			comments = append(comments, comment)
+		}
+	}
+	ticket.Comments = comments
+	return ticket
+}
+
+// pushLinks resolves links to local tickets by title and creates the links of
+// the pushed tickets in Jira. A ticket whose links could not be sent is
+// recorded without them, so the next push retries.
+func (s *PushService) pushLinks(ctx context.Context, tickets []domain.Ticket, pushed, statusUnsent map[int]bool, result *ProcessResult) {
+	keys := make(map[string]string) // Jira keys of local tickets by title
+	for _, ticket := range tickets {
+		if ticket.JiraID != "" {
+			keys[ticket.Title] = ticket.JiraID
+		}
+	}
+
+	for i := range tickets {
+		ticket := &tickets[i]
+		if !pushed[i] || len(ticket.Links) == 0 {
+			continue
+		}
+
+		if err := s.linkTicket(ctx, ticket, keys); err != nil {
+			errMsg := fmt.Sprintf("Failed to link ticket '%s' (%s): %v", ticket.Title, ticket.JiraID, err)
+			result.Errors = append(result.Errors, errMsg)
+			log.Println(errMsg)
+
+			s.stateManager.UpdateHash(recordedTicket(*ticket, !statusUnsent[i], false))
+			continue
+		}
+		s.stateManager.UpdateHash(recordedTicket(*ticket, !statusUnsent[i], true))
+	}
+}
+
+// remoteReadBatchSize is the number of keys per `key in (...)` search when
+// pushed tickets are read back
+const remoteReadBatchSize = 50
+
+// recordRemoteCopies reads the pushed tickets back from Jira and records
+// Jira's copy as their remote hash and merge base, keeping the local hash of
+// the pushed content, so the next pull neither takes values Jira normalized
+// (field defaults, whitespace, option names) for remote changes nor reports
+// them as conflicts with local edits. Tickets that cannot be read back keep
+// the pushed content.
+func (s *PushService) recordRemoteCopies(ctx context.Context, tickets []domain.Ticket, pushed map[int]bool) {
+	var keys []string
+	requested := make(map[string]bool)
+	for i, ticket := range tickets {
+		if pushed[i] && ticket.JiraID != "" {
+			keys = append(keys, fmt.Sprintf("%q", ticket.JiraID))
+			requested[ticket.JiraID] = true
+		}
+	}
+
+	for start := 0; start < len(keys) && ctx.Err() == nil; start += remoteReadBatchSize {
+		end := min(start+remoteReadBatchSize, len(keys))
+		remote, err := s.jiraClient.SearchTickets(ctx, "", fmt.Sprintf("key in (%s)", strings.Join(keys[start:end], ", ")))
+		if err != nil {
+			// Includes missing subtasks and capped results, which would give the wrong hash
+			log.Printf("Warning: Failed to read pushed tickets back from Jira: %v\n", err)
+			continue
+		}
+		for _, ticket := range remote {
+			if requested[ticket.JiraID] {
+				s.stateManager.UpdateRemote(ticket)
+			}
+		}
+	}
+}
+
+// linkTicket creates a ticket's links in Jira, replacing local title
+// references with the keys they resolve to
+func (s *PushService) linkTicket(ctx context.Context, ticket *domain.Ticket, keys map[string]string) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	resolved := make([]domain.Link, len(ticket.Links))
+	for i, link := range ticket.Links {
+		if link.Key == "" {
+			key, ok := keys[link.Title]
+			if !ok {
+				return fmt.Errorf("no ticket titled '%s' has a Jira ID", link.Title)
+			}
+			link = domain.Link{Type: link.Type, Key: key}
+		}
+		resolved[i] = link
+	}
+
+	if err := s.jiraClient.LinkIssues(ctx, ticket.JiraID, resolved); err != nil {
+		return err
+	}
+
+	ticket.Links = resolved
+	log.Printf("Linked ticket '%s' (%s) to %d issue(s)\n", ticket.Title, ticket.JiraID, len(resolved))
+	return nil
+}
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"testing"
 
@@ -52,7 +53,7 @@ func TestPushService_ProcessesAllAndReportsFailures(t *testing.T) {
 	pushService := NewPushService(mockRepo, mockJira, stateManager)
 
 	// Act: Run the push service without the --force flag
-	result, err := pushService.PushTickets("test.md", ProcessOptions{
+	result, err := pushService.PushTickets(context.Background(), "test.md", ProcessOptions{
 		ForcePartialUpload: false,
 	})
 
@@ -85,11 +86,11 @@ type MockRepositoryComprehensive struct {
 	tickets []domain.Ticket
 }
 
-func (m *MockRepositoryComprehensive) GetTickets(filePath string) ([]domain.Ticket, error) {
+func (m *MockRepositoryComprehensive) GetTickets(ctx context.Context, filePath string) ([]domain.Ticket, error) {
 	return m.tickets, nil
 }
 
-func (m *MockRepositoryComprehensive) SaveTickets(filePath string, tickets []domain.Ticket) error {
+func (m *MockRepositoryComprehensive) SaveTickets(ctx context.Context, filePath string, tickets []domain.Ticket) error {
 	return nil
 }
 
@@ -98,31 +99,31 @@ type MockJiraPortComprehensive struct {
 	failOnTicketID  string
 }
 
-func (m *MockJiraPortComprehensive) Authenticate() error {
+func (m *MockJiraPortComprehensive) Authenticate(ctx context.Context) error {
 	return nil
 }
 
-func (m *MockJiraPortComprehensive) CreateTask(task domain.Task, parentID string) (string, error) {
+func (m *MockJiraPortComprehensive) CreateTask(ctx context.Context, task domain.Task, parentID string) (string, error) {
 	return "", nil
 }
 
-func (m *MockJiraPortComprehensive) UpdateTask(task domain.Task) error {
+func (m *MockJiraPortComprehensive) UpdateTask(ctx context.Context, task domain.Task) error {
 	return nil
 }
 
-func (m *MockJiraPortComprehensive) GetProjectIssueTypes() (map[string][]string, error) {
+func (m *MockJiraPortComprehensive) GetProjectIssueTypes(ctx context.Context) (map[string][]string, error) {
 	return nil, nil
 }
 
-func (m *MockJiraPortComprehensive) GetIssueTypeFields(issueTypeName string) (map[string]interface{}, error) {
+func (m *MockJiraPortComprehensive) GetIssueTypeFields(ctx context.Context, issueTypeName string) (map[string]interface{}, error) {
 	return nil, nil
 }
 
-func (m *MockJiraPortComprehensive) CreateTicket(ticket domain.Ticket) (string, error) {
+func (m *MockJiraPortComprehensive) CreateTicket(ctx context.Context, ticket domain.Ticket) (string, error) {
 	return "", nil
 }
 
-func (m *MockJiraPortComprehensive) UpdateTicket(ticket domain.Ticket) error {
+func (m *MockJiraPortComprehensive) UpdateTicket(ctx context.Context, ticket domain.Ticket) error {
 	m.UpdateCallCount++
 	if ticket.JiraID == m.failOnTicketID {
 		return errors.New("simulated failure for TEST-2")
@@ -130,6 +131,34 @@ func (m *MockJiraPortComprehensive) UpdateTicket(ticket domain.Ticket) error {
 	return nil
 }
 
-func (m *MockJiraPortComprehensive) SearchTickets(projectKey string, jql string) ([]domain.Ticket, error) {
+func (m *MockJiraPortComprehensive) SearchTickets(ctx context.Context, projectKey string, jql string) ([]domain.Ticket, error) {
+	return nil, nil
+}
+
+func (m *MockJiraPortComprehensive) DiffTicket(ctx context.Context, ticket domain.Ticket) ([]domain.FieldChange, error) {
+	return nil, nil
+}
+
+func (m *MockJiraPortComprehensive) LinkIssues(ctx context.Context, issueKey string, links []domain.Link) error {
+	return nil
+}
+
+func (m *MockJiraPortComprehensive) TransitionIssue(ctx context.Context, issueKey string, status string) error {
+	return nil
+}
+
+func (m *MockJiraPortComprehensive) AddComment(ctx context.Context, issueKey string, comment domain.Comment) (domain.Comment, error) {
+	return comment, nil
+}
+
+func (m *MockJiraPortComprehensive) DiffTask(ctx context.Context, task domain.Task, parentID string) ([]domain.FieldChange, error) {
+	return nil, nil
+}
+
+func (m *MockJiraPortComprehensive) DiffLinks(ctx context.Context, issueKey string, links []domain.Link) ([]domain.Link, error) {
+	return links, nil
+}
+
+func (m *MockJiraPortComprehensive) TransitionPath(ctx context.Context, issueKey string, status string) ([]string, error) {
 	return nil, nil
 }
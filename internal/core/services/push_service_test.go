@@ -1,12 +1,19 @@
 package services
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
 	"testing"
 
 	"github.com/karolswdev/ticktr/internal/core/domain"
+	"github.com/karolswdev/ticktr/internal/core/ports"
 	"github.com/karolswdev/ticktr/internal/state"
 )
 
@@ -16,11 +23,11 @@ type MockRepository struct {
 	savedTickets []domain.Ticket
 }
 
-func (m *MockRepository) GetTickets(filepath string) ([]domain.Ticket, error) {
+func (m *MockRepository) GetTickets(ctx context.Context, filepath string) ([]domain.Ticket, error) {
 	return m.tickets, nil
 }
 
-func (m *MockRepository) SaveTickets(filepath string, tickets []domain.Ticket) error {
+func (m *MockRepository) SaveTickets(ctx context.Context, filepath string, tickets []domain.Ticket) error {
 	m.savedTickets = tickets
 	return nil
 }
@@ -33,46 +40,116 @@ type MockJiraPort struct {
 	CreateTaskCalled   int
 	LastCreatedTask    *domain.Task
 	LastUpdatedTask    *domain.Task
+	DiffTicketCalled   int
+	DiffTaskCalled     int
+	TicketChanges      map[string][]domain.FieldChange // Keyed by JiraID ("" for new tickets)
+	TaskChanges        map[string][]domain.FieldChange // Keyed by JiraID ("" for new tasks)
+	Links              map[string][]domain.Link        // Links sent, keyed by source issue
+	LinkErr            error                           // Returned by LinkIssues when set
+	Transitions        map[string]string               // Status requested, keyed by issue
+	TransitionErr      error                           // Returned by TransitionIssue when set
+	Comments           map[string][]domain.Comment     // Comments posted, keyed by issue
+	CommentErr         error                           // Returned by AddComment when set
+	TransitionPaths    map[string][]string             // Returned by TransitionPath, keyed by issue
 }
 
-func (m *MockJiraPort) Authenticate() error {
+func (m *MockJiraPort) Authenticate(ctx context.Context) error {
 	return nil
 }
 
-func (m *MockJiraPort) CreateTask(task domain.Task, parentID string) (string, error) {
+func (m *MockJiraPort) CreateTask(ctx context.Context, task domain.Task, parentID string) (string, error) {
 	m.CreateTaskCalled++
 	m.LastCreatedTask = &task
 	return "MOCK-TASK-123", nil
 }
 
-func (m *MockJiraPort) UpdateTask(task domain.Task) error {
+func (m *MockJiraPort) UpdateTask(ctx context.Context, task domain.Task) error {
 	m.UpdateTaskCalled++
 	m.LastUpdatedTask = &task
 	return nil
 }
 
-func (m *MockJiraPort) GetProjectIssueTypes() (map[string][]string, error) {
+func (m *MockJiraPort) GetProjectIssueTypes(ctx context.Context) (map[string][]string, error) {
 	return nil, nil
 }
 
-func (m *MockJiraPort) GetIssueTypeFields(issueTypeName string) (map[string]interface{}, error) {
+func (m *MockJiraPort) GetIssueTypeFields(ctx context.Context, issueTypeName string) (map[string]interface{}, error) {
 	return nil, nil
 }
 
-func (m *MockJiraPort) CreateTicket(ticket domain.Ticket) (string, error) {
+func (m *MockJiraPort) CreateTicket(ctx context.Context, ticket domain.Ticket) (string, error) {
 	m.CreateTicketCalled++
 	return fmt.Sprintf("MOCK-%d", m.CreateTicketCalled), nil
 }
 
-func (m *MockJiraPort) UpdateTicket(ticket domain.Ticket) error {
+func (m *MockJiraPort) UpdateTicket(ctx context.Context, ticket domain.Ticket) error {
 	m.UpdateTicketCalled++
 	return nil
 }
 
-func (m *MockJiraPort) SearchTickets(projectKey string, jql string) ([]domain.Ticket, error) {
+func (m *MockJiraPort) SearchTickets(ctx context.Context, projectKey string, jql string) ([]domain.Ticket, error) {
 	return []domain.Ticket{}, nil
 }
 
+func (m *MockJiraPort) DiffTicket(ctx context.Context, ticket domain.Ticket) ([]domain.FieldChange, error) {
+	m.DiffTicketCalled++
+	return m.TicketChanges[ticket.JiraID], nil
+}
+
+func (m *MockJiraPort) LinkIssues(ctx context.Context, issueKey string, links []domain.Link) error {
+	if m.LinkErr != nil {
+		return m.LinkErr
+	}
+	if m.Links == nil {
+		m.Links = make(map[string][]domain.Link)
+	}
+	m.Links[issueKey] = append(m.Links[issueKey], links...)
+	return nil
+}
+
+func (m *MockJiraPort) TransitionIssue(ctx context.Context, issueKey string, status string) error {
+	if m.TransitionErr != nil {
+		return m.TransitionErr
+	}
+	if m.Transitions == nil {
+		m.Transitions = make(map[string]string)
+	}
+	m.Transitions[issueKey] = status
+	return nil
+}
+
+func (m *MockJiraPort) AddComment(ctx context.Context, issueKey string, comment domain.Comment) (domain.Comment, error) {
+	if m.CommentErr != nil {
+		return domain.Comment{}, m.CommentErr
+	}
+	if m.Comments == nil {
+		m.Comments = make(map[string][]domain.Comment)
+	}
+	m.Comments[issueKey] = append(m.Comments[issueKey], comment)
+	comment.ID = fmt.Sprintf("%d", 10000+len(m.Comments[issueKey]))
+	comment.Author = "Mock User"
+	return comment, nil
+}
+
+func (m *MockJiraPort) DiffTask(ctx context.Context, task domain.Task, parentID string) ([]domain.FieldChange, error) {
+	m.DiffTaskCalled++
+	return m.TaskChanges[task.JiraID], nil
+}
+
+func (m *MockJiraPort) DiffLinks(ctx context.Context, issueKey string, links []domain.Link) ([]domain.Link, error) {
+	var missing []domain.Link
+	for _, link := range links {
+		if !slices.Contains(m.Links[issueKey], link) {
+			missing = append(missing, link)
+		}
+	}
+	return missing, nil
+}
+
+func (m *MockJiraPort) TransitionPath(ctx context.Context, issueKey string, status string) ([]string, error) {
+	return m.TransitionPaths[issueKey], nil
+}
+
 func TestPushService_SkipsUnchangedTickets(t *testing.T) {
 	// Create a temporary state file
 	tmpDir := t.TempDir()
@@ -131,7 +208,7 @@ func TestPushService_SkipsUnchangedTickets(t *testing.T) {
 	pushService := NewPushService(mockRepo, mockJira, stateManager)
 
 	// Run push
-	result, err := pushService.PushTickets("test.md", ProcessOptions{})
+	result, err := pushService.PushTickets(context.Background(), "test.md", ProcessOptions{})
 	if err != nil {
 		t.Fatalf("PushTickets failed: %v", err)
 	}
@@ -205,7 +282,7 @@ func TestPushService_FieldInheritance_ParentFields(t *testing.T) {
 	pushService := NewPushService(mockRepo, mockJira, stateManager)
 
 	// Run push
-	result, err := pushService.PushTickets("test.md", ProcessOptions{})
+	result, err := pushService.PushTickets(context.Background(), "test.md", ProcessOptions{})
 	if err != nil {
 		t.Fatalf("PushTickets failed: %v", err)
 	}
@@ -287,7 +364,7 @@ func TestPushService_FieldInheritance_TaskOverrides(t *testing.T) {
 	pushService := NewPushService(mockRepo, mockJira, stateManager)
 
 	// Run push
-	result, err := pushService.PushTickets("test.md", ProcessOptions{})
+	result, err := pushService.PushTickets(context.Background(), "test.md", ProcessOptions{})
 	if err != nil {
 		t.Fatalf("PushTickets failed: %v", err)
 	}
@@ -329,3 +406,471 @@ func TestPushService_FieldInheritance_TaskOverrides(t *testing.T) {
 	// Clean up
 	os.Remove(stateFile)
 }
+
+// cancellingJiraPort cancels the push context once the first ticket has been created
+type cancellingJiraPort struct {
+	MockJiraPort
+	cancel context.CancelFunc
+}
+
+func (m *cancellingJiraPort) CreateTicket(ctx context.Context, ticket domain.Ticket) (string, error) {
+	id, err := m.MockJiraPort.CreateTicket(ctx, ticket)
+	m.cancel()
+	return id, err
+}
+
+// TestPushService_CancelledContextSavesCompletedWork verifies an interrupted push stops sending
+// tickets but still writes the Jira IDs and state of the tickets already created
+func TestPushService_CancelledContextSavesCompletedWork(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "test.state")
+	stateManager := state.NewStateManager(stateFile)
+
+	mockRepo := &MockRepository{
+		tickets: []domain.Ticket{
+			{Title: "First"},
+			{Title: "Second"},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mockJira := &cancellingJiraPort{cancel: cancel}
+
+	pushService := NewPushService(mockRepo, mockJira, stateManager)
+	result, err := pushService.PushTickets(ctx, "test.md", ProcessOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("Expected context.Canceled, got: %v", err)
+	}
+
+	if mockJira.CreateTicketCalled != 1 || result.TicketsCreated != 1 {
+		t.Errorf("Expected push to stop after the first ticket, got %d create call(s)", mockJira.CreateTicketCalled)
+	}
+
+	if len(mockRepo.savedTickets) != 2 || mockRepo.savedTickets[0].JiraID != "MOCK-1" || mockRepo.savedTickets[1].JiraID != "" {
+		t.Errorf("Expected the file to be saved with only the first ticket's ID, got %+v", mockRepo.savedTickets)
+	}
+
+	saved := state.NewStateManager(stateFile)
+	if err := saved.Load(); err != nil {
+		t.Fatalf("Failed to load saved state: %v", err)
+	}
+	if _, exists := saved.GetStoredState("MOCK-1"); !exists {
+		t.Error("Expected the created ticket to be recorded in the state file")
+	}
+}
+
+func TestPushService_LinksResolveLocalTitles(t *testing.T) {
+	stateManager := state.NewStateManager(filepath.Join(t.TempDir(), ".ticketr.state"))
+
+	// The first ticket links to one created after it in the same file
+	mockRepo := &MockRepository{
+		tickets: []domain.Ticket{
+			{
+				Title: "Checkout page",
+				Links: []domain.Link{
+					{Type: "is blocked by", Title: "Payment gateway"},
+					{Type: "relates to", Key: "EXT-7"},
+				},
+			},
+			{Title: "Payment gateway"},
+		},
+	}
+	mockJira := &MockJiraPort{}
+
+	pushService := NewPushService(mockRepo, mockJira, stateManager)
+	if _, err := pushService.PushTickets(context.Background(), "test.md", ProcessOptions{}); err != nil {
+		t.Fatalf("PushTickets failed: %v", err)
+	}
+
+	sent := mockJira.Links["MOCK-1"]
+	if len(sent) != 2 || sent[0] != (domain.Link{Type: "is blocked by", Key: "MOCK-2"}) || sent[1] != (domain.Link{Type: "relates to", Key: "EXT-7"}) {
+		t.Errorf("Unexpected links sent: %+v", sent)
+	}
+
+	// The saved file references the new key, and the state matches it
+	saved := mockRepo.savedTickets[0]
+	if saved.Links[0].Key != "MOCK-2" || saved.Links[0].Title != "" {
+		t.Errorf("Expected the saved link to use the new key, got %+v", saved.Links[0])
+	}
+	if stateManager.HasChanged(saved) {
+		t.Error("Expected the saved ticket to match the stored state")
+	}
+}
+
+func TestPushService_FailedLinksAreRetried(t *testing.T) {
+	stateManager := state.NewStateManager(filepath.Join(t.TempDir(), ".ticketr.state"))
+
+	mockRepo := &MockRepository{
+		tickets: []domain.Ticket{
+			{Title: "Linked", JiraID: "TICKET-1", Links: []domain.Link{{Type: "blocks", Key: "TICKET-2"}}},
+			{Title: "Dangling", JiraID: "TICKET-3", Links: []domain.Link{{Type: "blocks", Title: "Missing"}}},
+		},
+	}
+	mockJira := &MockJiraPort{LinkErr: errors.New("unknown link type")}
+
+	pushService := NewPushService(mockRepo, mockJira, stateManager)
+	result, err := pushService.PushTickets(context.Background(), "test.md", ProcessOptions{})
+	if err == nil {
+		t.Fatal("Expected an error for the failed links")
+	}
+	if len(result.Errors) != 2 {
+		t.Errorf("Expected 2 link errors, got %v", result.Errors)
+	}
+
+	// Both tickets must be pushed again so their links are retried
+	for _, ticket := range mockRepo.savedTickets {
+		if !stateManager.HasChanged(ticket) {
+			t.Errorf("Expected '%s' to still count as changed", ticket.Title)
+		}
+	}
+}
+
+func TestPushService_TransitionsToMarkdownStatus(t *testing.T) {
+	stateManager := state.NewStateManager(filepath.Join(t.TempDir(), ".ticketr.state"))
+
+	mockRepo := &MockRepository{
+		tickets: []domain.Ticket{
+			{
+				Title:  "Onboarding emails",
+				JiraID: "TICKET-1",
+				Status: "In Review",
+				Tasks: []domain.Task{
+					{Title: "Copy", JiraID: "TASK-1", Status: "Done"},
+					{Title: "Schedule"},
+				},
+			},
+			{Title: "No status"},
+		},
+	}
+	mockJira := &MockJiraPort{}
+
+	pushService := NewPushService(mockRepo, mockJira, stateManager)
+	if _, err := pushService.PushTickets(context.Background(), "test.md", ProcessOptions{}); err != nil {
+		t.Fatalf("PushTickets failed: %v", err)
+	}
+
+	expected := map[string]string{"TICKET-1": "In Review", "TASK-1": "Done"}
+	if len(mockJira.Transitions) != len(expected) {
+		t.Fatalf("Expected transitions %v, got %v", expected, mockJira.Transitions)
+	}
+	for key, status := range expected {
+		if mockJira.Transitions[key] != status {
+			t.Errorf("Expected %s to move to '%s', got '%s'", key, status, mockJira.Transitions[key])
+		}
+	}
+}
+
+func TestPushService_FailedTransitionsAreRetried(t *testing.T) {
+	stateManager := state.NewStateManager(filepath.Join(t.TempDir(), ".ticketr.state"))
+
+	mockRepo := &MockRepository{
+		tickets: []domain.Ticket{
+			{Title: "Closed", JiraID: "TICKET-1", Status: "Done"},
+			{Title: "Task closed", JiraID: "TICKET-2", Tasks: []domain.Task{{Title: "Step", JiraID: "TASK-1", Status: "Done"}}},
+		},
+	}
+	mockJira := &MockJiraPort{TransitionErr: errors.New("no workflow path")}
+
+	pushService := NewPushService(mockRepo, mockJira, stateManager)
+	result, err := pushService.PushTickets(context.Background(), "test.md", ProcessOptions{})
+	if err == nil {
+		t.Fatal("Expected an error for the failed transitions")
+	}
+	if len(result.Errors) != 2 || result.TicketsUpdated != 2 {
+		t.Errorf("Expected 2 updated tickets and 2 transition errors, got %+v", result)
+	}
+
+	// The status is kept in the file, and both tickets are pushed again
+	for _, ticket := range mockRepo.savedTickets {
+		if !stateManager.HasChanged(ticket) {
+			t.Errorf("Expected '%s' to still count as changed", ticket.Title)
+		}
+	}
+	if mockRepo.savedTickets[0].Status != "Done" {
+		t.Errorf("Expected the local status to be kept, got %q", mockRepo.savedTickets[0].Status)
+	}
+}
+
+func TestPushService_PostsOnlyUnsentComments(t *testing.T) {
+	stateManager := state.NewStateManager(filepath.Join(t.TempDir(), ".ticketr.state"))
+
+	mockRepo := &MockRepository{
+		tickets: []domain.Ticket{
+			{
+				Title:  "Refunds",
+				JiraID: "TICKET-1",
+				Comments: []domain.Comment{
+					{ID: "9", Author: "Jane Doe", Created: "2024-01-02T10:00:00.000+0000", Body: "Pulled earlier"},
+					{Body: "New question"},
+				},
+			},
+		},
+	}
+	mockJira := &MockJiraPort{}
+
+	pushService := NewPushService(mockRepo, mockJira, stateManager)
+	if _, err := pushService.PushTickets(context.Background(), "test.md", ProcessOptions{}); err != nil {
+		t.Fatalf("PushTickets failed: %v", err)
+	}
+
+	posted := mockJira.Comments["TICKET-1"]
+	if len(posted) != 1 || posted[0].Body != "New question" {
+		t.Fatalf("Expected only the new comment to be posted, got %+v", posted)
+	}
+
+	// The saved comment carries its Jira ID, so the next push does not post it again
+	saved := mockRepo.savedTickets[0]
+	if saved.Comments[1].ID == "" || saved.Comments[1].Author != "Mock User" {
+		t.Errorf("Expected the saved comment to be marked as sent, got %+v", saved.Comments[1])
+	}
+	if stateManager.HasChanged(saved) {
+		t.Error("Expected the saved ticket to match the stored state")
+	}
+}
+
+func TestPushService_FailedCommentsAreRetried(t *testing.T) {
+	stateManager := state.NewStateManager(filepath.Join(t.TempDir(), ".ticketr.state"))
+
+	mockRepo := &MockRepository{
+		tickets: []domain.Ticket{
+			{Title: "Refunds", JiraID: "TICKET-1", Comments: []domain.Comment{{Body: "New question"}}},
+		},
+	}
+	mockJira := &MockJiraPort{CommentErr: errors.New("forbidden")}
+
+	pushService := NewPushService(mockRepo, mockJira, stateManager)
+	if _, err := pushService.PushTickets(context.Background(), "test.md", ProcessOptions{}); err == nil {
+		t.Fatal("Expected an error for the failed comment")
+	}
+
+	saved := mockRepo.savedTickets[0]
+	if saved.Comments[0].ID != "" {
+		t.Errorf("Expected the comment to stay unsent, got %+v", saved.Comments[0])
+	}
+	if !stateManager.HasChanged(saved) {
+		t.Error("Expected the ticket to still count as changed")
+	}
+}
+
+// filesRepository serves tickets by file path and is safe for concurrent use
+type filesRepository struct {
+	mu    sync.Mutex
+	files map[string][]domain.Ticket
+	saved map[string][]domain.Ticket
+}
+
+func (r *filesRepository) GetTickets(ctx context.Context, filepath string) ([]domain.Ticket, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	tickets, ok := r.files[filepath]
+	if !ok {
+		return nil, ports.ErrFileNotFound
+	}
+	return append([]domain.Ticket(nil), tickets...), nil
+}
+
+func (r *filesRepository) SaveTickets(ctx context.Context, filepath string, tickets []domain.Ticket) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.saved[filepath] = tickets
+	return nil
+}
+
+// syncJiraPort serialises ticket creates and updates on a MockJiraPort
+type syncJiraPort struct {
+	*MockJiraPort
+	mu sync.Mutex
+}
+
+func (m *syncJiraPort) CreateTicket(ctx context.Context, ticket domain.Ticket) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.MockJiraPort.CreateTicket(ctx, ticket)
+}
+
+func (m *syncJiraPort) UpdateTicket(ctx context.Context, ticket domain.Ticket) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.MockJiraPort.UpdateTicket(ctx, ticket)
+}
+
+func TestPushService_PushFilesSharesState(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), ".ticketr.state")
+	repo := &filesRepository{
+		files: map[string][]domain.Ticket{
+			"backlog/auth.md":    {{Title: "Login"}, {Title: "Logout"}},
+			"backlog/billing.md": {{Title: "Invoices", JiraID: "PROJ-9"}},
+		},
+		saved: make(map[string][]domain.Ticket),
+	}
+	jira := &syncJiraPort{MockJiraPort: &MockJiraPort{}}
+	service := NewPushService(repo, jira, state.NewStateManager(stateFile))
+
+	results := service.PushFiles(context.Background(), []string{"backlog/auth.md", "backlog/billing.md", "backlog/missing.md"}, ProcessOptions{}, 2)
+
+	if len(results) != 3 {
+		t.Fatalf("Expected 3 results, got %d", len(results))
+	}
+	if results[0].File != "backlog/auth.md" || results[0].Err != nil || results[0].Result.TicketsCreated != 2 {
+		t.Errorf("Unexpected result for auth.md: %+v", results[0])
+	}
+	if results[1].Err != nil || results[1].Result.TicketsUpdated != 1 {
+		t.Errorf("Unexpected result for billing.md: %+v", results[1])
+	}
+	if results[2].Err == nil || results[2].Result != nil {
+		t.Errorf("Expected the missing file to fail, got %+v", results[2])
+	}
+	if len(repo.saved["backlog/auth.md"]) != 2 || repo.saved["backlog/auth.md"][0].JiraID == "" {
+		t.Errorf("Expected auth.md to be saved with Jira IDs, got %+v", repo.saved["backlog/auth.md"])
+	}
+
+	// Both files' tickets are recorded in the one state file
+	reloaded := state.NewStateManager(stateFile)
+	if err := reloaded.Load(); err != nil {
+		t.Fatalf("Failed to load state: %v", err)
+	}
+	for _, key := range []string{repo.saved["backlog/auth.md"][0].JiraID, repo.saved["backlog/auth.md"][1].JiraID, "PROJ-9"} {
+		if _, ok := reloaded.GetStoredState(key); !ok {
+			t.Errorf("Expected %s in the state file", key)
+		}
+	}
+}
+
+// normalizingJiraPort stores pushed tickets the way Jira does, with trimmed
+// descriptions, capitalised option values and a default priority, and serves
+// them to searches by key
+type normalizingJiraPort struct {
+	*MockJiraPort
+	issues   map[string]domain.Ticket
+	searches []string
+	nextID   int
+}
+
+func (m *normalizingJiraPort) store(ticket domain.Ticket) {
+	ticket.Description = strings.TrimSpace(ticket.Description)
+	fields := map[string]string{"Priority": "Medium"}
+	for name, value := range ticket.CustomFields {
+		fields[name] = strings.ToUpper(value[:1]) + value[1:]
+	}
+	ticket.CustomFields = fields
+	ticket.Tasks = []domain.Task{}
+	m.issues[ticket.JiraID] = ticket
+}
+
+func (m *normalizingJiraPort) CreateTicket(ctx context.Context, ticket domain.Ticket) (string, error) {
+	m.nextID++
+	ticket.JiraID = fmt.Sprintf("PROJ-%d", m.nextID)
+	m.store(ticket)
+	return ticket.JiraID, nil
+}
+
+func (m *normalizingJiraPort) UpdateTicket(ctx context.Context, ticket domain.Ticket) error {
+	m.store(ticket)
+	return nil
+}
+
+func (m *normalizingJiraPort) SearchTickets(ctx context.Context, projectKey string, jql string) ([]domain.Ticket, error) {
+	m.searches = append(m.searches, jql)
+	var tickets []domain.Ticket
+	for key, ticket := range m.issues {
+		if projectKey != "" || strings.Contains(jql, fmt.Sprintf("%q", key)) {
+			tickets = append(tickets, ticket)
+		}
+	}
+	return tickets, nil
+}
+
+func TestPushService_RecordsRemoteHashAsJiraStoresIt(t *testing.T) {
+	stateManager := state.NewStateManager(filepath.Join(t.TempDir(), ".ticketr.state"))
+	repo := &filesRepository{
+		files: map[string][]domain.Ticket{"backlog.md": {
+			{Title: "Login", Description: "Users log in.\n", CustomFields: map[string]string{"Severity": "high"}},
+			{Title: "Logout", Description: "Users log out."},
+		}},
+		saved: make(map[string][]domain.Ticket),
+	}
+	jira := &normalizingJiraPort{MockJiraPort: &MockJiraPort{}, issues: make(map[string]domain.Ticket)}
+
+	if _, err := NewPushService(repo, jira, stateManager).PushTickets(context.Background(), "backlog.md", ProcessOptions{}); err != nil {
+		t.Fatalf("PushTickets failed: %v", err)
+	}
+
+	if len(jira.searches) != 1 || !strings.HasPrefix(jira.searches[0], "key in (") {
+		t.Fatalf("Expected one batched read-back, got %v", jira.searches)
+	}
+	for _, ticket := range repo.saved["backlog.md"] {
+		stored, _ := stateManager.GetStoredState(ticket.JiraID)
+		if stored.RemoteHash != stateManager.CalculateHash(jira.issues[ticket.JiraID]) {
+			t.Errorf("%s: expected the hash of Jira's copy as the remote hash", ticket.JiraID)
+		}
+		if stored.LocalHash != stateManager.CalculateHash(ticket) {
+			t.Errorf("%s: expected the hash of the pushed Markdown as the local hash", ticket.JiraID)
+		}
+		if stored.LocalHash == stored.RemoteHash {
+			t.Errorf("%s: expected Jira's normalized copy to hash differently", ticket.JiraID)
+		}
+		if !reflect.DeepEqual(stored.Base, state.NewSnapshot(jira.issues[ticket.JiraID])) {
+			t.Errorf("%s: expected Jira's copy as the merge base, got %+v", ticket.JiraID, stored.Base)
+		}
+	}
+
+	// Pulling right after pushing finds nothing changed on either side
+	pullRepo := &MockRepositoryForPull{tickets: repo.saved["backlog.md"]}
+	result, err := NewPullService(jira, pullRepo, stateManager).Pull(context.Background(), "backlog.md", PullOptions{ProjectKey: "PROJ"})
+	if err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	if result.TicketsUpdated != 0 || result.TicketsSkipped != 2 || len(result.Conflicts) != 0 {
+		t.Errorf("Expected both tickets to be unchanged, got %+v", result)
+	}
+}
+
+func TestPushService_NormalizedValuesDoNotConflictOnPull(t *testing.T) {
+	stateManager := state.NewStateManager(filepath.Join(t.TempDir(), ".ticketr.state"))
+	repo := &filesRepository{
+		files: map[string][]domain.Ticket{"backlog.md": {
+			{Title: "Login", Description: "Users log in.\n", CustomFields: map[string]string{"Severity": "high"}},
+		}},
+		saved: make(map[string][]domain.Ticket),
+	}
+	jira := &normalizingJiraPort{MockJiraPort: &MockJiraPort{}, issues: make(map[string]domain.Ticket)}
+
+	if _, err := NewPushService(repo, jira, stateManager).PushTickets(context.Background(), "backlog.md", ProcessOptions{}); err != nil {
+		t.Fatalf("PushTickets failed: %v", err)
+	}
+
+	// The description is edited locally and the title in Jira; the values Jira
+	// normalized are on neither side's list of changes
+	local := repo.saved["backlog.md"][0]
+	local.Description = "Users sign in.\n"
+	remote := jira.issues[local.JiraID]
+	remote.Title = "Sign in"
+	jira.issues[local.JiraID] = remote
+
+	pullRepo := &MockRepositoryForPull{tickets: []domain.Ticket{local}}
+	result, err := NewPullService(jira, pullRepo, stateManager).Pull(context.Background(), "backlog.md", PullOptions{ProjectKey: "PROJ"})
+	if err != nil {
+		t.Fatalf("Pull failed: %v", err)
+	}
+	if len(result.Conflicts) != 0 || len(result.FieldConflicts) != 0 || result.TicketsMerged != 1 {
+		t.Fatalf("Expected a clean merge, got %+v", result)
+	}
+	merged := pullRepo.saveTickets[0]
+	if merged.Title != "Sign in" || merged.Description != "Users sign in.\n" {
+		t.Errorf("Expected Jira's title and the local description, got %q and %q", merged.Title, merged.Description)
+	}
+}
+
+func TestPushService_KeepsPushedHashWhenReadBackFails(t *testing.T) {
+	stateManager := state.NewStateManager(filepath.Join(t.TempDir(), ".ticketr.state"))
+	ticket := domain.Ticket{JiraID: "PROJ-1", Title: "Login"}
+	repo := &MockRepository{tickets: []domain.Ticket{ticket}}
+	jira := &MockJiraPortForPull{searchError: errors.New("search failed")}
+
+	if _, err := NewPushService(repo, jira, stateManager).PushTickets(context.Background(), "backlog.md", ProcessOptions{}); err != nil {
+		t.Fatalf("A failed read-back should not fail the push: %v", err)
+	}
+	if stored, _ := stateManager.GetStoredState("PROJ-1"); stored.RemoteHash != stateManager.CalculateHash(ticket) {
+		t.Error("Expected the hash of the pushed ticket as the remote hash")
+	}
+}
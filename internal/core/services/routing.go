@@ -0,0 +1,157 @@
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"text/template"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+)
+
+// Attributes a routing rule can group tickets by
+const (
+	RouteByEpic      = "epic"
+	RouteByComponent = "component"
+	RouteByLabel     = "label"
+	RouteByType      = "type"
+)
+
+// RoutingRule sends pulled tickets that have a value for By to the file its
+// template names, e.g. By "epic" with File "backlog/{{.Epic}}.md"
+type RoutingRule struct {
+	By   string // RouteByEpic, RouteByComponent, RouteByLabel or RouteByType
+	File string // text/template over RouteData
+}
+
+// RouteData is what a routing rule's file template can use. Values are made
+// safe for file names.
+type RouteData struct {
+	Key       string
+	Epic      string            // Epic Link, or the parent on Jira Cloud
+	Component string            // First of the Components
+	Label     string            // First of the Labels
+	Type      string            // Issue type
+	Fields    map[string]string // Every field, by name
+}
+
+// compiledRoutingRule is a RoutingRule with its template parsed
+type compiledRoutingRule struct {
+	RoutingRule
+	template *template.Template
+}
+
+// Router picks the Markdown file each pulled ticket is written to: the file
+// of the first rule the ticket has a value for, or the fallback file
+type Router struct {
+	rules    []compiledRoutingRule
+	fallback string
+}
+
+// NewRouter creates a router from rules, sending tickets no rule applies to
+// to fallback. It fails on an unknown attribute or an invalid template.
+func NewRouter(rules []RoutingRule, fallback string) (*Router, error) {
+	router := &Router{fallback: filepath.Clean(fallback)}
+	for i, rule := range rules {
+		switch rule.By {
+		case RouteByEpic, RouteByComponent, RouteByLabel, RouteByType:
+		default:
+			return nil, fmt.Errorf("routing rule %d: unknown attribute '%s' (use epic, component, label or type)", i+1, rule.By)
+		}
+		if rule.File == "" {
+			return nil, fmt.Errorf("routing rule %d: no file template", i+1)
+		}
+		parsed, err := template.New(rule.File).Option("missingkey=zero").Parse(rule.File)
+		if err != nil {
+			return nil, fmt.Errorf("routing rule %d: invalid file template: %w", i+1, err)
+		}
+		router.rules = append(router.rules, compiledRoutingRule{RoutingRule: rule, template: parsed})
+	}
+	return router, nil
+}
+
+// Route returns the file a ticket belongs in
+func (r *Router) Route(ticket domain.Ticket) (string, error) {
+	data := routeData(ticket)
+	for _, rule := range r.rules {
+		var value string
+		switch rule.By {
+		case RouteByEpic:
+			value = data.Epic
+		case RouteByComponent:
+			value = data.Component
+		case RouteByLabel:
+			value = data.Label
+		case RouteByType:
+			value = data.Type
+		}
+		if value == "" {
+			continue
+		}
+
+		var file bytes.Buffer
+		if err := rule.template.Execute(&file, data); err != nil {
+			return "", fmt.Errorf("failed to route %s: %w", ticket.JiraID, err)
+		}
+		if strings.TrimSpace(file.String()) == "" {
+			return "", fmt.Errorf("failed to route %s: template '%s' gave no file name", ticket.JiraID, rule.File)
+		}
+		return filepath.Clean(file.String()), nil
+	}
+	return r.fallback, nil
+}
+
+// templateAction matches the actions of a file template
+var templateAction = regexp.MustCompile(`\{\{.*?\}\}`)
+
+// Patterns returns the fallback file and, for each rule, a glob matching the
+// files it can route to, so tickets already in one of them can be found
+func (r *Router) Patterns() []string {
+	patterns := []string{r.fallback}
+	for _, rule := range r.rules {
+		patterns = append(patterns, templateAction.ReplaceAllString(rule.File, "*"))
+	}
+	return patterns
+}
+
+// routeData collects the values a file template can use from a ticket
+func routeData(ticket domain.Ticket) RouteData {
+	fields := make(map[string]string, len(ticket.CustomFields))
+	for name, value := range ticket.CustomFields {
+		fields[name] = fileNameSafe(value)
+	}
+
+	epic := fields["Epic Link"]
+	if epic == "" {
+		epic = fields["Parent"]
+	}
+	return RouteData{
+		Key:       fileNameSafe(ticket.JiraID),
+		Epic:      epic,
+		Component: firstValue(fields["Components"]),
+		Label:     firstValue(fields["Labels"]),
+		Type:      fields["Type"],
+		Fields:    fields,
+	}
+}
+
+// firstValue returns the first of a comma-separated list of values
+func firstValue(values string) string {
+	first, _, _ := strings.Cut(values, ",")
+	return strings.TrimSpace(first)
+}
+
+// unsafeFileNameChars are replaced in values used in file names
+var unsafeFileNameChars = strings.NewReplacer("/", "-", `\`, "-", ":", "-", "*", "-", "?", "-", `"`, "-", "<", "-", ">", "-", "|", "-")
+
+// fileNameSafe makes a value usable as part of a file name, so a value such
+// as "Payments/Billing" cannot point into another directory
+func fileNameSafe(value string) string {
+	value = strings.TrimSpace(unsafeFileNameChars.Replace(value))
+	if value == "." || value == ".." {
+		return strings.Repeat("-", len(value))
+	}
+	return value
+}
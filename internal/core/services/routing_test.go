@@ -0,0 +1,129 @@
+package services
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+	"github.com/karolswdev/ticktr/internal/state"
+)
+
+func TestRouter_Route(t *testing.T) {
+	router, err := NewRouter([]RoutingRule{
+		{By: RouteByEpic, File: "backlog/{{.Epic}}.md"},
+		{By: RouteByComponent, File: "backlog/components/{{.Component}}.md"},
+		{By: RouteByType, File: "backlog/{{.Type}}s.md"},
+	}, "backlog/unsorted.md")
+	if err != nil {
+		t.Fatalf("NewRouter returned error: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		fields map[string]string
+		want   string
+	}{
+		{"epic link", map[string]string{"Epic Link": "PROJ-1", "Type": "Story"}, "backlog/PROJ-1.md"},
+		{"cloud parent", map[string]string{"Parent": "PROJ-2", "Type": "Story"}, "backlog/PROJ-2.md"},
+		{"first component", map[string]string{"Components": "Payments/Billing, Web", "Type": "Story"}, "backlog/components/Payments-Billing.md"},
+		{"issue type", map[string]string{"Type": "Bug"}, "backlog/Bugs.md"},
+		{"fallback", map[string]string{}, "backlog/unsorted.md"},
+	}
+	for _, tt := range tests {
+		got, err := router.Route(domain.Ticket{JiraID: "PROJ-9", CustomFields: tt.fields})
+		if err != nil || got != tt.want {
+			t.Errorf("%s: expected %s, got %s (%v)", tt.name, tt.want, got, err)
+		}
+	}
+
+	want := []string{"backlog/unsorted.md", "backlog/*.md", "backlog/components/*.md", "backlog/*s.md"}
+	if got := router.Patterns(); !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected patterns %v, got %v", want, got)
+	}
+}
+
+func TestNewRouter_Invalid(t *testing.T) {
+	if _, err := NewRouter([]RoutingRule{{By: "sprint", File: "{{.Key}}.md"}}, "out.md"); err == nil {
+		t.Error("Expected an error for an unknown attribute")
+	}
+	if _, err := NewRouter([]RoutingRule{{By: RouteByEpic, File: "{{.Epic"}}, "out.md"); err == nil {
+		t.Error("Expected an error for an invalid template")
+	}
+}
+
+func TestPullService_PullRouted(t *testing.T) {
+	repo := &filesRepository{
+		files: map[string][]domain.Ticket{
+			// PROJ-2 was moved to another epic in Jira but stays where it is
+			"backlog/PROJ-1.md": {{Title: "Login", JiraID: "PROJ-2", CustomFields: map[string]string{"Epic Link": "PROJ-1"}}},
+		},
+		saved: make(map[string][]domain.Ticket),
+	}
+	jira := &MockJiraPortForPull{searchResult: []domain.Ticket{
+		{Title: "Login", JiraID: "PROJ-2", CustomFields: map[string]string{"Epic Link": "PROJ-5"}},
+		{Title: "Invoices", JiraID: "PROJ-3", CustomFields: map[string]string{"Epic Link": "PROJ-5"}},
+		{Title: "Stray", JiraID: "PROJ-4", CustomFields: map[string]string{}},
+	}}
+	router, err := NewRouter([]RoutingRule{{By: RouteByEpic, File: "backlog/{{.Epic}}.md"}}, "pulled.md")
+	if err != nil {
+		t.Fatalf("NewRouter returned error: %v", err)
+	}
+	service := NewPullService(jira, repo, state.NewStateManager(filepath.Join(t.TempDir(), ".ticketr.state")))
+
+	result, err := service.PullRouted(context.Background(), router, []string{"pulled.md", "backlog/PROJ-1.md"}, PullOptions{ProjectKey: "PROJ"})
+	if err != nil {
+		t.Fatalf("PullRouted returned error: %v", err)
+	}
+
+	wantFiles := []string{"pulled.md", "backlog/PROJ-1.md", "backlog/PROJ-5.md"}
+	if !reflect.DeepEqual(result.Files, wantFiles) {
+		t.Errorf("Expected files %v, got %v", wantFiles, result.Files)
+	}
+	titles := func(file string) []string {
+		var titles []string
+		for _, ticket := range repo.saved[file] {
+			titles = append(titles, ticket.Title)
+		}
+		return titles
+	}
+	if got := titles("backlog/PROJ-1.md"); !reflect.DeepEqual(got, []string{"Login"}) {
+		t.Errorf("Expected Login to stay in PROJ-1.md, got %v", got)
+	}
+	if got := titles("backlog/PROJ-5.md"); !reflect.DeepEqual(got, []string{"Invoices"}) {
+		t.Errorf("Expected Invoices in a new PROJ-5.md, got %v", got)
+	}
+	if got := titles("pulled.md"); !reflect.DeepEqual(got, []string{"Stray"}) {
+		t.Errorf("Expected Stray in the fallback file, got %v", got)
+	}
+	if result.TicketsPulled != 2 || result.TicketsUpdated != 1 {
+		t.Errorf("Unexpected counts: %+v", result)
+	}
+}
+
+func TestPullService_PullRoutedKeepsLocalOnlyTickets(t *testing.T) {
+	repo := &filesRepository{
+		files: map[string][]domain.Ticket{
+			"pulled.md": {{Title: "Draft"}, {Title: "Archived", JiraID: "PROJ-8"}, {Title: "Second draft"}},
+		},
+		saved: make(map[string][]domain.Ticket),
+	}
+	jira := &MockJiraPortForPull{searchResult: []domain.Ticket{{Title: "New", JiraID: "PROJ-9"}}}
+	service := NewPullService(jira, repo, state.NewStateManager(filepath.Join(t.TempDir(), ".ticketr.state")))
+
+	if _, err := service.Pull(context.Background(), "pulled.md", PullOptions{ProjectKey: "PROJ"}); err != nil {
+		t.Fatalf("Pull returned error: %v", err)
+	}
+
+	// Drafts and tickets Jira did not return are written once each, in file order
+	saved := repo.saved["pulled.md"]
+	var titles []string
+	for _, ticket := range saved {
+		titles = append(titles, ticket.Title)
+	}
+	if want := "New, Draft, Archived, Second draft"; strings.Join(titles, ", ") != want {
+		t.Errorf("Expected %s, got %s", want, strings.Join(titles, ", "))
+	}
+}
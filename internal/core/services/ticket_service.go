@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"log"
 
@@ -65,8 +66,11 @@ func (s *TicketService) ProcessTicketsWithOptions(filePath string, options Proce
 		Errors: []string{},
 	}
 
+	// The deprecated service is not cancellable; PushService takes a context
+	ctx := context.Background()
+
 	// Read tickets from the file
-	tickets, err := s.repository.GetTickets(filePath)
+	tickets, err := s.repository.GetTickets(ctx, filePath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read tickets from file: %w", err)
 	}
@@ -78,7 +82,7 @@ func (s *TicketService) ProcessTicketsWithOptions(filePath string, options Proce
 		// Check if ticket needs to be created or updated
 		if ticket.JiraID != "" {
 			// Update existing ticket in Jira
-			err := s.jiraClient.UpdateTicket(*ticket)
+			err := s.jiraClient.UpdateTicket(ctx, *ticket)
 			if err != nil {
 				errMsg := fmt.Sprintf("Failed to update ticket '%s' (%s): %v", ticket.Title, ticket.JiraID, err)
 				result.Errors = append(result.Errors, errMsg)
@@ -89,7 +93,7 @@ func (s *TicketService) ProcessTicketsWithOptions(filePath string, options Proce
 			log.Printf("Updated ticket '%s' with Jira ID: %s\n", ticket.Title, ticket.JiraID)
 		} else {
 			// Create new ticket in Jira
-			jiraID, err := s.jiraClient.CreateTicket(*ticket)
+			jiraID, err := s.jiraClient.CreateTicket(ctx, *ticket)
 			if err != nil {
 				errMsg := fmt.Sprintf("Failed to create ticket '%s': %v", ticket.Title, err)
 				result.Errors = append(result.Errors, errMsg)
@@ -117,7 +121,7 @@ func (s *TicketService) ProcessTicketsWithOptions(filePath string, options Proce
 			// Check if task needs to be created or updated
 			if task.JiraID != "" {
 				// Update existing task in Jira
-				err := s.jiraClient.UpdateTask(taskWithFields)
+				err := s.jiraClient.UpdateTask(ctx, taskWithFields)
 				if err != nil {
 					errMsg := fmt.Sprintf("  Failed to update task '%s' (%s): %v", task.Title, task.JiraID, err)
 					result.Errors = append(result.Errors, errMsg)
@@ -135,7 +139,7 @@ func (s *TicketService) ProcessTicketsWithOptions(filePath string, options Proce
 					continue
 				}
 
-				taskJiraID, err := s.jiraClient.CreateTask(taskWithFields, ticket.JiraID)
+				taskJiraID, err := s.jiraClient.CreateTask(ctx, taskWithFields, ticket.JiraID)
 				if err != nil {
 					errMsg := fmt.Sprintf("  Failed to create task '%s': %v", task.Title, err)
 					result.Errors = append(result.Errors, errMsg)
@@ -152,7 +156,7 @@ func (s *TicketService) ProcessTicketsWithOptions(filePath string, options Proce
 	}
 
 	// Save the updated tickets back to the file
-	err = s.repository.SaveTickets(filePath, tickets)
+	err = s.repository.SaveTickets(ctx, filePath, tickets)
 	if err != nil {
 		// This is not critical - we've already created the items in Jira
 		log.Printf("Warning: Failed to save updated tickets back to file: %v\n", err)
@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -56,7 +57,7 @@ This uses the old format
 // MockUnsupportedRepository rejects files using # STORY headings
 type MockUnsupportedRepository struct{}
 
-func (m *MockUnsupportedRepository) GetTickets(filepath string) ([]domain.Ticket, error) {
+func (m *MockUnsupportedRepository) GetTickets(ctx context.Context, filepath string) ([]domain.Ticket, error) {
 	// Read the file to check format
 	content, err := os.ReadFile(filepath)
 	if err != nil {
@@ -72,7 +73,7 @@ func (m *MockUnsupportedRepository) GetTickets(filepath string) ([]domain.Ticket
 	return []domain.Ticket{}, nil
 }
 
-func (m *MockUnsupportedRepository) SaveTickets(filepath string, tickets []domain.Ticket) error {
+func (m *MockUnsupportedRepository) SaveTickets(ctx context.Context, filepath string, tickets []domain.Ticket) error {
 	return nil
 }
 
@@ -82,40 +83,68 @@ type MockJiraPortForUnsupported struct {
 	updateCalled bool
 }
 
-func (m *MockJiraPortForUnsupported) Authenticate() error {
+func (m *MockJiraPortForUnsupported) Authenticate(ctx context.Context) error {
 	return nil
 }
 
-func (m *MockJiraPortForUnsupported) CreateTask(task domain.Task, parentID string) (string, error) {
+func (m *MockJiraPortForUnsupported) CreateTask(ctx context.Context, task domain.Task, parentID string) (string, error) {
 	return "TASK-123", nil
 }
 
-func (m *MockJiraPortForUnsupported) UpdateTask(task domain.Task) error {
+func (m *MockJiraPortForUnsupported) UpdateTask(ctx context.Context, task domain.Task) error {
 	return nil
 }
 
-func (m *MockJiraPortForUnsupported) GetProjectIssueTypes() (map[string][]string, error) {
+func (m *MockJiraPortForUnsupported) GetProjectIssueTypes(ctx context.Context) (map[string][]string, error) {
 	return nil, nil
 }
 
-func (m *MockJiraPortForUnsupported) GetIssueTypeFields(issueTypeName string) (map[string]interface{}, error) {
+func (m *MockJiraPortForUnsupported) GetIssueTypeFields(ctx context.Context, issueTypeName string) (map[string]interface{}, error) {
 	return nil, nil
 }
 
-func (m *MockJiraPortForUnsupported) CreateTicket(ticket domain.Ticket) (string, error) {
+func (m *MockJiraPortForUnsupported) CreateTicket(ctx context.Context, ticket domain.Ticket) (string, error) {
 	m.createCalled = true
 	return "TICKET-123", nil
 }
 
-func (m *MockJiraPortForUnsupported) UpdateTicket(ticket domain.Ticket) error {
+func (m *MockJiraPortForUnsupported) UpdateTicket(ctx context.Context, ticket domain.Ticket) error {
 	m.updateCalled = true
 	return nil
 }
 
-func (m *MockJiraPortForUnsupported) SearchTickets(projectKey string, jql string) ([]domain.Ticket, error) {
+func (m *MockJiraPortForUnsupported) SearchTickets(ctx context.Context, projectKey string, jql string) ([]domain.Ticket, error) {
 	return []domain.Ticket{}, nil
 }
 
+func (m *MockJiraPortForUnsupported) DiffTicket(ctx context.Context, ticket domain.Ticket) ([]domain.FieldChange, error) {
+	return nil, nil
+}
+
+func (m *MockJiraPortForUnsupported) LinkIssues(ctx context.Context, issueKey string, links []domain.Link) error {
+	return nil
+}
+
+func (m *MockJiraPortForUnsupported) TransitionIssue(ctx context.Context, issueKey string, status string) error {
+	return nil
+}
+
+func (m *MockJiraPortForUnsupported) AddComment(ctx context.Context, issueKey string, comment domain.Comment) (domain.Comment, error) {
+	return comment, nil
+}
+
+func (m *MockJiraPortForUnsupported) DiffTask(ctx context.Context, task domain.Task, parentID string) ([]domain.FieldChange, error) {
+	return nil, nil
+}
+
+func (m *MockJiraPortForUnsupported) DiffLinks(ctx context.Context, issueKey string, links []domain.Link) ([]domain.Link, error) {
+	return links, nil
+}
+
+func (m *MockJiraPortForUnsupported) TransitionPath(ctx context.Context, issueKey string, status string) ([]string, error) {
+	return nil, nil
+}
+
 // Original test
 func TestTicketService_CalculateFinalFields(t *testing.T) {
 	service := NewTicketService(nil, nil)
@@ -530,11 +559,11 @@ type MockMixedRepository struct {
 	tickets []domain.Ticket
 }
 
-func (m *MockMixedRepository) GetTickets(filepath string) ([]domain.Ticket, error) {
+func (m *MockMixedRepository) GetTickets(ctx context.Context, filepath string) ([]domain.Ticket, error) {
 	return m.tickets, nil
 }
 
-func (m *MockMixedRepository) SaveTickets(filepath string, tickets []domain.Ticket) error {
+func (m *MockMixedRepository) SaveTickets(ctx context.Context, filepath string, tickets []domain.Ticket) error {
 	return nil
 }
 
@@ -545,11 +574,11 @@ type MockJiraPortWithErrors struct {
 	createCount        int
 }
 
-func (m *MockJiraPortWithErrors) Authenticate() error {
+func (m *MockJiraPortWithErrors) Authenticate(ctx context.Context) error {
 	return nil
 }
 
-func (m *MockJiraPortWithErrors) CreateTicket(ticket domain.Ticket) (string, error) {
+func (m *MockJiraPortWithErrors) CreateTicket(ctx context.Context, ticket domain.Ticket) (string, error) {
 	if m.failAll {
 		return "", fmt.Errorf("simulated JIRA error for ticket '%s'", ticket.Title)
 	}
@@ -560,7 +589,7 @@ func (m *MockJiraPortWithErrors) CreateTicket(ticket domain.Ticket) (string, err
 	return fmt.Sprintf("TICKET-%d", m.createCount), nil
 }
 
-func (m *MockJiraPortWithErrors) UpdateTicket(ticket domain.Ticket) error {
+func (m *MockJiraPortWithErrors) UpdateTicket(ctx context.Context, ticket domain.Ticket) error {
 	if m.failAll {
 		return fmt.Errorf("simulated JIRA error for ticket '%s'", ticket.Title)
 	}
@@ -570,7 +599,7 @@ func (m *MockJiraPortWithErrors) UpdateTicket(ticket domain.Ticket) error {
 	return nil
 }
 
-func (m *MockJiraPortWithErrors) CreateTask(task domain.Task, parentID string) (string, error) {
+func (m *MockJiraPortWithErrors) CreateTask(ctx context.Context, task domain.Task, parentID string) (string, error) {
 	if m.failAll {
 		return "", fmt.Errorf("simulated JIRA error for task '%s'", task.Title)
 	}
@@ -581,23 +610,51 @@ func (m *MockJiraPortWithErrors) CreateTask(task domain.Task, parentID string) (
 	return fmt.Sprintf("TASK-%d", m.createCount), nil
 }
 
-func (m *MockJiraPortWithErrors) UpdateTask(task domain.Task) error {
+func (m *MockJiraPortWithErrors) UpdateTask(ctx context.Context, task domain.Task) error {
 	if m.failAll {
 		return fmt.Errorf("simulated JIRA error for task '%s'", task.Title)
 	}
 	return nil
 }
 
-func (m *MockJiraPortWithErrors) GetProjectIssueTypes() (map[string][]string, error) {
+func (m *MockJiraPortWithErrors) GetProjectIssueTypes(ctx context.Context) (map[string][]string, error) {
 	return map[string][]string{
 		"TEST": {"Story", "Task", "Bug"},
 	}, nil
 }
 
-func (m *MockJiraPortWithErrors) GetIssueTypeFields(issueTypeName string) (map[string]interface{}, error) {
+func (m *MockJiraPortWithErrors) GetIssueTypeFields(ctx context.Context, issueTypeName string) (map[string]interface{}, error) {
 	return map[string]interface{}{}, nil
 }
 
-func (m *MockJiraPortWithErrors) SearchTickets(projectKey string, jql string) ([]domain.Ticket, error) {
+func (m *MockJiraPortWithErrors) SearchTickets(ctx context.Context, projectKey string, jql string) ([]domain.Ticket, error) {
 	return []domain.Ticket{}, nil
 }
+
+func (m *MockJiraPortWithErrors) DiffTicket(ctx context.Context, ticket domain.Ticket) ([]domain.FieldChange, error) {
+	return nil, nil
+}
+
+func (m *MockJiraPortWithErrors) LinkIssues(ctx context.Context, issueKey string, links []domain.Link) error {
+	return nil
+}
+
+func (m *MockJiraPortWithErrors) TransitionIssue(ctx context.Context, issueKey string, status string) error {
+	return nil
+}
+
+func (m *MockJiraPortWithErrors) AddComment(ctx context.Context, issueKey string, comment domain.Comment) (domain.Comment, error) {
+	return comment, nil
+}
+
+func (m *MockJiraPortWithErrors) DiffTask(ctx context.Context, task domain.Task, parentID string) ([]domain.FieldChange, error) {
+	return nil, nil
+}
+
+func (m *MockJiraPortWithErrors) DiffLinks(ctx context.Context, issueKey string, links []domain.Link) ([]domain.Link, error) {
+	return links, nil
+}
+
+func (m *MockJiraPortWithErrors) TransitionPath(ctx context.Context, issueKey string, status string) ([]string, error) {
+	return nil, nil
+}
@@ -0,0 +1,75 @@
+package validation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+)
+
+// FileTickets are the tickets read from one file
+type FileTickets struct {
+	File    string
+	Tickets []domain.Ticket
+}
+
+// keyLocation is where a ticket or task with a Jira key starts
+type keyLocation struct {
+	file string
+	line int
+}
+
+func (l keyLocation) String() string {
+	if l.line > 0 {
+		return fmt.Sprintf("%s:%d", l.file, l.line)
+	}
+	return l.file
+}
+
+// ValidateKeys reports tickets and tasks that share a Jira key with another
+// ticket or task, in the same file or another one. Pushing both would
+// overwrite one issue with two versions. The problems are returned by file,
+// one at every place a shared key appears.
+func (v *Validator) ValidateKeys(files []FileTickets) map[string][]ValidationError {
+	var keys []string
+	locations := make(map[string][]keyLocation)
+	add := func(key, file string, line int) {
+		if key == "" {
+			return
+		}
+		if _, seen := locations[key]; !seen {
+			keys = append(keys, key)
+		}
+		locations[key] = append(locations[key], keyLocation{file: file, line: line})
+	}
+	for _, file := range files {
+		for _, ticket := range file.Tickets {
+			add(ticket.JiraID, file.File, ticket.SourceLine)
+			for _, task := range ticket.Tasks {
+				add(task.JiraID, file.File, task.SourceLine)
+			}
+		}
+	}
+
+	problems := make(map[string][]ValidationError)
+	for _, key := range keys {
+		if len(locations[key]) < 2 {
+			continue
+		}
+		for i, location := range locations[key] {
+			var others []string
+			for j, other := range locations[key] {
+				if j != i {
+					others = append(others, other.String())
+				}
+			}
+			problems[location.file] = v.add(problems[location.file], ValidationError{
+				Field:   "Jira Key",
+				Message: fmt.Sprintf("%s is also used at %s", key, strings.Join(others, ", ")),
+				Line:    location.line,
+				Rule:    RuleDuplicateKey,
+			})
+		}
+	}
+	return problems
+}
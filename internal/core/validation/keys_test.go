@@ -0,0 +1,34 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+)
+
+// TestValidateKeys verifies a Jira key used in two files is reported at both places
+func TestValidateKeys(t *testing.T) {
+	files := []FileTickets{
+		{File: "backlog/auth.md", Tickets: []domain.Ticket{
+			{Title: "Login", JiraID: "PROJ-1", SourceLine: 1, Tasks: []domain.Task{{Title: "Form", JiraID: "PROJ-2", SourceLine: 6}}},
+			{Title: "Logout", SourceLine: 10},
+		}},
+		{File: "backlog/billing.md", Tickets: []domain.Ticket{
+			{Title: "Invoices", JiraID: "PROJ-3", SourceLine: 1},
+			{Title: "Login copy", JiraID: "PROJ-1", SourceLine: 4},
+		}},
+	}
+
+	problems := NewValidator().ValidateKeys(files)
+
+	if len(problems) != 2 || len(problems["backlog/auth.md"]) != 1 || len(problems["backlog/billing.md"]) != 1 {
+		t.Fatalf("Expected one problem in each file, got %v", problems)
+	}
+	got := problems["backlog/auth.md"][0]
+	if got.Line != 1 || got.Rule != RuleDuplicateKey || got.Message != "PROJ-1 is also used at backlog/billing.md:4" {
+		t.Errorf("Unexpected problem in auth.md: %+v", got)
+	}
+	if got := problems["backlog/billing.md"][0]; got.Message != "PROJ-1 is also used at backlog/auth.md:1" || got.IsWarning() {
+		t.Errorf("Unexpected problem in billing.md: %+v", got)
+	}
+}
@@ -0,0 +1,297 @@
+package validation
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+)
+
+// Severity is how a problem affects push: errors stop it, warnings are only reported
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Rule IDs, reported with each problem and used to configure or disable rules
+const (
+	RuleSyntax            = "syntax"
+	RuleTitleRequired     = "title_required"
+	RuleHierarchy         = "hierarchy"
+	RuleRequiredFields    = "required_fields"
+	RulePattern           = "pattern"
+	RuleMaxLength         = "max_length"
+	RuleUnknownField      = "unknown_field"
+	RuleNumber            = "number"
+	RuleJiraIssueType     = "jira_issue_type"
+	RuleJiraRequiredField = "jira_required_field"
+	RuleJiraAllowedValue  = "jira_allowed_value"
+	RuleDuplicateKey      = "duplicate_key"
+)
+
+// RuleDescriptions describes each rule, by rule ID
+var RuleDescriptions = map[string]string{
+	RuleSyntax:            "Markdown must follow the ticket grammar; content it does not fit is ignored",
+	RuleTitleRequired:     "Tickets must have a title",
+	RuleHierarchy:         "Task issue types must be allowed children of their ticket's issue type",
+	RuleRequiredFields:    "Fields listed in validation.required_fields must be set",
+	RulePattern:           "Field values must match the pattern of their validation.fields rule",
+	RuleMaxLength:         "Field values must not exceed the max_length of their validation.fields rule",
+	RuleUnknownField:      "Fields must be listed in field_mappings to be sent to Jira",
+	RuleNumber:            "Number fields must hold numbers",
+	RuleJiraIssueType:     "Issue types must exist in the Jira project",
+	RuleJiraRequiredField: "Fields Jira requires for the issue type must be set",
+	RuleJiraAllowedValue:  "Option fields must hold one of the values Jira allows",
+	RuleDuplicateKey:      "A Jira key must belong to one ticket or task across all files pushed together",
+}
+
+// Issue types assumed for tickets and tasks without a Type field
+const (
+	defaultTicketType = "Story"
+	defaultTaskType   = "Sub-task"
+)
+
+// FieldRule constrains the value of a field of tickets and tasks
+type FieldRule struct {
+	Field      string   // Custom field name, or Title or Description
+	IssueTypes []string // Issue types the rule applies to; all when empty
+	Pattern    string   // Regular expression the value must match
+	MaxLength  int      // Maximum length in characters; 0 for no limit
+	Message    string   // Reported instead of the default message
+	Severity   Severity // Severity of the pattern or max_length rule when empty
+}
+
+// Rules configure the Validator
+type Rules struct {
+	Hierarchy      map[string][]string // Parent issue type to allowed child types
+	RequiredFields map[string][]string // Issue type to fields that must be set
+	Fields         []FieldRule
+	Severity       map[string]Severity // Rule ID to severity; error when unset
+	Disabled       []string            // Rule IDs whose problems are not reported
+}
+
+// DefaultRules returns the rules NewValidator uses
+func DefaultRules() Rules {
+	return Rules{
+		Hierarchy: map[string][]string{
+			"Epic":    {"Story", "Task", "Bug"},
+			"Story":   {"Sub-task", "Task"},
+			"Task":    {"Sub-task"},
+			"Bug":     {"Sub-task"},
+			"Feature": {"Sub-task", "Task"},
+		},
+	}
+}
+
+// compiledFieldRule is a FieldRule with its pattern compiled
+type compiledFieldRule struct {
+	FieldRule
+	pattern *regexp.Regexp
+}
+
+// NewValidatorWithRules creates a validator applying the given rules. Issue
+// type names match case-insensitively. It fails on an invalid pattern,
+// severity or rule ID.
+func NewValidatorWithRules(rules Rules) (*Validator, error) {
+	v := &Validator{
+		hierarchyRules: make(map[string][]string),
+		requiredFields: make(map[string][]string),
+		severities:     make(map[string]Severity),
+		disabled:       make(map[string]bool),
+	}
+	for parent, children := range rules.Hierarchy {
+		v.hierarchyRules[strings.ToLower(parent)] = children
+	}
+	for issueType, fields := range rules.RequiredFields {
+		v.requiredFields[strings.ToLower(issueType)] = fields
+	}
+
+	for rule, severity := range rules.Severity {
+		if err := checkRuleID(rule); err != nil {
+			return nil, err
+		}
+		if err := checkSeverity(severity); err != nil {
+			return nil, fmt.Errorf("severity of %s: %w", rule, err)
+		}
+		v.severities[rule] = severity
+	}
+	for _, rule := range rules.Disabled {
+		if err := checkRuleID(rule); err != nil {
+			return nil, err
+		}
+		v.disabled[rule] = true
+	}
+
+	for _, rule := range rules.Fields {
+		if rule.Field == "" {
+			return nil, fmt.Errorf("field rule without a field")
+		}
+		if err := checkSeverity(rule.Severity); err != nil {
+			return nil, fmt.Errorf("rule for %s: %w", rule.Field, err)
+		}
+		compiled := compiledFieldRule{FieldRule: rule}
+		if rule.Pattern != "" {
+			pattern, err := regexp.Compile(rule.Pattern)
+			if err != nil {
+				return nil, fmt.Errorf("rule for %s: invalid pattern: %w", rule.Field, err)
+			}
+			compiled.pattern = pattern
+		}
+		v.fieldRules = append(v.fieldRules, compiled)
+	}
+
+	return v, nil
+}
+
+// checkSeverity fails unless severity is empty, error or warning
+func checkSeverity(severity Severity) error {
+	switch severity {
+	case "", SeverityError, SeverityWarning:
+		return nil
+	}
+	return fmt.Errorf("unknown severity '%s' (use error or warning)", severity)
+}
+
+// checkRuleID fails unless rule is a known rule ID
+func checkRuleID(rule string) error {
+	if _, known := RuleDescriptions[rule]; !known {
+		return fmt.Errorf("unknown validation rule '%s'", rule)
+	}
+	return nil
+}
+
+// add appends a problem unless its rule is disabled, giving it the rule's
+// configured severity when it has none of its own
+func (v *Validator) add(errors []ValidationError, problem ValidationError) []ValidationError {
+	if v.disabled[problem.Rule] {
+		return errors
+	}
+	if problem.Severity == "" {
+		problem.Severity = v.severities[problem.Rule]
+	}
+	if problem.Severity == "" {
+		problem.Severity = SeverityError
+	}
+	return append(errors, problem)
+}
+
+// Apply applies the configured severities and disabled rules to problems
+// found outside the validator, such as parse warnings. A problem keeps its
+// own severity unless one is configured for its rule.
+func (v *Validator) Apply(problems []ValidationError) []ValidationError {
+	applied := []ValidationError{}
+	for _, problem := range problems {
+		if severity, configured := v.severities[problem.Rule]; configured {
+			problem.Severity = severity
+		}
+		applied = v.add(applied, problem)
+	}
+	return applied
+}
+
+// ValidateRules checks tickets and tasks against the configured required
+// fields and field rules. Tasks count the fields they inherit as set.
+func (v *Validator) ValidateRules(tickets []domain.Ticket) []ValidationError {
+	errors := []ValidationError{}
+
+	for _, ticket := range tickets {
+		issueType := ticket.CustomFields["Type"]
+		if issueType == "" {
+			issueType = defaultTicketType
+		}
+		errors = append(errors, v.checkRequired(issueType, ticket.CustomFields, ticket.SourceLine)...)
+		errors = append(errors, v.checkFieldRules(issueType, ticket.Title, ticket.Description, ticket.CustomFields, ticket.FieldLines, ticket.SourceLine)...)
+
+		for _, task := range ticket.Tasks {
+			taskType := task.CustomFields["Type"]
+			if taskType == "" {
+				taskType = defaultTaskType
+			}
+			fields := make(map[string]string)
+			for k, val := range ticket.CustomFields {
+				fields[k] = val
+			}
+			for k, val := range task.CustomFields {
+				fields[k] = val
+			}
+			errors = append(errors, v.checkRequired(taskType, fields, task.SourceLine)...)
+			// Inherited fields were checked on the ticket
+			errors = append(errors, v.checkFieldRules(taskType, task.Title, task.Description, task.CustomFields, task.FieldLines, task.SourceLine)...)
+		}
+	}
+
+	return errors
+}
+
+// checkRequired reports the required fields of an issue type that are unset
+func (v *Validator) checkRequired(issueType string, fields map[string]string, line int) []ValidationError {
+	var errors []ValidationError
+	for _, field := range v.requiredFields[strings.ToLower(issueType)] {
+		if strings.TrimSpace(fields[field]) == "" {
+			errors = v.add(errors, ValidationError{
+				Field:   field,
+				Message: fmt.Sprintf("Required field '%s' is missing or empty for issue type '%s'", field, issueType),
+				Line:    line,
+				Rule:    RuleRequiredFields,
+			})
+		}
+	}
+	return errors
+}
+
+// checkFieldRules applies the field rules for an issue type to a ticket or
+// task, reporting custom fields at their own line
+func (v *Validator) checkFieldRules(issueType, title, description string, fields map[string]string, lines map[string]int, line int) []ValidationError {
+	var errors []ValidationError
+	for _, rule := range v.fieldRules {
+		if len(rule.IssueTypes) > 0 && !containsFold(rule.IssueTypes, issueType) {
+			continue
+		}
+
+		var value string
+		switch rule.Field {
+		case "Title":
+			value = title
+		case "Description":
+			value = description
+		default:
+			value = fields[rule.Field]
+		}
+		if value == "" {
+			// Unset fields are the business of required_fields
+			continue
+		}
+
+		var message, ruleID string
+		if rule.pattern != nil && !rule.pattern.MatchString(value) {
+			message, ruleID = fmt.Sprintf("'%s' does not match pattern '%s'", value, rule.Pattern), RulePattern
+		} else if rule.MaxLength > 0 && utf8.RuneCountInString(value) > rule.MaxLength {
+			message, ruleID = fmt.Sprintf("%d characters is longer than the maximum of %d", utf8.RuneCountInString(value), rule.MaxLength), RuleMaxLength
+		}
+		if message == "" {
+			continue
+		}
+		if rule.Message != "" {
+			message = rule.Message
+		}
+
+		errors = v.add(errors, ValidationError{Field: rule.Field, Message: message, Line: fieldLine(lines, rule.Field, line), Rule: ruleID, Severity: rule.Severity})
+	}
+	return errors
+}
+
+// SplitBySeverity separates problems that stop push from warnings
+func SplitBySeverity(problems []ValidationError) (errors, warnings []ValidationError) {
+	for _, problem := range problems {
+		if problem.IsWarning() {
+			warnings = append(warnings, problem)
+		} else {
+			errors = append(errors, problem)
+		}
+	}
+	return errors, warnings
+}
@@ -0,0 +1,157 @@
+package validation
+
+import (
+	"testing"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+)
+
+// TestValidateHierarchy_ConfiguredRules verifies a configured hierarchy replaces the built-in one
+func TestValidateHierarchy_ConfiguredRules(t *testing.T) {
+	validator, err := NewValidatorWithRules(Rules{
+		Hierarchy: map[string][]string{
+			"initiative": {"Epic"},
+			"Epic":       {"Story"},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewValidatorWithRules returned error: %v", err)
+	}
+
+	tickets := []domain.Ticket{
+		{
+			Title:        "Platform",
+			CustomFields: map[string]string{"Type": "Initiative"},
+			Tasks: []domain.Task{
+				{Title: "Billing", CustomFields: map[string]string{"Type": "epic"}, SourceLine: 4},
+				{Title: "Login", CustomFields: map[string]string{"Type": "Story"}, SourceLine: 6},
+			},
+		},
+		{
+			Title:        "Billing",
+			CustomFields: map[string]string{"Type": "Epic"},
+			Tasks:        []domain.Task{{Title: "Bug", CustomFields: map[string]string{"Type": "Bug"}, SourceLine: 12}},
+		},
+	}
+
+	errors := validator.ValidateHierarchy(tickets)
+
+	if len(errors) != 2 || errors[0].Line != 6 || errors[1].Line != 12 {
+		t.Fatalf("Expected errors on lines 6 and 12, got %v", errors)
+	}
+	if errors[0].Message != "A 'Story' cannot be the child of a 'Initiative'" {
+		t.Errorf("Unexpected message: %s", errors[0].Message)
+	}
+}
+
+// TestValidateRules_RequiredFields verifies per-type required fields, counting inherited ones for tasks
+func TestValidateRules_RequiredFields(t *testing.T) {
+	validator, err := NewValidatorWithRules(Rules{
+		RequiredFields: map[string][]string{
+			"Story":    {"Team", "Story Points"},
+			"Sub-task": {"Team"},
+		},
+		Severity: map[string]Severity{RuleRequiredFields: SeverityWarning},
+	})
+	if err != nil {
+		t.Fatalf("NewValidatorWithRules returned error: %v", err)
+	}
+
+	tickets := []domain.Ticket{{
+		Title:        "Checkout",
+		CustomFields: map[string]string{"Type": "Story", "Team": "Payments", "Story Points": " "},
+		SourceLine:   1,
+		Tasks:        []domain.Task{{Title: "API", SourceLine: 8}},
+	}}
+
+	errors := validator.ValidateRules(tickets)
+
+	if len(errors) != 1 {
+		t.Fatalf("Expected 1 error, got %d: %v", len(errors), errors)
+	}
+	if errors[0].Field != "Story Points" || errors[0].Line != 1 || !errors[0].IsWarning() {
+		t.Errorf("Expected a Story Points warning on line 1, got %+v", errors[0])
+	}
+}
+
+// TestValidateRules_FieldRules verifies patterns, maximum lengths and issue type filters
+func TestValidateRules_FieldRules(t *testing.T) {
+	validator, err := NewValidatorWithRules(Rules{
+		Fields: []FieldRule{
+			{Field: "Title", IssueTypes: []string{"Story"}, Pattern: `^\[[A-Z]+\] `, Message: "Title must start with a [TEAM] prefix"},
+			{Field: "Title", MaxLength: 20, Severity: SeverityWarning},
+			{Field: "Sprint", Pattern: `^\d+$`},
+		},
+	})
+	if err != nil {
+		t.Fatalf("NewValidatorWithRules returned error: %v", err)
+	}
+
+	tickets := []domain.Ticket{{
+		Title:        "Checkout without a prefix",
+		CustomFields: map[string]string{"Type": "Story", "Sprint": "12"},
+		SourceLine:   1,
+		Tasks: []domain.Task{
+			{Title: "No prefix needed", CustomFields: map[string]string{"Sprint": "next"}, SourceLine: 9},
+		},
+	}}
+
+	errors := validator.ValidateRules(tickets)
+
+	if len(errors) != 3 {
+		t.Fatalf("Expected 3 errors, got %d: %v", len(errors), errors)
+	}
+	if errors[0].Message != "Title must start with a [TEAM] prefix" || errors[0].IsWarning() {
+		t.Errorf("Expected the prefix error, got %+v", errors[0])
+	}
+	if errors[1].Message != "25 characters is longer than the maximum of 20" || !errors[1].IsWarning() {
+		t.Errorf("Expected the length warning, got %+v", errors[1])
+	}
+	if errors[2].Field != "Sprint" || errors[2].Line != 9 || errors[2].Message != `'next' does not match pattern '^\d+$'` {
+		t.Errorf("Expected the task's Sprint error, got %+v", errors[2])
+	}
+}
+
+// TestNewValidatorWithRules_Invalid verifies bad patterns and severities are rejected
+func TestNewValidatorWithRules_Invalid(t *testing.T) {
+	if _, err := NewValidatorWithRules(Rules{Fields: []FieldRule{{Field: "Title", Pattern: "("}}}); err == nil {
+		t.Error("Expected an error for an invalid pattern")
+	}
+	if _, err := NewValidatorWithRules(Rules{Severity: map[string]Severity{RuleHierarchy: "fatal"}}); err == nil {
+		t.Error("Expected an error for an unknown severity")
+	}
+}
+
+// TestSplitBySeverity verifies warnings are separated from errors
+func TestSplitBySeverity(t *testing.T) {
+	errors, warnings := SplitBySeverity([]ValidationError{
+		{Field: "A"},
+		{Field: "B", Severity: SeverityWarning},
+		{Field: "C", Severity: SeverityError},
+	})
+	if len(errors) != 2 || len(warnings) != 1 || warnings[0].Field != "B" {
+		t.Errorf("Unexpected split: %v / %v", errors, warnings)
+	}
+}
+
+// TestNewValidatorWithRules_Disabled verifies disabled rules are not reported and problems carry rule IDs
+func TestNewValidatorWithRules_Disabled(t *testing.T) {
+	validator, err := NewValidatorWithRules(Rules{Disabled: []string{RuleUnknownField}})
+	if err != nil {
+		t.Fatalf("NewValidatorWithRules returned error: %v", err)
+	}
+	tickets := []domain.Ticket{{Title: "", CustomFields: map[string]string{"Sprnt": "12"}, SourceLine: 1}}
+	mappings := map[string]interface{}{}
+
+	if errors := validator.ValidateFields(tickets, mappings); len(errors) != 0 {
+		t.Errorf("Expected the unknown field to be suppressed, got %v", errors)
+	}
+	errors := validator.ValidateTickets(tickets)
+	if len(errors) != 1 || errors[0].Rule != RuleTitleRequired || errors[0].Severity != SeverityError {
+		t.Errorf("Expected a title_required error, got %v", errors)
+	}
+
+	if _, err := NewValidatorWithRules(Rules{Disabled: []string{"no_such_rule"}}); err == nil {
+		t.Error("Expected an error for an unknown rule ID")
+	}
+}
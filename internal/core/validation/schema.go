@@ -0,0 +1,258 @@
+package validation
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+)
+
+// FieldSpec describes a field Jira's create metadata lists for an issue type
+type FieldSpec struct {
+	ID              string
+	Name            string
+	Required        bool
+	HasDefaultValue bool     // Jira fills the field in when it is left out, e.g. Reporter
+	Type            string   // Schema type, e.g. "string", "number", "option", "array"
+	AllowedValues   []string // Names of the options, for option and array fields
+}
+
+// Schema is the create metadata of a project's issue types
+type Schema struct {
+	TicketType string                 // Issue type of tickets without a Type field
+	TaskType   string                 // Issue type tasks are created as
+	IssueTypes map[string][]FieldSpec // Fields by issue type name; a type missing here does not exist
+}
+
+// setByTicketr are the fields push fills in itself, whatever the Markdown says
+var setByTicketr = map[string]bool{
+	"summary":     true,
+	"description": true,
+	"project":     true,
+	"issuetype":   true,
+	"parent":      true,
+}
+
+// FieldSpecsFromCreateMeta converts the result of JiraPort.GetIssueTypeFields
+// into field specs
+func FieldSpecsFromCreateMeta(meta map[string]interface{}) []FieldSpec {
+	entries, _ := meta["fields"].([]map[string]interface{})
+
+	var specs []FieldSpec
+	for _, entry := range entries {
+		spec := FieldSpec{}
+		spec.ID, _ = entry["key"].(string)
+		spec.Name, _ = entry["name"].(string)
+		spec.Required, _ = entry["required"].(bool)
+		spec.HasDefaultValue, _ = entry["hasDefaultValue"].(bool)
+		spec.Type, _ = entry["type"].(string)
+		spec.AllowedValues, _ = entry["allowedValues"].([]string)
+		specs = append(specs, spec)
+	}
+	sort.Slice(specs, func(i, j int) bool { return specs[i].ID < specs[j].ID })
+	return specs
+}
+
+// ValidateFields checks field names and values against field_mappings without
+// contacting Jira: fields without a mapping, which push would not send, and
+// values of number fields that are not numbers
+func (v *Validator) ValidateFields(tickets []domain.Ticket, fieldMappings map[string]interface{}) []ValidationError {
+	errors := []ValidationError{}
+
+	check := func(fields map[string]string, lines map[string]int, line int) {
+		for _, name := range sortedNames(fields) {
+			mapping, mapped := fieldMappings[name]
+			if !mapped {
+				errors = v.add(errors, ValidationError{
+					Field:   name,
+					Message: "Unknown field: not in field_mappings, so push would not send it",
+					Line:    fieldLine(lines, name, line),
+					Rule:    RuleUnknownField,
+				})
+				continue
+			}
+			if mappingType(mapping) == "number" && !isNumber(fields[name]) {
+				errors = v.add(errors, ValidationError{
+					Field:   name,
+					Message: fmt.Sprintf("'%s' is not a number", fields[name]),
+					Line:    fieldLine(lines, name, line),
+					Rule:    RuleNumber,
+				})
+			}
+		}
+	}
+
+	for _, ticket := range tickets {
+		check(ticket.CustomFields, ticket.FieldLines, ticket.SourceLine)
+		for _, task := range ticket.Tasks {
+			// Inherited fields were checked on the ticket
+			check(task.CustomFields, task.FieldLines, task.SourceLine)
+		}
+	}
+
+	return errors
+}
+
+// ValidateSchema checks tickets against the create metadata of their issue
+// types: the issue type exists, required fields are set, option fields hold
+// allowed values and number fields hold numbers. Tasks are checked with the
+// fields they inherit, as push sends them.
+func (v *Validator) ValidateSchema(tickets []domain.Ticket, fieldMappings map[string]interface{}, schema Schema) []ValidationError {
+	errors := []ValidationError{}
+
+	// Markdown field names by Jira field ID
+	names := make(map[string][]string)
+	for name, mapping := range fieldMappings {
+		names[mappingID(mapping)] = append(names[mappingID(mapping)], name)
+	}
+	for _, list := range names {
+		sort.Strings(list)
+	}
+
+	check := func(issueType string, fields map[string]string, line int) {
+		specs, exists := schema.IssueTypes[issueType]
+		if !exists {
+			errors = v.add(errors, ValidationError{
+				Field:   "Type",
+				Message: fmt.Sprintf("Issue type '%s' does not exist in the project", issueType),
+				Line:    line,
+				Rule:    RuleJiraIssueType,
+			})
+			return
+		}
+
+		for _, spec := range specs {
+			if setByTicketr[spec.ID] {
+				continue
+			}
+
+			var set []string // Markdown names with a value for the field
+			for _, name := range names[spec.ID] {
+				if fields[name] != "" {
+					set = append(set, name)
+				}
+			}
+
+			if len(set) == 0 {
+				if spec.Required && !spec.HasDefaultValue {
+					message := fmt.Sprintf("Required field '%s' is missing or empty for issue type '%s'", spec.Name, issueType)
+					if len(names[spec.ID]) == 0 {
+						message += fmt.Sprintf(" (add %s to field_mappings)", spec.ID)
+					}
+					errors = v.add(errors, ValidationError{Field: spec.Name, Message: message, Line: line, Rule: RuleJiraRequiredField})
+				}
+				continue
+			}
+
+			for _, name := range set {
+				if message, rule := checkValue(spec, fields[name]); message != "" {
+					errors = v.add(errors, ValidationError{Field: name, Message: message, Line: line, Rule: rule})
+				}
+			}
+		}
+	}
+
+	for _, ticket := range tickets {
+		issueType := ticket.CustomFields["Type"]
+		if issueType == "" {
+			issueType = schema.TicketType
+		}
+		check(issueType, ticket.CustomFields, ticket.SourceLine)
+
+		for _, task := range ticket.Tasks {
+			fields := make(map[string]string)
+			for k, val := range ticket.CustomFields {
+				fields[k] = val
+			}
+			for k, val := range task.CustomFields {
+				fields[k] = val
+			}
+			check(schema.TaskType, fields, task.SourceLine)
+		}
+	}
+
+	return errors
+}
+
+// checkValue returns why a value does not fit a field and the rule it breaks,
+// or "" when it fits
+func checkValue(spec FieldSpec, value string) (string, string) {
+	if spec.Type == "number" && !isNumber(value) {
+		return fmt.Sprintf("'%s' is not a number", value), RuleNumber
+	}
+	if len(spec.AllowedValues) == 0 {
+		return "", ""
+	}
+
+	values := []string{value}
+	if spec.Type == "array" {
+		values = strings.Split(value, ",")
+	}
+	for _, val := range values {
+		val = strings.TrimSpace(val)
+		if val != "" && !containsFold(spec.AllowedValues, val) {
+			return fmt.Sprintf("'%s' is not an allowed value (%s)", val, strings.Join(spec.AllowedValues, ", ")), RuleJiraAllowedValue
+		}
+	}
+	return "", ""
+}
+
+// mappingID returns the Jira field ID of a field_mappings entry
+func mappingID(mapping interface{}) string {
+	switch m := mapping.(type) {
+	case string:
+		return m
+	case map[string]interface{}:
+		id, _ := m["id"].(string)
+		return id
+	}
+	return ""
+}
+
+// mappingType returns the type of a field_mappings entry, "" when it has none
+func mappingType(mapping interface{}) string {
+	if m, ok := mapping.(map[string]interface{}); ok {
+		fieldType, _ := m["type"].(string)
+		return fieldType
+	}
+	return ""
+}
+
+// isNumber reports whether a field value is empty or a number
+func isNumber(value string) bool {
+	if value == "" {
+		return true
+	}
+	_, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	return err == nil
+}
+
+// containsFold reports whether list holds value, ignoring case
+func containsFold(list []string, value string) bool {
+	for _, item := range list {
+		if strings.EqualFold(item, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldLine returns the source line of a field, or line when it is not known
+func fieldLine(lines map[string]int, name string, line int) int {
+	if fieldLine, ok := lines[name]; ok {
+		return fieldLine
+	}
+	return line
+}
+
+// sortedNames returns a field map's names in alphabetical order
+func sortedNames(fields map[string]string) []string {
+	names := make([]string, 0, len(fields))
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,177 @@
+package validation
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+)
+
+var schemaFieldMappings = map[string]interface{}{
+	"Type":       "issuetype",
+	"Priority":   "priority",
+	"Components": "components",
+	"Team":       "customfield_10100",
+	"Story Points": map[string]interface{}{
+		"id":   "customfield_10010",
+		"type": "number",
+	},
+}
+
+var testSchema = Schema{
+	TicketType: "Task",
+	TaskType:   "Sub-task",
+	IssueTypes: map[string][]FieldSpec{
+		"Story": {
+			{ID: "summary", Name: "Summary", Required: true},
+			{ID: "reporter", Name: "Reporter", Required: true, HasDefaultValue: true},
+			{ID: "priority", Name: "Priority", Type: "priority", AllowedValues: []string{"High", "Medium", "Low"}},
+			{ID: "components", Name: "Components", Type: "array", AllowedValues: []string{"Backend", "Frontend"}},
+			{ID: "customfield_10010", Name: "Story Points", Type: "number"},
+			{ID: "customfield_10100", Name: "Team", Required: true},
+		},
+		"Sub-task": {
+			{ID: "customfield_10100", Name: "Team", Required: true},
+			{ID: "customfield_10200", Name: "Environment", Required: true},
+		},
+	},
+}
+
+// TestValidateFields_UnknownAndNumber verifies unmapped names and non-numbers are reported offline
+func TestValidateFields_UnknownAndNumber(t *testing.T) {
+	tickets := []domain.Ticket{{
+		Title:        "Checkout",
+		CustomFields: map[string]string{"Priority": "High", "Story Points": "five", "Sprnt": "12"},
+		SourceLine:   3,
+		Tasks: []domain.Task{{
+			Title:        "API",
+			CustomFields: map[string]string{"Story Points": "2.5"},
+			SourceLine:   12,
+		}},
+	}}
+
+	errors := NewValidator().ValidateFields(tickets, schemaFieldMappings)
+
+	if len(errors) != 2 {
+		t.Fatalf("Expected 2 errors, got %d: %v", len(errors), errors)
+	}
+	if errors[0].Field != "Sprnt" || errors[0].Line != 3 || !strings.Contains(errors[0].Message, "not in field_mappings") {
+		t.Errorf("Expected the unknown field on line 3, got %+v", errors[0])
+	}
+	if errors[1].Field != "Story Points" || errors[1].Message != "'five' is not a number" {
+		t.Errorf("Expected the bad number, got %+v", errors[1])
+	}
+}
+
+// TestValidateFields_ReportsFieldLine verifies problems point at the line of the field, not the ticket heading
+func TestValidateFields_ReportsFieldLine(t *testing.T) {
+	tickets := []domain.Ticket{{
+		Title:        "Checkout",
+		CustomFields: map[string]string{"Sprnt": "12", "Story Points": "five"},
+		SourceLine:   3,
+		FieldLines:   map[string]int{"Sprnt": 7, "Story Points": 8},
+	}}
+
+	errors := NewValidator().ValidateFields(tickets, schemaFieldMappings)
+
+	if len(errors) != 2 || errors[0].Rule != RuleUnknownField || errors[0].Line != 7 || errors[1].Rule != RuleNumber || errors[1].Line != 8 {
+		t.Errorf("Expected the unknown field on line 7 and the bad number on line 8, got %+v", errors)
+	}
+}
+
+// TestValidateSchema_RequiredAndAllowedValues verifies checks against create metadata
+func TestValidateSchema_RequiredAndAllowedValues(t *testing.T) {
+	tickets := []domain.Ticket{{
+		Title: "Checkout",
+		CustomFields: map[string]string{
+			"Type":       "Story",
+			"Priority":   "Urgent",
+			"Components": "backend, Mobile",
+		},
+		SourceLine: 7,
+	}}
+
+	errors := NewValidator().ValidateSchema(tickets, schemaFieldMappings, testSchema)
+
+	want := map[string]string{
+		"Priority":   "'Urgent' is not an allowed value (High, Medium, Low)",
+		"Components": "'Mobile' is not an allowed value (Backend, Frontend)",
+		"Team":       "Required field 'Team' is missing or empty for issue type 'Story'",
+	}
+	if len(errors) != len(want) {
+		t.Fatalf("Expected %d errors, got %d: %v", len(want), len(errors), errors)
+	}
+	for _, err := range errors {
+		if err.Message != want[err.Field] {
+			t.Errorf("%s: expected %q, got %q", err.Field, want[err.Field], err.Message)
+		}
+		if err.Line != 7 {
+			t.Errorf("%s: expected line 7, got %d", err.Field, err.Line)
+		}
+	}
+}
+
+// TestValidateSchema_TasksInheritFields verifies tasks are checked as the subtask type with inherited fields
+func TestValidateSchema_TasksInheritFields(t *testing.T) {
+	tickets := []domain.Ticket{{
+		Title:        "Checkout",
+		CustomFields: map[string]string{"Type": "Story", "Team": "Payments"},
+		SourceLine:   1,
+		Tasks: []domain.Task{{
+			Title:      "API",
+			SourceLine: 9,
+		}},
+	}}
+
+	errors := NewValidator().ValidateSchema(tickets, schemaFieldMappings, testSchema)
+
+	if len(errors) != 1 {
+		t.Fatalf("Expected 1 error, got %d: %v", len(errors), errors)
+	}
+	want := "Required field 'Environment' is missing or empty for issue type 'Sub-task' (add customfield_10200 to field_mappings)"
+	if errors[0].Line != 9 || errors[0].Message != want {
+		t.Errorf("Expected %q on line 9, got %+v", want, errors[0])
+	}
+}
+
+// TestValidateSchema_UnknownIssueType verifies issue types the project lacks are reported
+func TestValidateSchema_UnknownIssueType(t *testing.T) {
+	tickets := []domain.Ticket{{
+		Title:        "Checkout",
+		CustomFields: map[string]string{"Type": "Feature"},
+		SourceLine:   4,
+	}}
+
+	errors := NewValidator().ValidateSchema(tickets, schemaFieldMappings, testSchema)
+
+	if len(errors) != 1 || errors[0].Field != "Type" || errors[0].Message != "Issue type 'Feature' does not exist in the project" {
+		t.Errorf("Expected the unknown issue type, got %v", errors)
+	}
+}
+
+// TestFieldSpecsFromCreateMeta verifies the adapter's metadata is converted and sorted
+func TestFieldSpecsFromCreateMeta(t *testing.T) {
+	meta := map[string]interface{}{
+		"issueType": "Story",
+		"fields": []map[string]interface{}{
+			{"key": "priority", "name": "Priority", "type": "priority", "allowedValues": []string{"High", "Low"}},
+			{"key": "customfield_10010", "name": "Story Points", "required": true, "type": "number"},
+			{"key": "reporter", "name": "Reporter", "required": true, "hasDefaultValue": true},
+		},
+	}
+
+	specs := FieldSpecsFromCreateMeta(meta)
+
+	if len(specs) != 3 || specs[0].ID != "customfield_10010" || specs[1].ID != "priority" || specs[2].ID != "reporter" {
+		t.Fatalf("Expected specs sorted by ID, got %+v", specs)
+	}
+	if !specs[0].Required || specs[0].Type != "number" {
+		t.Errorf("Expected a required number field, got %+v", specs[0])
+	}
+	if len(specs[1].AllowedValues) != 2 {
+		t.Errorf("Expected the allowed values, got %+v", specs[1])
+	}
+	if !specs[2].HasDefaultValue {
+		t.Errorf("Expected the default value flag, got %+v", specs[2])
+	}
+}
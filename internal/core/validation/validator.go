@@ -2,15 +2,19 @@ package validation
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/karolswdev/ticktr/internal/core/domain"
 )
 
 // ValidationError represents a validation error with context
 type ValidationError struct {
-	Field   string
-	Message string
-	Line    int
+	Field    string
+	Message  string
+	Line     int
+	Column   int      // 1-based, 0 when the problem is with the whole line or item
+	Rule     string   // ID of the rule that found the problem, e.g. RuleHierarchy
+	Severity Severity // Error when empty
 }
 
 func (v ValidationError) Error() string {
@@ -20,22 +24,24 @@ func (v ValidationError) Error() string {
 	return fmt.Sprintf("%s: %s", v.Field, v.Message)
 }
 
+// IsWarning reports whether the problem is reported without stopping push
+func (v ValidationError) IsWarning() bool {
+	return v.Severity == SeverityWarning
+}
+
 // Validator provides validation services for tickets
 type Validator struct {
-	hierarchyRules map[string][]string // Maps parent type to allowed child types
+	hierarchyRules map[string][]string // Maps lower-case parent type to allowed child types
+	requiredFields map[string][]string // Maps lower-case issue type to required fields
+	fieldRules     []compiledFieldRule
+	severities     map[string]Severity // Maps rule ID to severity
+	disabled       map[string]bool     // Rule IDs whose problems are not reported
 }
 
-// NewValidator creates a new validator instance
+// NewValidator creates a new validator instance with the default rules
 func NewValidator() *Validator {
-	return &Validator{
-		hierarchyRules: map[string][]string{
-			"Epic":    {"Story", "Task", "Bug"},
-			"Story":   {"Sub-task", "Task"},
-			"Task":    {"Sub-task"},
-			"Bug":     {"Sub-task"},
-			"Feature": {"Sub-task", "Task"},
-		},
-	}
+	v, _ := NewValidatorWithRules(DefaultRules())
+	return v
 }
 
 // ValidateHierarchy validates ticket hierarchy rules
@@ -56,11 +62,11 @@ func (v *Validator) ValidateHierarchy(tickets []domain.Ticket) []ValidationError
 	for _, ticket := range tickets {
 		parentType := ticket.CustomFields["Type"]
 		if parentType == "" {
-			parentType = "Story" // Default type
+			parentType = defaultTicketType
 		}
 
 		// Check if parent type has hierarchy rules
-		allowedChildTypes, hasRules := v.hierarchyRules[parentType]
+		allowedChildTypes, hasRules := v.hierarchyRules[strings.ToLower(parentType)]
 		if !hasRules {
 			continue // No rules for this parent type
 		}
@@ -69,23 +75,15 @@ func (v *Validator) ValidateHierarchy(tickets []domain.Ticket) []ValidationError
 		for _, task := range ticket.Tasks {
 			childType := task.CustomFields["Type"]
 			if childType == "" {
-				childType = "Sub-task" // Default child type
+				childType = defaultTaskType
 			}
 
-			// Check if child type is allowed
-			allowed := false
-			for _, allowedType := range allowedChildTypes {
-				if childType == allowedType {
-					allowed = true
-					break
-				}
-			}
-
-			if !allowed {
-				errors = append(errors, ValidationError{
+			if !containsFold(allowedChildTypes, childType) {
+				errors = v.add(errors, ValidationError{
 					Field:   fmt.Sprintf("Task '%s'", task.Title),
 					Message: fmt.Sprintf("A '%s' cannot be the child of a '%s'", childType, parentType),
 					Line:    task.SourceLine,
+					Rule:    RuleHierarchy,
 				})
 			}
 		}
@@ -100,20 +98,22 @@ func (v *Validator) ValidateRequiredFields(ticket domain.Ticket, requiredFields
 
 	// Check title
 	if ticket.Title == "" {
-		errors = append(errors, ValidationError{
+		errors = v.add(errors, ValidationError{
 			Field:   "Title",
 			Message: "Title is required",
 			Line:    ticket.SourceLine,
+			Rule:    RuleTitleRequired,
 		})
 	}
 
 	// Check custom required fields
 	for _, field := range requiredFields {
 		if value, exists := ticket.CustomFields[field]; !exists || value == "" {
-			errors = append(errors, ValidationError{
+			errors = v.add(errors, ValidationError{
 				Field:   field,
 				Message: fmt.Sprintf("Required field '%s' is missing or empty", field),
 				Line:    ticket.SourceLine,
+				Rule:    RuleRequiredFields,
 			})
 		}
 	}
@@ -129,14 +129,18 @@ func (v *Validator) ValidateTickets(tickets []domain.Ticket) []ValidationError {
 	hierarchyErrors := v.ValidateHierarchy(tickets)
 	allErrors = append(allErrors, hierarchyErrors...)
 
+	// Validate the configured required fields and field rules
+	allErrors = append(allErrors, v.ValidateRules(tickets)...)
+
 	// Validate each ticket's required fields (basic validation)
 	for _, ticket := range tickets {
 		// Basic required fields check (title is always required)
 		if ticket.Title == "" {
-			allErrors = append(allErrors, ValidationError{
+			allErrors = v.add(allErrors, ValidationError{
 				Field:   "Title",
 				Message: "Title is required",
 				Line:    ticket.SourceLine,
+				Rule:    RuleTitleRequired,
 			})
 		}
 	}
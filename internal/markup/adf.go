@@ -0,0 +1,361 @@
+package markup
+
+import "strings"
+
+// ADF documents are handled as generic JSON maps so that documents built for a
+// request and documents decoded from a response have the same shape.
+
+// MarkdownToADF converts Markdown text to an Atlassian Document Format document
+func MarkdownToADF(text string) map[string]interface{} {
+	return ToADF(ParseMarkdown(text))
+}
+
+// ADFToMarkdown converts an Atlassian Document Format document to Markdown text
+func ADFToMarkdown(doc interface{}) string {
+	return RenderMarkdown(FromADF(doc))
+}
+
+// ToADF builds an Atlassian Document Format document from blocks
+func ToADF(blocks []Block) map[string]interface{} {
+	return map[string]interface{}{
+		"type":    "doc",
+		"version": 1,
+		"content": adfBlocks(blocks),
+	}
+}
+
+// adfBlocks converts blocks to ADF block nodes
+func adfBlocks(blocks []Block) []interface{} {
+	nodes := make([]interface{}, 0, len(blocks))
+	for _, block := range blocks {
+		nodes = append(nodes, adfBlock(block))
+	}
+	return nodes
+}
+
+// adfBlock converts a single block to an ADF node
+func adfBlock(block Block) map[string]interface{} {
+	switch block.Kind {
+	case Heading:
+		return map[string]interface{}{
+			"type":    "heading",
+			"attrs":   map[string]interface{}{"level": clampLevel(block.Level)},
+			"content": adfInline(block.Spans),
+		}
+
+	case CodeBlock:
+		node := map[string]interface{}{"type": "codeBlock", "content": []interface{}{}}
+		if block.Language != "" {
+			node["attrs"] = map[string]interface{}{"language": block.Language}
+		}
+		if block.Text != "" {
+			node["content"] = []interface{}{map[string]interface{}{"type": "text", "text": block.Text}}
+		}
+		return node
+
+	case BulletList, OrderedList:
+		items := make([]interface{}, 0, len(block.Items))
+		for _, item := range block.Items {
+			content := adfBlocks(item)
+			if len(content) == 0 {
+				// List items must not be empty
+				content = []interface{}{adfBlock(Block{Kind: Paragraph})}
+			}
+			items = append(items, map[string]interface{}{"type": "listItem", "content": content})
+		}
+		if block.Kind == BulletList {
+			return map[string]interface{}{"type": "bulletList", "content": items}
+		}
+		return map[string]interface{}{
+			"type":    "orderedList",
+			"attrs":   map[string]interface{}{"order": listStart(block)},
+			"content": items,
+		}
+
+	case Table:
+		rows := make([]interface{}, 0, len(block.Rows))
+		for r, row := range block.Rows {
+			cellType := "tableCell"
+			if r == 0 {
+				cellType = "tableHeader"
+			}
+			cells := make([]interface{}, 0, len(row))
+			for _, cell := range row {
+				cells = append(cells, map[string]interface{}{
+					"type":    cellType,
+					"content": []interface{}{adfBlock(Block{Kind: Paragraph, Spans: cell})},
+				})
+			}
+			rows = append(rows, map[string]interface{}{"type": "tableRow", "content": cells})
+		}
+		return map[string]interface{}{"type": "table", "content": rows}
+
+	case Quote:
+		return map[string]interface{}{"type": "blockquote", "content": adfBlocks(block.Children)}
+
+	case Rule:
+		return map[string]interface{}{"type": "rule"}
+
+	default:
+		return map[string]interface{}{"type": "paragraph", "content": adfInline(block.Spans)}
+	}
+}
+
+// adfInline converts spans to ADF text and hardBreak nodes
+func adfInline(spans []Span) []interface{} {
+	nodes := make([]interface{}, 0, len(spans))
+	for _, span := range spans {
+		if span.Break {
+			nodes = append(nodes, map[string]interface{}{"type": "hardBreak"})
+			continue
+		}
+
+		var marks []interface{}
+		if span.Marks&Code != 0 {
+			// ADF only allows links alongside the code mark
+			marks = append(marks, map[string]interface{}{"type": "code"})
+		} else {
+			for _, m := range []struct {
+				mark Mark
+				name string
+			}{{Strong, "strong"}, {Emphasis, "em"}, {Strike, "strike"}} {
+				if span.Marks&m.mark != 0 {
+					marks = append(marks, map[string]interface{}{"type": m.name})
+				}
+			}
+		}
+		if span.Href != "" {
+			marks = append(marks, map[string]interface{}{
+				"type":  "link",
+				"attrs": map[string]interface{}{"href": span.Href},
+			})
+		}
+
+		node := map[string]interface{}{"type": "text", "text": span.Text}
+		if len(marks) > 0 {
+			node["marks"] = marks
+		}
+		nodes = append(nodes, node)
+	}
+	return nodes
+}
+
+// FromADF converts an Atlassian Document Format document, as decoded from
+// JSON, to blocks. Nodes without a Markdown equivalent keep their text content.
+func FromADF(doc interface{}) []Block {
+	node, ok := doc.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+	if adfType(node) == "doc" {
+		return trimBlocks(blocksFromADF(adfContent(node)))
+	}
+	return trimBlocks(blocksFromADF([]interface{}{node}))
+}
+
+// blocksFromADF converts ADF block nodes to blocks
+func blocksFromADF(nodes []interface{}) []Block {
+	var blocks []Block
+	var pending []Span // Inline nodes found directly among block nodes
+
+	flushPending := func() {
+		if len(pending) > 0 {
+			blocks = append(blocks, Block{Kind: Paragraph, Spans: pending})
+			pending = nil
+		}
+	}
+
+	for _, raw := range nodes {
+		node, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		switch adfType(node) {
+		case "text", "hardBreak", "mention", "emoji", "inlineCard", "date", "status":
+			for _, span := range spansFromADF([]interface{}{node}) {
+				pending = appendSpan(pending, span)
+			}
+			continue
+		}
+		flushPending()
+
+		switch adfType(node) {
+		case "paragraph":
+			blocks = append(blocks, Block{Kind: Paragraph, Spans: spansFromADF(adfContent(node))})
+
+		case "heading":
+			level := adfNumber(adfAttrs(node)["level"])
+			blocks = append(blocks, Block{Kind: Heading, Level: clampLevel(level), Spans: spansFromADF(adfContent(node))})
+
+		case "codeBlock":
+			language, _ := adfAttrs(node)["language"].(string)
+			blocks = append(blocks, Block{Kind: CodeBlock, Language: language, Text: PlainText(spansFromADF(adfContent(node)))})
+
+		case "bulletList", "orderedList":
+			list := Block{Kind: BulletList}
+			if adfType(node) == "orderedList" {
+				list.Kind = OrderedList
+				list.Start = adfNumber(adfAttrs(node)["order"])
+			}
+			for _, item := range adfContent(node) {
+				if itemNode, ok := item.(map[string]interface{}); ok {
+					list.Items = append(list.Items, blocksFromADF(adfContent(itemNode)))
+				}
+			}
+			blocks = append(blocks, list)
+
+		case "table":
+			table := Block{Kind: Table}
+			for _, row := range adfContent(node) {
+				rowNode, ok := row.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				var cells [][]Span
+				for _, cell := range adfContent(rowNode) {
+					cellNode, ok := cell.(map[string]interface{})
+					if !ok {
+						continue
+					}
+					var spans []Span
+					for i, b := range blocksFromADF(adfContent(cellNode)) {
+						if i > 0 {
+							spans = append(spans, Span{Break: true})
+						}
+						spans = append(spans, b.Spans...)
+					}
+					cells = append(cells, spans)
+				}
+				table.Rows = append(table.Rows, cells)
+			}
+			blocks = append(blocks, table)
+
+		case "blockquote", "panel":
+			blocks = append(blocks, Block{Kind: Quote, Children: blocksFromADF(adfContent(node))})
+
+		case "rule":
+			blocks = append(blocks, Block{Kind: Rule})
+
+		default:
+			// Containers such as expand keep their content; media has none to keep
+			blocks = append(blocks, blocksFromADF(adfContent(node))...)
+		}
+	}
+	flushPending()
+
+	return blocks
+}
+
+// spansFromADF converts ADF inline nodes to spans
+func spansFromADF(nodes []interface{}) []Span {
+	var spans []Span
+	for _, raw := range nodes {
+		node, ok := raw.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		attrs := adfAttrs(node)
+
+		switch adfType(node) {
+		case "text":
+			text, _ := node["text"].(string)
+			span := Span{Text: text}
+			for _, rawMark := range adfSlice(node["marks"]) {
+				mark, ok := rawMark.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				switch adfType(mark) {
+				case "strong":
+					span.Marks |= Strong
+				case "em":
+					span.Marks |= Emphasis
+				case "strike":
+					span.Marks |= Strike
+				case "code":
+					span.Marks |= Code
+				case "link":
+					span.Href, _ = adfAttrs(mark)["href"].(string)
+				}
+			}
+			spans = appendSpan(spans, span)
+
+		case "hardBreak":
+			spans = append(spans, Span{Break: true})
+
+		case "mention", "emoji", "status":
+			text, _ := attrs["text"].(string)
+			if text == "" {
+				text, _ = attrs["shortName"].(string)
+			}
+			spans = appendSpan(spans, Span{Text: text})
+
+		case "inlineCard":
+			url, _ := attrs["url"].(string)
+			spans = appendSpan(spans, Span{Text: url, Href: url})
+
+		case "date":
+			text, _ := attrs["timestamp"].(string)
+			spans = appendSpan(spans, Span{Text: text})
+
+		default:
+			for _, span := range spansFromADF(adfContent(node)) {
+				spans = appendSpan(spans, span)
+			}
+		}
+	}
+	return spans
+}
+
+// adfType returns the type of an ADF node or mark
+func adfType(node map[string]interface{}) string {
+	t, _ := node["type"].(string)
+	return t
+}
+
+// adfContent returns the child nodes of an ADF node
+func adfContent(node map[string]interface{}) []interface{} {
+	return adfSlice(node["content"])
+}
+
+// adfAttrs returns the attributes of an ADF node or mark
+func adfAttrs(node map[string]interface{}) map[string]interface{} {
+	attrs, _ := node["attrs"].(map[string]interface{})
+	return attrs
+}
+
+// adfSlice returns a JSON array value as a slice
+func adfSlice(value interface{}) []interface{} {
+	items, _ := value.([]interface{})
+	return items
+}
+
+// adfNumber returns a numeric attribute decoded from JSON or set in Go
+func adfNumber(value interface{}) int {
+	switch v := value.(type) {
+	case float64:
+		return int(v)
+	case int:
+		return v
+	}
+	return 0
+}
+
+// IsADF reports whether a field value is an Atlassian Document Format document
+func IsADF(value interface{}) bool {
+	node, ok := value.(map[string]interface{})
+	return ok && adfType(node) == "doc"
+}
+
+// trimBlocks drops empty paragraphs, which ADF editors leave behind
+func trimBlocks(blocks []Block) []Block {
+	result := blocks[:0]
+	for _, block := range blocks {
+		if block.Kind == Paragraph && strings.TrimSpace(PlainText(block.Spans)) == "" {
+			continue
+		}
+		result = append(result, block)
+	}
+	return result
+}
@@ -0,0 +1,108 @@
+package markup
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// decodeADF passes a document through JSON, as it would travel to and from Jira
+func decodeADF(t *testing.T, doc map[string]interface{}) interface{} {
+	t.Helper()
+	data, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Failed to encode ADF: %v", err)
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Failed to decode ADF: %v", err)
+	}
+	return decoded
+}
+
+// TestADF_RoundTrip verifies Markdown converted to ADF and back is unchanged
+func TestADF_RoundTrip(t *testing.T) {
+	text := "Intro with **bold**, *em*, ~~old~~, `code` and a [link](https://example.com).\nSecond line\n\n" +
+		"## Details\n\n" +
+		"- one\n- two\n  - nested\n    1. deep\n\n" +
+		"3. third\n4. fourth\n\n" +
+		"```go\nfmt.Println(\"hi\")\n```\n\n" +
+		"| Name | Value |\n| --- | --- |\n| a | `b` |\n\n" +
+		"> quoted\n\n" +
+		"---"
+
+	doc := decodeADF(t, MarkdownToADF(text))
+	if !IsADF(doc) {
+		t.Fatalf("Expected an ADF document, got %#v", doc)
+	}
+	if got := ADFToMarkdown(doc); got != text {
+		t.Errorf("Round trip changed the text:\ngot:\n%s\nwant:\n%s", got, text)
+	}
+}
+
+// TestToADF_NodeShapes verifies the generated nodes follow the ADF schema
+func TestToADF_NodeShapes(t *testing.T) {
+	doc := MarkdownToADF("# Title\n\n[`cmd`](https://example.com)\n\n-")
+
+	if doc["version"] != 1 {
+		t.Errorf("Expected version 1, got %v", doc["version"])
+	}
+	content := adfSlice(doc["content"])
+	if len(content) != 3 {
+		t.Fatalf("Expected 3 blocks, got %d", len(content))
+	}
+
+	heading := content[0].(map[string]interface{})
+	if adfType(heading) != "heading" || adfAttrs(heading)["level"] != 1 {
+		t.Errorf("Unexpected heading node: %#v", heading)
+	}
+
+	text := adfContent(content[1].(map[string]interface{}))[0].(map[string]interface{})
+	marks := adfSlice(text["marks"])
+	if len(marks) != 2 || adfType(marks[0].(map[string]interface{})) != "code" || adfType(marks[1].(map[string]interface{})) != "link" {
+		t.Errorf("Expected code and link marks, got %#v", marks)
+	}
+
+	item := adfContent(content[2].(map[string]interface{}))[0].(map[string]interface{})
+	if len(adfContent(item)) != 1 {
+		t.Errorf("Expected an empty list item to hold a paragraph, got %#v", item)
+	}
+}
+
+// TestFromADF_JiraNodes verifies nodes without a Markdown equivalent keep their text
+func TestFromADF_JiraNodes(t *testing.T) {
+	raw := `{"type":"doc","version":1,"content":[
+		{"type":"paragraph","content":[
+			{"type":"mention","attrs":{"id":"1","text":"@Alice"}},
+			{"type":"text","text":" see "},
+			{"type":"inlineCard","attrs":{"url":"https://example.com/x"}},
+			{"type":"text","text":" "},
+			{"type":"emoji","attrs":{"shortName":":smile:"}}
+		]},
+		{"type":"paragraph","content":[]},
+		{"type":"panel","attrs":{"panelType":"info"},"content":[
+			{"type":"paragraph","content":[{"type":"text","text":"Heads up"}]}
+		]},
+		{"type":"expand","content":[
+			{"type":"paragraph","content":[{"type":"text","text":"Hidden"}]}
+		]}
+	]}`
+	var doc interface{}
+	if err := json.Unmarshal([]byte(raw), &doc); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "@Alice see [https://example.com/x](https://example.com/x) :smile:\n\n> Heads up\n\nHidden"
+	if got := ADFToMarkdown(doc); got != want {
+		t.Errorf("ADFToMarkdown() =\n%s\nwant\n%s", got, want)
+	}
+}
+
+// TestIsADF verifies only document nodes are recognised
+func TestIsADF(t *testing.T) {
+	if IsADF("text") || IsADF(map[string]interface{}{"type": "paragraph"}) || IsADF(nil) {
+		t.Error("Expected non-documents to be rejected")
+	}
+	if !IsADF(map[string]interface{}{"type": "doc", "content": []interface{}{}}) {
+		t.Error("Expected a doc node to be recognised")
+	}
+}
@@ -0,0 +1,759 @@
+package markup
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var (
+	headingPattern        = regexp.MustCompile(`^\s{0,3}(#{1,6})\s+(.*)$`)
+	listItemPattern       = regexp.MustCompile(`^(\s*)([-*+]|\d{1,9}[.)])(?:\s+(.*))?$`)
+	rulePattern           = regexp.MustCompile(`^\s{0,3}(?:(?:-\s*){3,}|(?:\*\s*){3,}|(?:_\s*){3,})$`)
+	fencePattern          = regexp.MustCompile("^\\s*(```+|~~~+)\\s*([^`\\s]*)")
+	tableSeparatorPattern = regexp.MustCompile(`^\s*\|?\s*:?-+:?\s*(\|\s*:?-+:?\s*)*\|?\s*$`)
+)
+
+// ParseMarkdown parses Markdown text into blocks
+func ParseMarkdown(text string) []Block {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return parseBlocks(strings.Split(text, "\n"))
+}
+
+// parseBlocks parses lines of Markdown into blocks
+func parseBlocks(lines []string) []Block {
+	var blocks []Block
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			i++
+
+		case fencePattern.MatchString(line):
+			m := fencePattern.FindStringSubmatch(line)
+			var code []string
+			i++
+			for i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), m[1]) {
+				code = append(code, lines[i])
+				i++
+			}
+			i++ // Skip the closing fence
+			blocks = append(blocks, Block{Kind: CodeBlock, Language: m[2], Text: strings.Join(code, "\n")})
+
+		case headingPattern.MatchString(line):
+			m := headingPattern.FindStringSubmatch(line)
+			blocks = append(blocks, Block{Kind: Heading, Level: len(m[1]), Spans: ParseInline(strings.TrimSpace(m[2]))})
+			i++
+
+		case rulePattern.MatchString(line):
+			blocks = append(blocks, Block{Kind: Rule})
+			i++
+
+		case strings.HasPrefix(trimmed, ">"):
+			var quoted []string
+			for i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">") {
+				content := strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")
+				quoted = append(quoted, strings.TrimPrefix(content, " "))
+				i++
+			}
+			blocks = append(blocks, Block{Kind: Quote, Children: parseBlocks(quoted)})
+
+		case isTableStart(lines, i):
+			var rows [][][]Span
+			rows = append(rows, parseTableRow(line))
+			i += 2 // Skip the header separator
+			for i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|") {
+				rows = append(rows, parseTableRow(lines[i]))
+				i++
+			}
+			blocks = append(blocks, Block{Kind: Table, Rows: rows})
+
+		case listItemPattern.MatchString(line):
+			var list Block
+			list, i = parseList(lines, i)
+			blocks = append(blocks, list)
+
+		default:
+			var text []string
+			for i < len(lines) && strings.TrimSpace(lines[i]) != "" && (len(text) == 0 || !startsBlock(lines, i)) {
+				text = append(text, strings.TrimSpace(lines[i]))
+				i++
+			}
+			blocks = append(blocks, Block{Kind: Paragraph, Spans: ParseInline(strings.Join(text, "\n"))})
+		}
+	}
+
+	return blocks
+}
+
+// startsBlock reports whether the line at i starts a block other than a paragraph
+func startsBlock(lines []string, i int) bool {
+	line := lines[i]
+	return fencePattern.MatchString(line) ||
+		headingPattern.MatchString(line) ||
+		rulePattern.MatchString(line) ||
+		strings.HasPrefix(strings.TrimSpace(line), ">") ||
+		isTableStart(lines, i) ||
+		listItemPattern.MatchString(line)
+}
+
+// isTableStart reports whether a pipe table header starts at line i
+func isTableStart(lines []string, i int) bool {
+	return strings.HasPrefix(strings.TrimSpace(lines[i]), "|") &&
+		i+1 < len(lines) && strings.Contains(lines[i+1], "-") &&
+		tableSeparatorPattern.MatchString(lines[i+1])
+}
+
+// parseTableRow splits a pipe table row into cells, honouring escaped pipes
+func parseTableRow(line string) [][]Span {
+	line = strings.TrimSpace(line)
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+
+	var cells [][]Span
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteString(`\|`)
+			i++
+		case line[i] == '|':
+			cells = append(cells, ParseInline(strings.TrimSpace(cell.String())))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, ParseInline(strings.TrimSpace(cell.String())))
+}
+
+// parseList parses the list starting at line i and returns it with the index
+// of the first line after it
+func parseList(lines []string, i int) (Block, int) {
+	first := listItemPattern.FindStringSubmatch(lines[i])
+	indent := indentWidth(first[1])
+	ordered := isOrderedMarker(first[2])
+
+	list := Block{Kind: BulletList}
+	if ordered {
+		list.Kind = OrderedList
+		list.Start, _ = strconv.Atoi(strings.TrimRight(first[2], ".)"))
+	}
+
+	var item []string
+	contentOffset := 0
+	flush := func() {
+		if item != nil {
+			list.Items = append(list.Items, parseBlocks(item))
+		}
+	}
+
+	for i < len(lines) {
+		line := lines[i]
+
+		if strings.TrimSpace(line) == "" {
+			// A blank line continues the list only if more of it follows
+			next := i + 1
+			for next < len(lines) && strings.TrimSpace(lines[next]) == "" {
+				next++
+			}
+			if next < len(lines) && (indentWidth(leadingSpace(lines[next])) > indent || isSiblingItem(lines[next], indent, ordered)) {
+				item = append(item, "")
+				i++
+				continue
+			}
+			break
+		}
+
+		if isSiblingItem(line, indent, ordered) {
+			m := listItemPattern.FindStringSubmatch(line)
+			flush()
+			item = []string{m[3]}
+			contentOffset = indent + len(m[2]) + 1
+			i++
+			continue
+		}
+
+		if indentWidth(leadingSpace(line)) > indent {
+			item = append(item, dedent(line, contentOffset))
+			i++
+			continue
+		}
+
+		// Lazy continuation of the item's paragraph
+		if last := item[len(item)-1]; strings.TrimSpace(last) != "" && !startsBlock(lines, i) {
+			item = append(item, strings.TrimSpace(line))
+			i++
+			continue
+		}
+
+		break
+	}
+	flush()
+
+	return list, i
+}
+
+// isSiblingItem reports whether line is a list item of the same kind at the given indent
+func isSiblingItem(line string, indent int, ordered bool) bool {
+	m := listItemPattern.FindStringSubmatch(line)
+	if m == nil || rulePattern.MatchString(line) {
+		return false
+	}
+	return indentWidth(m[1]) == indent && isOrderedMarker(m[2]) == ordered
+}
+
+// isOrderedMarker reports whether a list marker is numbered
+func isOrderedMarker(marker string) bool {
+	return marker[0] >= '0' && marker[0] <= '9'
+}
+
+// leadingSpace returns the leading whitespace of a line
+func leadingSpace(line string) string {
+	return line[:len(line)-len(strings.TrimLeft(line, " \t"))]
+}
+
+// indentWidth returns the width of leading whitespace, counting tabs as four spaces
+func indentWidth(space string) int {
+	return len(strings.ReplaceAll(space, "\t", "    "))
+}
+
+// dedent removes up to n columns of leading whitespace from a line
+func dedent(line string, n int) string {
+	line = strings.Replace(line, "\t", "    ", 1)
+	i := 0
+	for i < len(line) && i < n && line[i] == ' ' {
+		i++
+	}
+	return line[i:]
+}
+
+// ParseInline parses Markdown inline syntax (emphasis, code, links and line
+// breaks) into spans
+func ParseInline(text string) []Span {
+	return parseInline(text, 0, "")
+}
+
+// parseInline parses text whose spans all carry the given marks and link target
+func parseInline(text string, marks Mark, href string) []Span {
+	var spans []Span
+	var buf strings.Builder
+
+	flush := func() {
+		spans = appendSpan(spans, Span{Text: buf.String(), Marks: marks, Href: href})
+		buf.Reset()
+	}
+	nested := func(inner []Span) {
+		flush()
+		for _, span := range inner {
+			spans = appendSpan(spans, span)
+		}
+	}
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		rest := text[i:]
+
+		if c == '\\' && i+1 < len(text) && strings.IndexByte(escapable, text[i+1]) >= 0 {
+			buf.WriteByte(text[i+1])
+			i += 2
+			continue
+		}
+
+		if c == '\n' {
+			flush()
+			spans = append(spans, Span{Break: true})
+			i++
+			continue
+		}
+
+		if c == '`' {
+			n := len(rest) - len(strings.TrimLeft(rest, "`"))
+			if end := strings.Index(rest[n:], rest[:n]); end >= 0 {
+				code := rest[n : n+end]
+				if len(code) > 1 && code[0] == ' ' && code[len(code)-1] == ' ' {
+					code = code[1 : len(code)-1]
+				}
+				flush()
+				spans = appendSpan(spans, Span{Text: code, Marks: marks | Code, Href: href})
+				i += 2*n + end
+				continue
+			}
+			buf.WriteString(rest[:n])
+			i += n
+			continue
+		}
+
+		if delim, mark := emphasisDelimiter(text, i); delim != "" {
+			if end := findClosing(text, i+len(delim), delim); end >= 0 {
+				nested(parseInline(text[i+len(delim):end], marks|mark, href))
+				i = end + len(delim)
+				continue
+			}
+		}
+
+		if c == '[' {
+			if label, target, n := parseLink(rest); n > 0 {
+				nested(parseInline(label, marks, target))
+				i += n
+				continue
+			}
+		}
+
+		buf.WriteByte(c)
+		i++
+	}
+	flush()
+
+	return spans
+}
+
+// escapable lists the characters a backslash escapes
+const escapable = "\\`*_{}[]()#+-.!|~>"
+
+// emphasisDelimiter returns the emphasis delimiter opening at i, if any
+func emphasisDelimiter(text string, i int) (string, Mark) {
+	rest := text[i:]
+	var delim string
+	var mark Mark
+	switch {
+	case strings.HasPrefix(rest, "**"), strings.HasPrefix(rest, "__"):
+		delim, mark = rest[:2], Strong
+	case strings.HasPrefix(rest, "~~"):
+		delim, mark = "~~", Strike
+	case rest[0] == '*' || rest[0] == '_':
+		delim, mark = rest[:1], Emphasis
+	default:
+		return "", 0
+	}
+
+	// An opener must be followed by text, and underscores inside words are literal
+	if len(rest) <= len(delim) || rest[len(delim)] == ' ' || rest[len(delim)] == '\n' {
+		return "", 0
+	}
+	if delim[0] == '_' && i > 0 && isWordChar(text[i-1]) {
+		return "", 0
+	}
+	return delim, mark
+}
+
+// findClosing returns the index of the delimiter closing an emphasis opened
+// before from, or -1. Code spans and escapes are skipped.
+func findClosing(text string, from int, delim string) int {
+	for j := from; j < len(text); j++ {
+		switch text[j] {
+		case '\\':
+			j++
+			continue
+		case '`':
+			n := len(text[j:]) - len(strings.TrimLeft(text[j:], "`"))
+			if end := strings.Index(text[j+n:], text[j:j+n]); end >= 0 {
+				j += 2*n + end - 1
+			}
+			continue
+		}
+
+		if !strings.HasPrefix(text[j:], delim) || j == from || text[j-1] == ' ' {
+			continue
+		}
+
+		if len(delim) == 1 && j+1 < len(text) && text[j+1] == delim[0] {
+			// A doubled delimiter is strong emphasis nested inside this one
+			if end := findClosing(text, j+2, delim+delim); end >= 0 {
+				j = end + 1
+				continue
+			}
+		}
+		if len(delim) == 1 && delim[0] == '_' && j+1 < len(text) && isWordChar(text[j+1]) {
+			continue
+		}
+		if len(delim) == 2 && j+2 < len(text) && text[j+2] == delim[0] {
+			// "***" closes the inner emphasis first
+			return j + 1
+		}
+		return j
+	}
+	return -1
+}
+
+// parseLink parses a [label](target) link at the start of text and returns
+// its parts and length, or zero length when text does not start with a link
+func parseLink(text string) (string, string, int) {
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		switch text[i] {
+		case '\\':
+			i++
+		case '[':
+			depth++
+		case ']':
+			depth--
+			if depth == 0 {
+				if i+1 >= len(text) || text[i+1] != '(' {
+					return "", "", 0
+				}
+				end := strings.IndexByte(text[i+2:], ')')
+				if end < 0 {
+					return "", "", 0
+				}
+				target := strings.TrimSpace(text[i+2 : i+2+end])
+				if target == "" || strings.ContainsAny(target, " \n") {
+					return "", "", 0
+				}
+				return text[1:i], target, i + 3 + end
+			}
+		}
+	}
+	return "", "", 0
+}
+
+// isWordChar reports whether c is a letter or digit
+func isWordChar(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9'
+}
+
+// RenderMarkdown renders blocks as Markdown text
+func RenderMarkdown(blocks []Block) string {
+	return joinBlocks(blocks, false, renderMarkdownBlock)
+}
+
+// joinBlocks renders blocks separated by blank lines. Within a list item
+// (nested) a sub-list directly follows the text before it.
+func joinBlocks(blocks []Block, nested bool, render func(Block) string) string {
+	var sb strings.Builder
+	for i, block := range blocks {
+		if i > 0 {
+			sb.WriteString("\n")
+			if !nested || (block.Kind != BulletList && block.Kind != OrderedList) {
+				sb.WriteString("\n")
+			}
+		}
+		sb.WriteString(render(block))
+	}
+	return sb.String()
+}
+
+// renderMarkdownBlock renders a single block as Markdown
+func renderMarkdownBlock(block Block) string {
+	switch block.Kind {
+	case Heading:
+		return strings.Repeat("#", clampLevel(block.Level)) + " " + RenderInline(block.Spans)
+
+	case CodeBlock:
+		return "```" + block.Language + "\n" + block.Text + "\n```"
+
+	case BulletList, OrderedList:
+		var lines []string
+		for i, item := range block.Items {
+			marker := "- "
+			if block.Kind == OrderedList {
+				marker = fmt.Sprintf("%d. ", listStart(block)+i)
+			}
+			body := joinBlocks(item, true, renderMarkdownBlock)
+			lines = append(lines, marker+indentLines(body, strings.Repeat(" ", len(marker))))
+		}
+		return strings.Join(lines, "\n")
+
+	case Table:
+		var lines []string
+		for i, row := range block.Rows {
+			cells := make([]string, len(row))
+			for c, cell := range row {
+				cells[c] = strings.ReplaceAll(RenderInline(withoutBreaks(cell)), "|", `\|`)
+			}
+			lines = append(lines, "| "+strings.Join(cells, " | ")+" |")
+			if i == 0 {
+				lines = append(lines, "|"+strings.Repeat(" --- |", len(row)))
+			}
+		}
+		return strings.Join(lines, "\n")
+
+	case Quote:
+		lines := strings.Split(RenderMarkdown(block.Children), "\n")
+		for i, line := range lines {
+			if line == "" {
+				lines[i] = ">"
+			} else {
+				lines[i] = "> " + line
+			}
+		}
+		return strings.Join(lines, "\n")
+
+	case Rule:
+		return "---"
+
+	default:
+		return escapeBlockStarts(RenderInline(block.Spans))
+	}
+}
+
+// escapeBlockStarts escapes the start of each line of a paragraph that would
+// otherwise read as a block, such as "# ", "- " or "1. "
+func escapeBlockStarts(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if !startsBlock(lines, i) {
+			continue
+		}
+		trimmed := strings.TrimLeft(line, " ")
+		// A numbered item is escaped after its number
+		at := len(line) - len(strings.TrimLeft(trimmed, "0123456789"))
+		lines[i] = line[:at] + "\\" + line[at:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// indentLines indents every line after the first, leaving blank lines empty
+func indentLines(text, indent string) string {
+	lines := strings.Split(text, "\n")
+	for i := 1; i < len(lines); i++ {
+		if lines[i] != "" {
+			lines[i] = indent + lines[i]
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// withoutBreaks replaces line breaks with spaces, for formats that cannot hold them
+func withoutBreaks(spans []Span) []Span {
+	result := make([]Span, 0, len(spans))
+	for _, span := range spans {
+		if span.Break {
+			span = Span{Text: " "}
+		}
+		result = appendSpan(result, span)
+	}
+	return result
+}
+
+// listStart returns the first number of an ordered list
+func listStart(block Block) int {
+	if block.Start < 1 {
+		return 1
+	}
+	return block.Start
+}
+
+// clampLevel keeps a heading level within 1-6
+func clampLevel(level int) int {
+	if level < 1 {
+		return 1
+	}
+	if level > 6 {
+		return 6
+	}
+	return level
+}
+
+// markSyntax is how a format writes one inline mark
+type markSyntax struct {
+	mark        Mark
+	open, close string
+}
+
+// inlineSyntax describes how a format writes styled spans
+type inlineSyntax struct {
+	marks               []markSyntax // Outermost first; Code is written by code
+	openLink, closeLink func(href string) string
+	code                func(text string) string
+	lineBreak           string
+	escapable           string                    // Characters a backslash escapes in text
+	parse               func(text string) []Span  // Reads the format back, to decide what to escape
+	braced              func(delim string) string // Writes a delimiter touching a word character; nil when delimiters may touch words
+}
+
+// markdownInline is the Markdown inline syntax
+var markdownInline = inlineSyntax{
+	marks: []markSyntax{
+		{Strong, "**", "**"},
+		{Emphasis, "*", "*"},
+		{Strike, "~~", "~~"},
+	},
+	openLink:  func(string) string { return "[" },
+	closeLink: func(href string) string { return "](" + href + ")" },
+	code:      markdownCode,
+	lineBreak: "\n",
+	escapable: "\\`*_[]~",
+	parse:     ParseInline,
+}
+
+// RenderInline renders spans as Markdown inline text
+func RenderInline(spans []Span) string {
+	return renderInline(spans, markdownInline)
+}
+
+// markdownCode renders an inline code span, widening the delimiter when the
+// code itself contains backticks
+func markdownCode(text string) string {
+	if !strings.Contains(text, "`") {
+		return "`" + text + "`"
+	}
+	return "`` " + text + " ``"
+}
+
+// renderInline renders spans in a format. Text is written as is where it
+// reads back unchanged, so ordinary punctuation is not cluttered with escapes;
+// otherwise only the characters that would read as markup are escaped.
+func renderInline(spans []Span, syntax inlineSyntax) string {
+	var normalized []Span
+	for _, span := range spans {
+		normalized = appendSpan(normalized, span)
+	}
+
+	text := writeInline(normalized, syntax, nil)
+	if syntax.parse == nil || spansEqual(syntax.parse(text), normalized) {
+		return text
+	}
+
+	// Escape every markup character, then stop escaping each kind of character
+	// that reads back unchanged without it, so delimiters stay escaped in pairs
+	escaped := make([][]bool, len(normalized))
+	setEscaped := func(chars string, on bool) {
+		for i, span := range normalized {
+			if span.Break || span.Marks&Code != 0 {
+				continue
+			}
+			if escaped[i] == nil {
+				escaped[i] = make([]bool, len(span.Text))
+			}
+			for j := 0; j < len(span.Text); j++ {
+				if strings.IndexByte(chars, span.Text[j]) >= 0 {
+					escaped[i][j] = on
+				}
+			}
+		}
+	}
+	setEscaped(syntax.escapable, true)
+	for _, c := range syntax.escapable {
+		setEscaped(string(c), false)
+		if !spansEqual(syntax.parse(writeInline(normalized, syntax, escaped)), normalized) {
+			setEscaped(string(c), true)
+		}
+	}
+	return writeInline(normalized, syntax, escaped)
+}
+
+// spansEqual reports whether two span sequences are identical
+func spansEqual(a, b []Span) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// writeInline writes spans, opening and closing marks only where they change
+// between neighbouring spans. escaped[i][j] backslash-escapes byte j of span i.
+// Where the syntax braces delimiters, a mark touching a word character on
+// either side is braced at both ends.
+func writeInline(spans []Span, syntax inlineSyntax, escaped [][]bool) string {
+	text, touching := writeMarks(spans, syntax, escaped, nil)
+	if syntax.braced == nil || len(touching) == 0 {
+		return text
+	}
+	text, _ = writeMarks(spans, syntax, escaped, touching)
+	return text
+}
+
+// writeMarks writes spans like writeInline, bracing the marks whose opening
+// order is in braced. It also returns the marks that touch a word character.
+func writeMarks(spans []Span, syntax inlineSyntax, escaped [][]bool, braced map[int]bool) (string, map[int]bool) {
+	type open struct {
+		mark  Mark // Zero for a link
+		href  string
+		close string
+		id    int // Opening order of a mark
+	}
+	var sb strings.Builder
+	var stack []open
+	var starts, ends []int // Where each mark's opening delimiter starts and closing one ends
+
+	delimiter := func(delim string, id int) string {
+		if braced[id] {
+			return syntax.braced(delim)
+		}
+		return delim
+	}
+	closeTo := func(n int) {
+		for len(stack) > n {
+			o := stack[len(stack)-1]
+			if o.mark == 0 {
+				sb.WriteString(o.close)
+			} else {
+				sb.WriteString(delimiter(o.close, o.id))
+				ends[o.id] = sb.Len()
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	isOpen := func(m Mark, href string) bool {
+		for _, o := range stack {
+			if o.mark == m && o.href == href {
+				return true
+			}
+		}
+		return false
+	}
+
+	for i, span := range spans {
+		if span.Break {
+			sb.WriteString(syntax.lineBreak)
+			continue
+		}
+
+		// Keep the open marks this span shares with the previous one
+		keep := 0
+		for keep < len(stack) {
+			o := stack[keep]
+			if (o.mark == 0 && o.href != span.Href) || (o.mark != 0 && span.Marks&o.mark == 0) {
+				break
+			}
+			keep++
+		}
+		closeTo(keep)
+
+		if span.Href != "" && !isOpen(0, span.Href) {
+			sb.WriteString(syntax.openLink(span.Href))
+			stack = append(stack, open{href: span.Href, close: syntax.closeLink(span.Href)})
+		}
+		for _, ms := range syntax.marks {
+			if span.Marks&ms.mark != 0 && !isOpen(ms.mark, "") {
+				id := len(starts)
+				starts, ends = append(starts, sb.Len()), append(ends, 0)
+				sb.WriteString(delimiter(ms.open, id))
+				stack = append(stack, open{mark: ms.mark, close: ms.close, id: id})
+			}
+		}
+
+		if span.Marks&Code != 0 {
+			sb.WriteString(syntax.code(span.Text))
+			continue
+		}
+		for j := 0; j < len(span.Text); j++ {
+			if i < len(escaped) && j < len(escaped[i]) && escaped[i][j] {
+				sb.WriteByte('\\')
+			}
+			sb.WriteByte(span.Text[j])
+		}
+	}
+	closeTo(0)
+
+	text := sb.String()
+	touching := make(map[int]bool)
+	for id, start := range starts {
+		if start > 0 && isWordChar(text[start-1]) || ends[id] < len(text) && isWordChar(text[ends[id]]) {
+			touching[id] = true
+		}
+	}
+	return text, touching
+}
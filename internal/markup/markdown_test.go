@@ -0,0 +1,96 @@
+package markup
+
+import (
+	"reflect"
+	"testing"
+)
+
+// TestParseInline verifies emphasis, code spans, links and escapes become styled spans
+func TestParseInline(t *testing.T) {
+	spans := ParseInline("a **bold *both*** `x*y` [see **docs**](https://example.com) \\*lit\\*")
+	want := []Span{
+		{Text: "a "},
+		{Text: "bold ", Marks: Strong},
+		{Text: "both", Marks: Strong | Emphasis},
+		{Text: " "},
+		{Text: "x*y", Marks: Code},
+		{Text: " "},
+		{Text: "see ", Href: "https://example.com"},
+		{Text: "docs", Marks: Strong, Href: "https://example.com"},
+		{Text: " *lit*"},
+	}
+	if !reflect.DeepEqual(spans, want) {
+		t.Errorf("ParseInline() =\n%#v\nwant\n%#v", spans, want)
+	}
+}
+
+// TestParseInline_LiteralDelimiters verifies unmatched and intra-word delimiters stay text
+func TestParseInline_LiteralDelimiters(t *testing.T) {
+	for _, text := range []string{"2 * 3 * 4", "snake_case_name", "price: $5 [approx]"} {
+		spans := ParseInline(text)
+		if len(spans) != 1 || spans[0].Marks != 0 || spans[0].Text != text {
+			t.Errorf("ParseInline(%q) = %#v, want plain text", text, spans)
+		}
+	}
+}
+
+// TestParseMarkdown_Blocks verifies each block type is recognised
+func TestParseMarkdown_Blocks(t *testing.T) {
+	blocks := ParseMarkdown("## Title\n\nSome text\ncontinued\n\n```sh\nmake test\n```\n\n- a\n  - b\n\n3. c\n\n| H |\n| --- |\n| v |\n\n> note\n\n---")
+
+	kinds := make([]BlockKind, len(blocks))
+	for i, b := range blocks {
+		kinds[i] = b.Kind
+	}
+	want := []BlockKind{Heading, Paragraph, CodeBlock, BulletList, OrderedList, Table, Quote, Rule}
+	if !reflect.DeepEqual(kinds, want) {
+		t.Fatalf("Block kinds = %v, want %v", kinds, want)
+	}
+
+	if blocks[0].Level != 2 || PlainText(blocks[0].Spans) != "Title" {
+		t.Errorf("Unexpected heading: %#v", blocks[0])
+	}
+	if PlainText(blocks[1].Spans) != "Some text\ncontinued" {
+		t.Errorf("Expected a line break in the paragraph, got %q", PlainText(blocks[1].Spans))
+	}
+	if blocks[2].Language != "sh" || blocks[2].Text != "make test" {
+		t.Errorf("Unexpected code block: %#v", blocks[2])
+	}
+	nested := blocks[3].Items[0]
+	if len(nested) != 2 || nested[1].Kind != BulletList {
+		t.Errorf("Expected a nested list in the first item, got %#v", nested)
+	}
+	if blocks[4].Start != 3 {
+		t.Errorf("Expected ordered list to start at 3, got %d", blocks[4].Start)
+	}
+	if len(blocks[5].Rows) != 2 {
+		t.Errorf("Expected header and body rows, got %d", len(blocks[5].Rows))
+	}
+}
+
+// TestRenderMarkdown_RoundTrip verifies canonical Markdown survives parsing and rendering unchanged
+func TestRenderMarkdown_RoundTrip(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+	}{
+		{"inline", "Plain **bold** *em* ~~gone~~ `code` [link](https://example.com) and [**bold link**](https://example.com)"},
+		{"heading", "### Section *one*"},
+		{"code block", "```go\nfunc main() {\n\tfmt.Println(\"*not emphasis*\")\n}\n```"},
+		{"nested lists", "- one\n- two\n  - nested\n    1. deep\n    2. deeper\n- three"},
+		{"ordered start", "4. four\n5. five"},
+		{"multi paragraph item", "- first\n\n  second paragraph\n- next"},
+		{"table", "| Name | Value |\n| --- | --- |\n| a | `b` |\n| pipe \\| escaped | **x** |"},
+		{"quote", "> quoted\n>\n> - item"},
+		{"mixed", "Intro\n\n## Steps\n\n1. Do it\n2. Check it\n\n---\n\nDone"},
+		{"escaped block starts", "\\# not a heading\n\\- not a bullet\n2\\. not numbered\n\\> not quoted"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderMarkdown(ParseMarkdown(tt.text)); got != tt.text {
+				t.Errorf("Round trip changed the text:\ngot:\n%s\nwant:\n%s", got, tt.text)
+			}
+		})
+	}
+}
@@ -0,0 +1,91 @@
+// Package markup converts ticket descriptions between the Markdown used in
+// ticket files and the rich text formats Jira stores. Every format is parsed
+// into the same small document model (blocks of styled spans) and rendered
+// from it, so any two formats can be converted through Markdown.
+package markup
+
+import "strings"
+
+// BlockKind identifies the type of a block
+type BlockKind int
+
+const (
+	// Paragraph is a run of inline text
+	Paragraph BlockKind = iota
+	// Heading is a section heading with a Level from 1 to 6
+	Heading
+	// CodeBlock is preformatted text with an optional Language
+	CodeBlock
+	// BulletList is an unordered list whose Items hold the item contents
+	BulletList
+	// OrderedList is a numbered list starting at Start
+	OrderedList
+	// Table holds Rows of cells; the first row is the header
+	Table
+	// Quote is a block quotation containing Children
+	Quote
+	// Rule is a horizontal rule
+	Rule
+)
+
+// Block is a block-level element of a document
+type Block struct {
+	Kind     BlockKind
+	Level    int        // Heading level
+	Spans    []Span     // Paragraph and Heading content
+	Text     string     // CodeBlock content
+	Language string     // CodeBlock language
+	Start    int        // OrderedList first number
+	Items    [][]Block  // BulletList and OrderedList items
+	Rows     [][][]Span // Table cells; Rows[0] is the header row
+	Children []Block    // Quote content
+}
+
+// Mark is a set of inline styles applied to a span
+type Mark int
+
+const (
+	// Strong is bold text
+	Strong Mark = 1 << iota
+	// Emphasis is italic text
+	Emphasis
+	// Strike is struck-through text
+	Strike
+	// Code is inline code
+	Code
+)
+
+// Span is a run of text sharing the same styles. A Break span is a hard line
+// break within a paragraph.
+type Span struct {
+	Text  string
+	Marks Mark
+	Href  string // Link target when the span is part of a link
+	Break bool
+}
+
+// PlainText returns the text of spans without any styling
+func PlainText(spans []Span) string {
+	var sb strings.Builder
+	for _, span := range spans {
+		if span.Break {
+			sb.WriteString("\n")
+			continue
+		}
+		sb.WriteString(span.Text)
+	}
+	return sb.String()
+}
+
+// appendSpan adds a span, merging it into the previous one when the styles match
+func appendSpan(spans []Span, span Span) []Span {
+	if span.Text == "" && !span.Break {
+		return spans
+	}
+	if n := len(spans); n > 0 && !span.Break && !spans[n-1].Break &&
+		spans[n-1].Marks == span.Marks && spans[n-1].Href == span.Href {
+		spans[n-1].Text += span.Text
+		return spans
+	}
+	return append(spans, span)
+}
@@ -0,0 +1,534 @@
+package markup
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	wikiHeadingPattern  = regexp.MustCompile(`^\s*h([1-6])\.\s*(.*)$`)
+	wikiListItemPattern = regexp.MustCompile(`^\s*([*#-]+)(?:\s+(.*))?$`)
+	wikiRulePattern     = regexp.MustCompile(`^\s*-{4,}\s*$`)
+	wikiCodePattern     = regexp.MustCompile(`^\s*\{(code|noformat)(?::([^}|]*))?(?:\|[^}]*)?\}`)
+)
+
+// MarkdownToWiki converts Markdown text to Jira wiki markup
+func MarkdownToWiki(text string) string {
+	return RenderWiki(ParseMarkdown(text))
+}
+
+// WikiToMarkdown converts Jira wiki markup to Markdown text
+func WikiToMarkdown(text string) string {
+	return RenderMarkdown(ParseWiki(text))
+}
+
+// ParseWiki parses Jira wiki markup into blocks
+func ParseWiki(text string) []Block {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	return parseWikiBlocks(strings.Split(text, "\n"))
+}
+
+// parseWikiBlocks parses lines of wiki markup into blocks
+func parseWikiBlocks(lines []string) []Block {
+	var blocks []Block
+
+	for i := 0; i < len(lines); {
+		line := lines[i]
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			i++
+
+		case wikiCodePattern.MatchString(line):
+			m := wikiCodePattern.FindStringSubmatch(line)
+			closing := "{" + m[1] + "}"
+			language := strings.TrimSpace(m[2])
+			if m[1] == "noformat" || strings.Contains(language, "=") {
+				// noformat has no language, and parameters such as title= are not one
+				language = ""
+			}
+
+			var code []string
+			rest := strings.TrimPrefix(line, m[0])
+			for {
+				if end := strings.Index(rest, closing); end >= 0 {
+					if before := rest[:end]; before != "" {
+						code = append(code, before)
+					}
+					i++
+					break
+				}
+				if rest != "" || len(code) > 0 {
+					code = append(code, rest)
+				}
+				i++
+				if i >= len(lines) {
+					break
+				}
+				rest = lines[i]
+			}
+			blocks = append(blocks, Block{Kind: CodeBlock, Language: language, Text: strings.Join(code, "\n")})
+
+		case strings.HasPrefix(trimmed, "{quote}"):
+			var quoted []string
+			rest := strings.TrimPrefix(trimmed, "{quote}")
+			for {
+				if end := strings.Index(rest, "{quote}"); end >= 0 {
+					quoted = append(quoted, rest[:end])
+					i++
+					break
+				}
+				quoted = append(quoted, rest)
+				i++
+				if i >= len(lines) {
+					break
+				}
+				rest = lines[i]
+			}
+			blocks = append(blocks, Block{Kind: Quote, Children: parseWikiBlocks(quoted)})
+
+		case strings.HasPrefix(trimmed, "bq. "):
+			paragraph := Block{Kind: Paragraph, Spans: ParseWikiInline(strings.TrimPrefix(trimmed, "bq. "))}
+			blocks = append(blocks, Block{Kind: Quote, Children: []Block{paragraph}})
+			i++
+
+		case wikiHeadingPattern.MatchString(line):
+			m := wikiHeadingPattern.FindStringSubmatch(line)
+			blocks = append(blocks, Block{Kind: Heading, Level: int(m[1][0] - '0'), Spans: ParseWikiInline(strings.TrimSpace(m[2]))})
+			i++
+
+		case wikiRulePattern.MatchString(line):
+			blocks = append(blocks, Block{Kind: Rule})
+			i++
+
+		case strings.HasPrefix(trimmed, "|"):
+			table := Block{Kind: Table}
+			for i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), "|") {
+				table.Rows = append(table.Rows, parseWikiTableRow(lines[i]))
+				i++
+			}
+			blocks = append(blocks, table)
+
+		case wikiListItemPattern.MatchString(line):
+			var items []wikiItem
+			for i < len(lines) && strings.TrimSpace(lines[i]) != "" {
+				if m := wikiListItemPattern.FindStringSubmatch(lines[i]); m != nil && !wikiRulePattern.MatchString(lines[i]) {
+					items = append(items, wikiItem{markers: m[1], text: m[2]})
+				} else if !startsWikiBlock(lines[i]) {
+					// A plain line continues the previous item on a new line
+					items[len(items)-1].text += "\n" + strings.TrimSpace(lines[i])
+				} else {
+					break
+				}
+				i++
+			}
+			blocks = append(blocks, buildWikiLists(items, 0)...)
+
+		default:
+			var text []string
+			for i < len(lines) && strings.TrimSpace(lines[i]) != "" && (len(text) == 0 || !startsWikiBlock(lines[i])) {
+				text = append(text, strings.TrimSpace(lines[i]))
+				i++
+			}
+			blocks = append(blocks, Block{Kind: Paragraph, Spans: ParseWikiInline(strings.Join(text, "\n"))})
+		}
+	}
+
+	return blocks
+}
+
+// startsWikiBlock reports whether a line starts a block other than a paragraph
+func startsWikiBlock(line string) bool {
+	trimmed := strings.TrimSpace(line)
+	return wikiCodePattern.MatchString(line) ||
+		strings.HasPrefix(trimmed, "{quote}") ||
+		strings.HasPrefix(trimmed, "bq. ") ||
+		strings.HasPrefix(trimmed, "|") ||
+		wikiHeadingPattern.MatchString(line) ||
+		wikiRulePattern.MatchString(line) ||
+		wikiListItemPattern.MatchString(line)
+}
+
+// wikiItem is a list item line; its markers give the nesting, e.g. "#*"
+type wikiItem struct {
+	markers string
+	text    string
+}
+
+// buildWikiLists builds the lists at the given nesting depth from item lines
+func buildWikiLists(items []wikiItem, depth int) []Block {
+	var lists []Block
+
+	for i := 0; i < len(items); {
+		kind := BulletList
+		if items[i].markers[depth] == '#' {
+			kind = OrderedList
+		}
+		list := Block{Kind: kind}
+
+		for i < len(items) && (items[i].markers[depth] == '#') == (kind == OrderedList) {
+			if len(items[i].markers) == depth+1 {
+				var item []Block
+				if spans := ParseWikiInline(items[i].text); len(spans) > 0 {
+					item = append(item, Block{Kind: Paragraph, Spans: spans})
+				}
+				list.Items = append(list.Items, item)
+				i++
+				continue
+			}
+
+			// Deeper items nest inside the current item, or an empty one
+			start := i
+			for i < len(items) && len(items[i].markers) > depth+1 {
+				i++
+			}
+			if len(list.Items) == 0 {
+				list.Items = append(list.Items, nil)
+			}
+			last := len(list.Items) - 1
+			list.Items[last] = append(list.Items[last], buildWikiLists(items[start:i], depth+1)...)
+		}
+		lists = append(lists, list)
+	}
+
+	return lists
+}
+
+// parseWikiTableRow splits a table row into cells; "||" separates header cells
+func parseWikiTableRow(line string) [][]Span {
+	line = strings.TrimSpace(line)
+
+	var cells [][]Span
+	var cell strings.Builder
+	depth := 0 // Inside a link or macro, where | is not a separator
+	started := false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case c == '\\' && i+1 < len(line):
+			cell.WriteByte(c)
+			cell.WriteByte(line[i+1])
+			i++
+		case c == '[' || c == '{':
+			depth++
+			cell.WriteByte(c)
+		case (c == ']' || c == '}') && depth > 0:
+			depth--
+			cell.WriteByte(c)
+		case c == '|' && depth == 0:
+			if started {
+				cells = append(cells, ParseWikiInline(strings.TrimSpace(cell.String())))
+				cell.Reset()
+			}
+			started = true
+			if i+1 < len(line) && line[i+1] == '|' {
+				i++
+			}
+		default:
+			cell.WriteByte(c)
+		}
+	}
+	if rest := strings.TrimSpace(cell.String()); rest != "" {
+		cells = append(cells, ParseWikiInline(rest))
+	}
+	return cells
+}
+
+// wikiMarks maps wiki emphasis delimiters to marks; underline has no
+// Markdown equivalent and keeps only its text
+var wikiMarks = map[byte]Mark{'*': Strong, '_': Emphasis, '-': Strike, '+': 0}
+
+// wikiEscapable lists the characters a backslash escapes in wiki markup;
+// "." is escaped only to keep "h3. " and "bq. " from starting a block
+const wikiEscapable = "*_-+{}[]|!^~?#."
+
+// ParseWikiInline parses wiki inline markup (emphasis, monospace, links and
+// line breaks) into spans
+func ParseWikiInline(text string) []Span {
+	return parseWikiInline(text, 0, "")
+}
+
+// parseWikiInline parses text whose spans all carry the given marks and link target
+func parseWikiInline(text string, marks Mark, href string) []Span {
+	var spans []Span
+	var buf strings.Builder
+
+	flush := func() {
+		spans = appendSpan(spans, Span{Text: buf.String(), Marks: marks, Href: href})
+		buf.Reset()
+	}
+	nested := func(inner []Span) {
+		flush()
+		for _, span := range inner {
+			spans = appendSpan(spans, span)
+		}
+	}
+
+	for i := 0; i < len(text); {
+		c := text[i]
+		rest := text[i:]
+
+		switch {
+		case strings.HasPrefix(rest, `\\`), c == '\n':
+			flush()
+			spans = append(spans, Span{Break: true})
+			if c == '\n' {
+				i++
+			} else {
+				i += 2
+			}
+			continue
+
+		case c == '\\' && i+1 < len(text) && strings.IndexByte(wikiEscapable, text[i+1]) >= 0:
+			buf.WriteByte(text[i+1])
+			i += 2
+			continue
+
+		case strings.HasPrefix(rest, "{{"):
+			if end := strings.Index(rest[2:], "}}"); end >= 0 {
+				flush()
+				spans = appendSpan(spans, Span{Text: rest[2 : 2+end], Marks: marks | Code, Href: href})
+				i += end + 4
+				continue
+			}
+
+		case len(rest) >= 3 && rest[0] == '{' && rest[2] == '}' && strings.IndexByte("*_-+", rest[1]) >= 0:
+			// Braced emphasis such as {*}bold{*} may touch word characters
+			if end := strings.Index(rest[3:], rest[:3]); end > 0 && !strings.Contains(rest[3:3+end], "\n") {
+				nested(parseWikiInline(rest[3:3+end], marks|wikiMarks[rest[1]], href))
+				i += end + 6
+				continue
+			}
+
+		case strings.HasPrefix(rest, "{color"):
+			// Colours have no Markdown equivalent; keep the text
+			if end := strings.IndexByte(rest, '}'); end >= 0 {
+				i += end + 1
+				continue
+			}
+
+		case c == '[':
+			if label, target, n := parseWikiLink(rest); n > 0 {
+				nested(parseWikiInline(label, marks, target))
+				i += n
+				continue
+			}
+		}
+
+		if mark, ok := wikiMarks[c]; ok {
+			if end := wikiClosing(text, i); end > 0 {
+				nested(parseWikiInline(text[i+1:end], marks|mark, href))
+				i = end + 1
+				continue
+			}
+		}
+
+		buf.WriteByte(c)
+		i++
+	}
+	flush()
+
+	return spans
+}
+
+// wikiClosing returns the index of the delimiter closing an emphasis opened at
+// i, or -1. Wiki emphasis starts and ends at word boundaries and stays on one line.
+func wikiClosing(text string, i int) int {
+	delim := text[i]
+	if i > 0 && isWordChar(text[i-1]) {
+		return -1
+	}
+	if i+1 >= len(text) || text[i+1] == ' ' || text[i+1] == '\n' || text[i+1] == delim {
+		return -1
+	}
+	for j := i + 2; j < len(text); j++ {
+		switch text[j] {
+		case '\n':
+			return -1
+		case '\\':
+			j++
+			continue
+		case delim:
+			if text[j-1] != ' ' && (j+1 == len(text) || !isWordChar(text[j+1])) {
+				return j
+			}
+		}
+	}
+	return -1
+}
+
+// parseWikiLink parses a [label|target] or [target] link at the start of text
+// and returns its parts and length. User mentions ([~name]) read as their text.
+func parseWikiLink(text string) (string, string, int) {
+	end := strings.IndexByte(text, ']')
+	if end < 0 || strings.Contains(text[:end], "\n") {
+		return "", "", 0
+	}
+	inner := text[1:end]
+
+	if strings.HasPrefix(inner, "~") {
+		return inner[1:], "", end + 1
+	}
+
+	label, target := inner, inner
+	if sep := strings.LastIndexByte(inner, '|'); sep >= 0 {
+		label, target = inner[:sep], strings.TrimSpace(inner[sep+1:])
+	}
+	if !strings.Contains(target, "://") && !strings.HasPrefix(target, "mailto:") {
+		return "", "", 0
+	}
+	return label, target, end + 1
+}
+
+// RenderWiki renders blocks as Jira wiki markup
+func RenderWiki(blocks []Block) string {
+	return joinBlocks(blocks, false, renderWikiBlock)
+}
+
+// renderWikiBlock renders a single block as wiki markup
+func renderWikiBlock(block Block) string {
+	switch block.Kind {
+	case Heading:
+		return "h" + string(rune('0'+clampLevel(block.Level))) + ". " + RenderWikiInline(withoutBreaks(block.Spans))
+
+	case CodeBlock:
+		if block.Language != "" {
+			return "{code:" + block.Language + "}\n" + block.Text + "\n{code}"
+		}
+		return "{code}\n" + block.Text + "\n{code}"
+
+	case BulletList, OrderedList:
+		return strings.Join(wikiListLines(block, ""), "\n")
+
+	case Table:
+		var lines []string
+		for r, row := range block.Rows {
+			sep := "|"
+			if r == 0 {
+				sep = "||"
+			}
+			cells := make([]string, len(row))
+			for c, cell := range row {
+				cells[c] = escapeWikiPipes(RenderWikiInline(withoutBreaks(cell)))
+			}
+			lines = append(lines, sep+strings.Join(cells, sep)+sep)
+		}
+		return strings.Join(lines, "\n")
+
+	case Quote:
+		if len(block.Children) == 1 && block.Children[0].Kind == Paragraph && !hasBreak(block.Children[0].Spans) {
+			return "bq. " + RenderWikiInline(block.Children[0].Spans)
+		}
+		return "{quote}\n" + RenderWiki(block.Children) + "\n{quote}"
+
+	case Rule:
+		return "----"
+
+	default:
+		return escapeWikiBlockStarts(RenderWikiInline(block.Spans))
+	}
+}
+
+// escapeWikiBlockStarts escapes the start of each line of a paragraph that
+// would otherwise read as a block, such as "h3. ", "bq. ", "* " or "# "
+func escapeWikiBlockStarts(text string) string {
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if !startsWikiBlock(line) {
+			continue
+		}
+		trimmed := strings.TrimLeft(line, " ")
+		at := len(line) - len(trimmed)
+		if strings.IndexByte(wikiEscapable, trimmed[0]) < 0 {
+			// "hN. " and "bq. " are escaped at their dot
+			at += strings.IndexByte(trimmed, '.')
+		}
+		lines[i] = line[:at] + "\\" + line[at:]
+	}
+	return strings.Join(lines, "\n")
+}
+
+// wikiListLines renders a list whose items are prefixed by the markers of
+// the lists containing it. Wiki list items hold a single line of text, so
+// further paragraphs continue on new lines and other blocks follow the list.
+func wikiListLines(block Block, prefix string) []string {
+	marker := prefix + "*"
+	if block.Kind == OrderedList {
+		marker = prefix + "#"
+	}
+
+	var lines []string
+	for _, item := range block.Items {
+		var text []string
+		var nested []string
+		for _, child := range item {
+			switch child.Kind {
+			case BulletList, OrderedList:
+				nested = append(nested, wikiListLines(child, marker)...)
+			case Paragraph:
+				text = append(text, RenderWikiInline(child.Spans))
+			default:
+				text = append(text, renderWikiBlock(child))
+			}
+		}
+		lines = append(lines, strings.TrimRight(marker+" "+strings.Join(text, "\n"), " "))
+		lines = append(lines, nested...)
+	}
+	return lines
+}
+
+// hasBreak reports whether spans contain a line break
+func hasBreak(spans []Span) bool {
+	for _, span := range spans {
+		if span.Break {
+			return true
+		}
+	}
+	return false
+}
+
+// escapeWikiPipes escapes table cell separators outside links and macros
+func escapeWikiPipes(text string) string {
+	var sb strings.Builder
+	depth := 0
+	for i := 0; i < len(text); i++ {
+		c := text[i]
+		switch {
+		case c == '\\' && i+1 < len(text):
+			sb.WriteByte(c)
+			sb.WriteByte(text[i+1])
+			i++
+			continue
+		case c == '[' || c == '{':
+			depth++
+		case (c == ']' || c == '}') && depth > 0:
+			depth--
+		case c == '|' && depth == 0:
+			sb.WriteByte('\\')
+		}
+		sb.WriteByte(c)
+	}
+	return sb.String()
+}
+
+// wikiInline is the Jira wiki inline syntax
+var wikiInline = inlineSyntax{
+	marks: []markSyntax{
+		{Strong, "*", "*"},
+		{Emphasis, "_", "_"},
+		{Strike, "-", "-"},
+	},
+	openLink:  func(string) string { return "[" },
+	closeLink: func(href string) string { return "|" + href + "]" },
+	code:      func(text string) string { return "{{" + text + "}}" },
+	lineBreak: "\n",
+	escapable: "*_-+{}[]|!",
+	parse:     ParseWikiInline,
+	braced:    func(delim string) string { return "{" + delim + "}" },
+}
+
+// RenderWikiInline renders spans as wiki inline markup
+func RenderWikiInline(spans []Span) string {
+	return renderInline(spans, wikiInline)
+}
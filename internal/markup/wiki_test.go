@@ -0,0 +1,89 @@
+package markup
+
+import "testing"
+
+// TestMarkdownToWiki verifies each Markdown construct maps to its wiki equivalent
+func TestMarkdownToWiki(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		wiki     string
+	}{
+		{"inline", "**bold** *em* ~~old~~ `code` [docs](https://example.com)", "*bold* _em_ -old- {{code}} [docs|https://example.com]"},
+		{"heading", "### Section", "h3. Section"},
+		{"code block", "```go\nx := *p\n```", "{code:go}\nx := *p\n{code}"},
+		{"plain code block", "```\nraw\n```", "{code}\nraw\n{code}"},
+		{"nested lists", "- a\n  - b\n    1. c\n- d", "* a\n** b\n**# c\n* d"},
+		{"table", "| H1 | H2 |\n| --- | --- |\n| a | [x](https://x.io) |", "||H1||H2||\n|a|[x|https://x.io]|"},
+		{"quote", "> quoted", "bq. quoted"},
+		{"rule", "---", "----"},
+		{"escaped markup", "\\*not bold\\* and 2 * 3", "\\*not bold\\* and 2 \\* 3"},
+		{"plain punctuation", "snake_case, well-known and 2 * 3", "snake_case, well-known and 2 * 3"},
+		{"intraword emphasis", "**bold**text, 2*3*4 and a~~b~~c", "{*}bold{*}text, 2{_}3{_}4 and a{-}b{-}c"},
+		{"block prefixes", "h3. text\nbq. text\n\\* text\n\\# text", "h3\\. text\nbq\\. text\n\\* text\n\\# text"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MarkdownToWiki(tt.markdown); got != tt.wiki {
+				t.Errorf("MarkdownToWiki() =\n%s\nwant\n%s", got, tt.wiki)
+			}
+		})
+	}
+}
+
+// TestWikiToMarkdown verifies wiki markup written in Jira converts to Markdown
+func TestWikiToMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		wiki     string
+		markdown string
+	}{
+		{"inline", "*bold* _em_ -old- +under+ {{code}} [docs|https://example.com] [https://example.com]",
+			"**bold** *em* ~~old~~ under `code` [docs](https://example.com) [https://example.com](https://example.com)"},
+		{"heading", "h2. Title", "## Title"},
+		{"code with title", "{code:title=Main.java|borderStyle=solid}\nclass Main {}\n{code}", "```\nclass Main {}\n```"},
+		{"noformat", "{noformat}\n*raw*\n{noformat}", "```\n*raw*\n```"},
+		{"single line code", "{code:sql}SELECT 1{code}", "```sql\nSELECT 1\n```"},
+		{"mixed lists", "# one\n#* bullet\n# two", "1. one\n   - bullet\n2. two"},
+		{"list continuation", "* first\ncontinued\n* second", "- first\n  continued\n- second"},
+		{"table", "||Key||Value||\n|a|[link|https://x.io]|", "| Key | Value |\n| --- | --- |\n| a | [link](https://x.io) |"},
+		{"quote block", "{quote}\nfirst\n\nsecond\n{quote}", "> first\n>\n> second"},
+		{"mention and colour", "Ask [~jdoe] about {color:red}this{color}", "Ask jdoe about this"},
+		{"line break", "one\\\\two", "one\ntwo"},
+		{"word boundaries", "a*b*c and x_y_z", "a\\*b\\*c and x_y_z"},
+		{"braced emphasis", "{*}bold{*}text and x{_}y{_}z", "**bold**text and x*y*z"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := WikiToMarkdown(tt.wiki); got != tt.markdown {
+				t.Errorf("WikiToMarkdown() =\n%s\nwant\n%s", got, tt.markdown)
+			}
+		})
+	}
+}
+
+// TestWiki_RoundTrip verifies Markdown converted to wiki markup and back is unchanged
+func TestWiki_RoundTrip(t *testing.T) {
+	tests := []string{
+		"Intro with **bold**, *em*, ~~old~~, `code` and a [link](https://example.com).\nSecond line",
+		"## Details\n\n- one\n- two\n  - nested\n    1. deep\n- three",
+		"1. first\n2. second",
+		"```go\nfunc main() {\n\tfmt.Println(\"*hi*\")\n}\n```",
+		"| Name | Value |\n| --- | --- |\n| a | `b` |\n| pipe \\| here | **x** |",
+		"> quoted\n\n---\n\nDone",
+		"Keep \\*literal\\* stars, snake_case and well-known words",
+		"**bold**text, 2*3*4, a~~b~~c and ***both***words",
+		"h3. not a heading\nbq. not a quote",
+		"\\* not a list\n\\# not a heading\n1\\. not numbered\n\\- not a bullet",
+		"> h1. quoted text",
+		"| * cell | h2. cell |\n| --- | --- |\n| a | b |",
+	}
+
+	for _, text := range tests {
+		if got := WikiToMarkdown(MarkdownToWiki(text)); got != text {
+			t.Errorf("Round trip changed the text:\ngot:\n%s\nwant:\n%s\nvia wiki:\n%s", got, text, MarkdownToWiki(text))
+		}
+	}
+}
@@ -0,0 +1,201 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Severity of a diagnostic: errors stop the file from being used, warnings
+// point at content the parser ignores
+type Severity string
+
+const (
+	SeverityError   Severity = "error"
+	SeverityWarning Severity = "warning"
+)
+
+// Diagnostic is a problem found at a position of a Markdown file
+type Diagnostic struct {
+	Severity   Severity
+	Line       int // 1-based
+	Column     int // 1-based
+	Message    string
+	Suggestion string // How to fix it, or ""
+}
+
+func (d Diagnostic) String() string {
+	s := fmt.Sprintf("line %d, column %d: %s: %s", d.Line, d.Column, d.Severity, d.Message)
+	if d.Suggestion != "" {
+		s += " (" + d.Suggestion + ")"
+	}
+	return s
+}
+
+// Diagnostics are the problems found in a file, in line order. As an error
+// they list every error.
+type Diagnostics []Diagnostic
+
+// Errors returns the diagnostics that are errors
+func (d Diagnostics) Errors() Diagnostics {
+	var errors Diagnostics
+	for _, diagnostic := range d {
+		if diagnostic.Severity == SeverityError {
+			errors = append(errors, diagnostic)
+		}
+	}
+	return errors
+}
+
+// Warnings returns the diagnostics that are warnings
+func (d Diagnostics) Warnings() Diagnostics {
+	var warnings Diagnostics
+	for _, diagnostic := range d {
+		if diagnostic.Severity == SeverityWarning {
+			warnings = append(warnings, diagnostic)
+		}
+	}
+	return warnings
+}
+
+func (d Diagnostics) Error() string {
+	if len(d) == 1 {
+		return d[0].String()
+	}
+	lines := []string{fmt.Sprintf("%d problems found:", len(d))}
+	for _, diagnostic := range d {
+		lines = append(lines, "  "+diagnostic.String())
+	}
+	return strings.Join(lines, "\n")
+}
+
+// add records a problem at the first non-blank character of lines[index]
+func (d *Diagnostics) add(severity Severity, lines []string, index int, message, suggestion string) {
+	line := lines[index]
+	*d = append(*d, Diagnostic{
+		Severity:   severity,
+		Line:       index + 1,
+		Column:     len(line) - len(strings.TrimLeft(line, " \t")) + 1,
+		Message:    message,
+		Suggestion: suggestion,
+	})
+}
+
+// Section names the parser reads
+var (
+	ticketSections = []string{"Description", "Fields", "Acceptance Criteria", "Links", "Comments", "Tasks"}
+	taskSections   = []string{"Description", "Fields", "Acceptance Criteria"}
+)
+
+// checkHeading reports a heading line of a ticket, or with task set of a
+// task, that is not a known section: a near miss of one, such as
+// "## Acceptence Criteria" or "#### Fields", a ticket-only section under a
+// task, or a "###" heading, which ends the section above it. Custom "## "
+// sections are left alone.
+func (d *Diagnostics) checkHeading(lines []string, index int, trimmed string, task bool) {
+	level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+	name := strings.TrimSpace(trimmed[level:])
+	if level == 0 || name == "" {
+		return
+	}
+
+	known := ticketSections
+	if task {
+		known = taskSections
+	}
+	if suggestion := closestSection(name, known); suggestion != "" {
+		d.add(SeverityWarning, lines, index,
+			fmt.Sprintf("Unknown section '%s' is ignored", trimmed),
+			fmt.Sprintf("did you mean '## %s'?", suggestion))
+		return
+	}
+	if task && closestSection(name, ticketSections) != "" {
+		d.add(SeverityWarning, lines, index,
+			fmt.Sprintf("Section '%s' is not supported for tasks and is ignored", trimmed),
+			"move it to the ticket")
+		return
+	}
+	if level > 2 {
+		d.add(SeverityWarning, lines, index,
+			fmt.Sprintf("Heading '%s' ends the section above it; the lines up to the next section are ignored", trimmed),
+			"use a '## ' section or remove the heading")
+	}
+}
+
+// closestSection returns the known section a name is close to, ignoring
+// case, or ""
+func closestSection(name string, known []string) string {
+	for _, section := range known {
+		if distance(strings.ToLower(name), strings.ToLower(section)) <= 2 {
+			return section
+		}
+	}
+	return ""
+}
+
+// distance returns the Levenshtein distance between two strings
+func distance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current := make([]int, len(b)+1)
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous = current
+	}
+	return previous[len(b)]
+}
+
+// Conflict markers pull writes around the lines where the Markdown file and
+// Jira disagree, local version first
+const (
+	ConflictStart     = "<<<<<<< local"
+	ConflictSeparator = "======="
+	ConflictEnd       = ">>>>>>> jira"
+)
+
+// checkConflictMarkers reports every line of an unresolved conflict marker.
+// A "=======" line is only a marker between "<<<<<<<" and ">>>>>>>", as it
+// also underlines Markdown headings.
+func (d *Diagnostics) checkConflictMarkers(lines []string) {
+	open := false
+	for i, line := range lines {
+		switch {
+		case strings.HasPrefix(line, "<<<<<<<"):
+			open = true
+		case strings.HasPrefix(line, ">>>>>>>"):
+			open = false
+		case open && strings.HasPrefix(line, ConflictSeparator):
+		default:
+			continue
+		}
+		d.add(SeverityError, lines, i,
+			fmt.Sprintf("Unresolved conflict marker '%s'", line),
+			"keep the version you want, delete the markers and run 'ticketr resolve'")
+	}
+}
+
+// commentTracker recognises the lines of HTML comments, which may span lines
+type commentTracker struct {
+	open bool
+}
+
+// skip reports whether a trimmed line is part of an HTML comment
+func (c *commentTracker) skip(trimmed string) bool {
+	if c.open {
+		c.open = !strings.Contains(trimmed, "-->")
+		return true
+	}
+	if strings.HasPrefix(trimmed, "<!--") {
+		c.open = !strings.Contains(trimmed, "-->")
+		return true
+	}
+	return false
+}
@@ -0,0 +1,144 @@
+package parser
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestParseLinesWithDiagnostics_ReportsEveryProblem verifies problems are collected with positions and fixes
+func TestParseLinesWithDiagnostics_ReportsEveryProblem(t *testing.T) {
+	lines := strings.Split(`# TICKET: Checkout
+
+## Acceptence Criteria
+- Pays
+
+## Fields
+Priority High
+<!-- Owner: payments -->
+Sprint: 12
+
+## Notes
+### Open questions
+
+## Tasks
+* Wrong bullet
+- API
+  #### Fields
+  Type: Sub-task
+  ## Links
+- Docs
+  Write them
+
+# STORY: Old
+# TICKET:`, "\n")
+
+	tickets, diagnostics := New().ParseLinesWithDiagnostics(lines)
+
+	if len(tickets) != 1 || len(tickets[0].Tasks) != 2 {
+		t.Fatalf("Expected the ticket and both tasks to be parsed, got %+v", tickets)
+	}
+	if got := tickets[0].CustomFields; len(got) != 1 || got["Sprint"] != "12" {
+		t.Errorf("Expected only the Sprint field, got %v", got)
+	}
+
+	want := []struct {
+		severity     Severity
+		line, column int
+		message      string
+	}{
+		{SeverityWarning, 3, 1, "Unknown section '## Acceptence Criteria' is ignored (did you mean '## Acceptance Criteria'?)"},
+		{SeverityWarning, 7, 1, "Field line has no ':' and is ignored"},
+		{SeverityWarning, 15, 1, "Line in '## Tasks' is not a task item and is ignored (start task items with '- ')"},
+		{SeverityWarning, 17, 3, "Unknown section '#### Fields' is ignored (did you mean '## Fields'?)"},
+		{SeverityWarning, 19, 3, "Section '## Links' is not supported for tasks and is ignored"},
+		{SeverityWarning, 21, 3, "Line under task 'Docs' is not in a section and is ignored (put it under '  ## Description')"},
+		{SeverityError, 23, 1, "'# STORY:' format detected"},
+		{SeverityError, 24, 1, "Ticket heading has no title"},
+	}
+	if len(diagnostics) != len(want) {
+		t.Fatalf("Expected %d diagnostics, got %d:\n%v", len(want), len(diagnostics), diagnostics)
+	}
+	for i, w := range want {
+		d := diagnostics[i]
+		text := d.Message
+		if d.Suggestion != "" {
+			text += " (" + d.Suggestion + ")"
+		}
+		if d.Severity != w.severity || d.Line != w.line || d.Column != w.column || !strings.HasPrefix(text, w.message) {
+			t.Errorf("Diagnostic %d: expected %s at %d:%d %q, got %s", i, w.severity, w.line, w.column, w.message, d)
+		}
+	}
+}
+
+// TestParseLines_FailsWithAllErrors verifies ParseLines reports every error, not just the first
+func TestParseLines_FailsWithAllErrors(t *testing.T) {
+	lines := []string{"# STORY: One", "", "# Ticket: Two", "", "## Fields", "Priority High"}
+
+	tickets, err := New().ParseLines(lines)
+
+	if tickets != nil {
+		t.Errorf("Expected no tickets on error, got %v", tickets)
+	}
+	diagnostics, ok := err.(Diagnostics)
+	if !ok || len(diagnostics) != 2 {
+		t.Fatalf("Expected two error diagnostics, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "line 1, column 1") || !strings.Contains(err.Error(), "line 3, column 1: error: Malformed ticket heading") {
+		t.Errorf("Expected both lines in the error, got:\n%v", err)
+	}
+}
+
+// TestParseLinesWithDiagnostics_CustomSectionsAreQuiet verifies custom sections and comments produce no diagnostics
+func TestParseLinesWithDiagnostics_CustomSectionsAreQuiet(t *testing.T) {
+	lines := strings.Split(`<!-- Template -->
+# TICKET: Checkout
+
+## Notes
+### Background
+Anything goes here.
+
+## Tasks
+<!--
+  Add tasks below
+-->
+- API
+  ## Description
+  Build it.`, "\n")
+
+	_, diagnostics := New().ParseLinesWithDiagnostics(lines)
+
+	if len(diagnostics) != 0 {
+		t.Errorf("Expected no diagnostics, got %v", diagnostics)
+	}
+}
+
+// TestParseLines_RefusesConflictMarkers verifies files with unresolved conflict markers cannot be used
+func TestParseLines_RefusesConflictMarkers(t *testing.T) {
+	lines := strings.Split(`# TICKET: [PROJ-1] Checkout
+
+Heading
+=======
+
+## Fields
+<<<<<<< local
+Priority: Medium
+=======
+Priority: High
+>>>>>>> jira`, "\n")
+
+	_, err := New().ParseLines(lines)
+
+	diagnostics, ok := err.(Diagnostics)
+	if !ok {
+		t.Fatalf("Expected diagnostics, got %v", err)
+	}
+	var marked []int
+	for _, diagnostic := range diagnostics {
+		if strings.HasPrefix(diagnostic.Message, "Unresolved conflict marker") {
+			marked = append(marked, diagnostic.Line)
+		}
+	}
+	if len(marked) != 3 || marked[0] != 7 || marked[1] != 9 || marked[2] != 11 {
+		t.Errorf("Expected the markers on lines 7, 9 and 11 and not the heading underline, got %v", diagnostics)
+	}
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"os"
 	"regexp"
+	"slices"
+	"sort"
 	"strings"
 
 	"github.com/karolswdev/ticktr/internal/core/domain"
@@ -17,38 +19,88 @@ func New() *Parser {
 }
 
 func (p *Parser) Parse(filePath string) ([]domain.Ticket, error) {
+	tickets, diagnostics, err := p.ParseWithDiagnostics(filePath)
+	if err != nil {
+		return nil, err
+	}
+	if errors := diagnostics.Errors(); len(errors) > 0 {
+		return nil, errors
+	}
+	return tickets, nil
+}
+
+// ParseWithDiagnostics parses tickets from a file like Parse, and returns
+// every problem found instead of failing on errors. The error is only for
+// files that cannot be read.
+func (p *Parser) ParseWithDiagnostics(filePath string) ([]domain.Ticket, Diagnostics, error) {
 	file, err := os.Open(filePath)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open file: %w", err)
+		return nil, nil, fmt.Errorf("failed to open file: %w", err)
 	}
 	defer file.Close()
 
 	scanner := bufio.NewScanner(file)
 	var lines []string
-	lineNum := 0
 	for scanner.Scan() {
-		lineNum++
-		line := scanner.Text()
+		lines = append(lines, scanner.Text())
+	}
 
-		// Check for unsupported # STORY: format
-		if strings.HasPrefix(strings.TrimSpace(line), "# STORY:") {
-			//lint:ignore ST1005 Capitalized for clarity in user-facing error message
-			return nil, fmt.Errorf("'# STORY:' format detected at line %d - ticketr requires '# TICKET:' headings.", lineNum)
-		}
+	if err := scanner.Err(); err != nil {
+		return nil, nil, fmt.Errorf("error reading file: %w", err)
+	}
 
-		lines = append(lines, line)
+	tickets, diagnostics := p.ParseLinesWithDiagnostics(lines)
+	return tickets, diagnostics, nil
+}
+
+// ParseLines parses tickets from the lines of a Markdown file. It fails with
+// the file's Diagnostics errors, all of them, if there are any.
+func (p *Parser) ParseLines(lines []string) ([]domain.Ticket, error) {
+	tickets, diagnostics := p.ParseLinesWithDiagnostics(lines)
+	if errors := diagnostics.Errors(); len(errors) > 0 {
+		return nil, errors
 	}
+	return tickets, nil
+}
 
-	if err := scanner.Err(); err != nil {
-		return nil, fmt.Errorf("error reading file: %w", err)
+var (
+	ticketRegex = regexp.MustCompile(`^# TICKET:\s*(?:\[([^\]]+)\])?\s*(.+)$`)
+	// Anything that looks like it was meant to be a ticket heading
+	ticketLikeRegex = regexp.MustCompile(`(?i)^#\s*ticket\s*:`)
+)
+
+// ParseLinesWithDiagnostics parses tickets from the lines of a Markdown file
+// and reports, in line order, the problems found on the way: errors for
+// headings that cannot be read and for unresolved conflict markers, and
+// warnings for content that is ignored, such as misspelt sections and field
+// lines without a colon
+func (p *Parser) ParseLinesWithDiagnostics(lines []string) ([]domain.Ticket, Diagnostics) {
+	var diagnostics Diagnostics
+	for i, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		switch {
+		case strings.HasPrefix(trimmed, "# STORY:"):
+			diagnostics.add(SeverityError, lines, i,
+				"'# STORY:' format detected - ticketr requires '# TICKET:' headings",
+				"replace '# STORY:' with '# TICKET:'")
+		case ticketLikeRegex.MatchString(trimmed) && !ticketRegex.MatchString(line):
+			if strings.TrimSpace(trimmed[strings.Index(trimmed, ":")+1:]) == "" {
+				diagnostics.add(SeverityError, lines, i, "Ticket heading has no title", "write the title after '# TICKET:'")
+			} else {
+				diagnostics.add(SeverityError, lines, i, "Malformed ticket heading is ignored", "write it as '# TICKET: Title' at column 1")
+			}
+		}
 	}
 
-	return p.parseLines(lines)
+	diagnostics.checkConflictMarkers(lines)
+
+	tickets := p.parseLines(lines, &diagnostics)
+	sort.SliceStable(diagnostics, func(i, j int) bool { return diagnostics[i].Line < diagnostics[j].Line })
+	return tickets, diagnostics
 }
 
-func (p *Parser) parseLines(lines []string) ([]domain.Ticket, error) {
+func (p *Parser) parseLines(lines []string, diagnostics *Diagnostics) []domain.Ticket {
 	var tickets []domain.Ticket
-	ticketRegex := regexp.MustCompile(`^# TICKET:\s*(?:\[([^\]]+)\])?\s*(.+)$`)
 
 	for i := 0; i < len(lines); i++ {
 		matches := ticketRegex.FindStringSubmatch(lines[i])
@@ -62,7 +114,7 @@ func (p *Parser) parseLines(lines []string) ([]domain.Ticket, error) {
 
 			// Parse ticket sections
 			i++
-			nextIdx := p.parseTicketSections(&ticket, lines, i, 0)
+			nextIdx := p.parseTicketSections(&ticket, lines, i, 0, diagnostics)
 
 			tickets = append(tickets, ticket)
 
@@ -72,12 +124,13 @@ func (p *Parser) parseLines(lines []string) ([]domain.Ticket, error) {
 		}
 	}
 
-	return tickets, nil
+	return tickets
 }
 
-func (p *Parser) parseTicketSections(ticket *domain.Ticket, lines []string, startIdx int, indent int) int {
+func (p *Parser) parseTicketSections(ticket *domain.Ticket, lines []string, startIdx int, indent int, diagnostics *Diagnostics) int {
 	i := startIdx
 	indentStr := strings.Repeat(" ", indent)
+	customSection := false // Inside a "## " section the parser does not know
 
 	for i < len(lines) {
 		line := lines[i]
@@ -97,6 +150,12 @@ func (p *Parser) parseTicketSections(ticket *domain.Ticket, lines []string, star
 			line = line[indent:]
 		}
 
+		if strings.HasPrefix(line, "## ") {
+			customSection = !slices.ContainsFunc(ticketSections, func(name string) bool {
+				return strings.HasPrefix(line, "## "+name)
+			})
+		}
+
 		// Check for section headers
 		if strings.HasPrefix(line, "## Description") {
 			i++
@@ -105,19 +164,36 @@ func (p *Parser) parseTicketSections(ticket *domain.Ticket, lines []string, star
 			i = desc.nextIdx
 		} else if strings.HasPrefix(line, "## Fields") {
 			i++
-			fields := p.parseFieldsSection(lines, i, indent)
+			fields := p.parseFieldsSection(lines, i, indent, diagnostics)
 			for k, v := range fields.fields {
+				if k == domain.StatusField {
+					// Status is the ticket's own and not inherited by its tasks
+					ticket.Status = v
+					continue
+				}
 				ticket.CustomFields[k] = v
 			}
+			ticket.FieldOrder = fields.order
+			ticket.FieldLines = fields.lines
 			i = fields.nextIdx
 		} else if strings.HasPrefix(line, "## Acceptance Criteria") {
 			i++
 			ac := p.parseAcceptanceCriteria(lines, i, indent)
 			ticket.AcceptanceCriteria = ac.criteria
 			i = ac.nextIdx
+		} else if strings.HasPrefix(line, "## Links") {
+			i++
+			links := p.parseLinks(lines, i)
+			ticket.Links = links.links
+			i = links.nextIdx
+		} else if strings.HasPrefix(line, "## Comments") {
+			i++
+			comments := p.parseComments(lines, i)
+			ticket.Comments = comments.comments
+			i = comments.nextIdx
 		} else if strings.HasPrefix(line, "## Tasks") {
 			i++
-			tasks := p.parseTasks(lines, i, indent)
+			tasks := p.parseTasks(lines, i, indent, diagnostics)
 			ticket.Tasks = tasks.tasks
 			i = tasks.nextIdx
 			// If parseTasks found a next ticket, we should return that index
@@ -125,6 +201,16 @@ func (p *Parser) parseTicketSections(ticket *domain.Ticket, lines []string, star
 				return i
 			}
 		} else {
+			if trimmed := strings.TrimSpace(line); strings.HasPrefix(trimmed, "#") {
+				if strings.HasPrefix(trimmed, "## ") && line != trimmed && slices.Contains(ticketSections, strings.TrimSpace(trimmed[3:])) {
+					diagnostics.add(SeverityWarning, lines, i,
+						fmt.Sprintf("Section '%s' is indented and is ignored", trimmed),
+						"start ticket sections at column 1 and task sections at column 3")
+				} else if !customSection || strings.HasPrefix(trimmed, "## ") {
+					// Headings inside a custom section belong to it
+					diagnostics.checkHeading(lines, i, trimmed, false)
+				}
+			}
 			i++
 		}
 	}
@@ -150,12 +236,9 @@ func (p *Parser) parseMultilineSection(lines []string, startIdx int, baseIndent
 			break
 		}
 
-		// Check for task list item (starts with -)
-		if baseIndent > 0 && strings.TrimSpace(line) != "" && strings.HasPrefix(strings.TrimSpace(line), "-") && !strings.HasPrefix(line, strings.Repeat(" ", baseIndent+2)) {
-			break
-		}
-
-		// Add the line content (removing base indentation if present)
+		// Add the line content (removing base indentation if present). An
+		// indented list is part of a task's text; the next task item, back at
+		// the parent's indentation, ends it.
 		if baseIndent > 0 && strings.HasPrefix(line, strings.Repeat(" ", baseIndent)) {
 			content = append(content, line[baseIndent:])
 		} else if baseIndent == 0 {
@@ -177,11 +260,16 @@ func (p *Parser) parseMultilineSection(lines []string, startIdx int, baseIndent
 
 type fieldsResult struct {
 	fields  map[string]string
+	order   []string       // Field names in the order they are listed
+	lines   map[string]int // Line each field's value was read from
 	nextIdx int
 }
 
-func (p *Parser) parseFieldsSection(lines []string, startIdx int, baseIndent int) fieldsResult {
+func (p *Parser) parseFieldsSection(lines []string, startIdx int, baseIndent int, diagnostics *Diagnostics) fieldsResult {
 	fields := make(map[string]string)
+	fieldLines := make(map[string]int)
+	var order []string
+	var comments commentTracker
 	i := startIdx
 	fieldRegex := regexp.MustCompile(`^([^:]+):\s*(.*)$`)
 
@@ -211,14 +299,25 @@ func (p *Parser) parseFieldsSection(lines []string, startIdx int, baseIndent int
 			continue
 		}
 
-		if trimmed == "" {
+		if trimmed == "" || comments.skip(trimmed) {
 			i++
 			continue
 		}
 
 		// Parse field
 		if matches := fieldRegex.FindStringSubmatch(trimmed); matches != nil {
+			if strings.HasPrefix(matches[1], "- ") {
+				diagnostics.add(SeverityWarning, lines, i,
+					fmt.Sprintf("Field name '%s' starts with a list marker", matches[1]),
+					fmt.Sprintf("write it as '%s: %s'", strings.TrimSpace(matches[1][2:]), strings.TrimSpace(matches[2])))
+			}
+			if _, seen := fields[matches[1]]; !seen {
+				order = append(order, matches[1])
+			}
 			fields[matches[1]] = strings.TrimSpace(matches[2])
+			fieldLines[matches[1]] = i + 1
+		} else {
+			diagnostics.add(SeverityWarning, lines, i, "Field line has no ':' and is ignored", "write fields as 'Name: value'")
 		}
 
 		i++
@@ -226,6 +325,8 @@ func (p *Parser) parseFieldsSection(lines []string, startIdx int, baseIndent int
 
 	return fieldsResult{
 		fields:  fields,
+		order:   order,
+		lines:   fieldLines,
 		nextIdx: i,
 	}
 }
@@ -287,13 +388,107 @@ func (p *Parser) parseAcceptanceCriteria(lines []string, startIdx int, baseInden
 	}
 }
 
+type linksResult struct {
+	links   []domain.Link
+	nextIdx int
+}
+
+// parseLinks parses "- <relationship>: <KEY>" items, where a target in square
+// brackets is the title of a local ticket
+func (p *Parser) parseLinks(lines []string, startIdx int) linksResult {
+	var links []domain.Link
+	i := startIdx
+	linkRegex := regexp.MustCompile(`^-\s*([^:]+):\s*(.+)$`)
+
+	for i < len(lines) {
+		trimmed := strings.TrimSpace(lines[i])
+
+		// Stop at next section
+		if strings.HasPrefix(trimmed, "##") || strings.HasPrefix(trimmed, "# TICKET:") {
+			break
+		}
+
+		if matches := linkRegex.FindStringSubmatch(trimmed); matches != nil {
+			link := domain.Link{Type: strings.TrimSpace(matches[1])}
+			target := strings.TrimSpace(matches[2])
+			if strings.HasPrefix(target, "[") && strings.HasSuffix(target, "]") {
+				link.Title = strings.TrimSpace(target[1 : len(target)-1])
+			} else {
+				link.Key = target
+			}
+			links = append(links, link)
+		}
+
+		i++
+	}
+
+	return linksResult{
+		links:   links,
+		nextIdx: i,
+	}
+}
+
+type commentsResult struct {
+	comments []domain.Comment
+	nextIdx  int
+}
+
+// parseComments reads "- [id] author (created):" items pulled from Jira and
+// plain "- text" items written locally, each followed by body lines indented
+// by two spaces
+func (p *Parser) parseComments(lines []string, startIdx int) commentsResult {
+	var comments []domain.Comment
+	var body []string
+	i := startIdx
+	pulledRegex := regexp.MustCompile(`^-\s*\[(\d+)\]\s*(.*?)\s*\(([^()]*)\):\s*(.*)$`)
+
+	finish := func() {
+		if len(comments) > 0 {
+			comments[len(comments)-1].Body = strings.TrimSpace(strings.Join(body, "\n"))
+		}
+		body = nil
+	}
+
+	for i < len(lines) {
+		line := lines[i]
+
+		// Stop at the next unindented section
+		if strings.HasPrefix(line, "#") {
+			break
+		}
+
+		if strings.HasPrefix(line, "-") {
+			finish()
+			if matches := pulledRegex.FindStringSubmatch(line); matches != nil {
+				comments = append(comments, domain.Comment{ID: matches[1], Author: matches[2], Created: matches[3]})
+				body = []string{matches[4]}
+			} else {
+				comments = append(comments, domain.Comment{})
+				body = []string{strings.TrimSpace(strings.TrimPrefix(line, "-"))}
+			}
+		} else if len(comments) > 0 {
+			// Body lines, with blank lines between paragraphs kept
+			body = append(body, strings.TrimPrefix(line, "  "))
+		}
+
+		i++
+	}
+	finish()
+
+	return commentsResult{
+		comments: comments,
+		nextIdx:  i,
+	}
+}
+
 type tasksResult struct {
 	tasks   []domain.Task
 	nextIdx int
 }
 
-func (p *Parser) parseTasks(lines []string, startIdx int, baseIndent int) tasksResult {
+func (p *Parser) parseTasks(lines []string, startIdx int, baseIndent int, diagnostics *Diagnostics) tasksResult {
 	var tasks []domain.Task
+	var comments commentTracker
 	i := startIdx
 	taskRegex := regexp.MustCompile(`^-\s*(?:\[([^\]]+)\])?\s*(.+)$`)
 
@@ -326,10 +521,12 @@ func (p *Parser) parseTasks(lines []string, startIdx int, baseIndent int) tasksR
 
 			// Parse task sections (they should be indented)
 			i++
-			i = p.parseTaskSections(&task, lines, i, baseIndent+2)
+			i = p.parseTaskSections(&task, lines, i, baseIndent+2, diagnostics)
 
 			tasks = append(tasks, task)
 			i-- // Adjust because loop will increment
+		} else if trimmed != "" && !comments.skip(trimmed) {
+			diagnostics.add(SeverityWarning, lines, i, "Line in '## Tasks' is not a task item and is ignored", listSuggestion(trimmed))
 		}
 
 		i++
@@ -341,9 +538,11 @@ func (p *Parser) parseTasks(lines []string, startIdx int, baseIndent int) tasksR
 	}
 }
 
-func (p *Parser) parseTaskSections(task *domain.Task, lines []string, startIdx int, indent int) int {
+func (p *Parser) parseTaskSections(task *domain.Task, lines []string, startIdx int, indent int, diagnostics *Diagnostics) int {
 	i := startIdx
 	indentStr := strings.Repeat(" ", indent)
+	var comments commentTracker
+	underHeading := false
 
 	for i < len(lines) {
 		if i >= len(lines) {
@@ -381,25 +580,54 @@ func (p *Parser) parseTaskSections(task *domain.Task, lines []string, startIdx i
 		// Parse different sections
 		if strings.HasPrefix(trimmed, "## Description") {
 			i++
+			underHeading = false
 			desc := p.parseMultilineSection(lines, i, indent)
 			task.Description = strings.TrimSpace(desc.content)
 			i = desc.nextIdx
 		} else if strings.HasPrefix(trimmed, "## Fields") {
 			i++
-			fields := p.parseFieldsSection(lines, i, indent)
+			underHeading = false
+			fields := p.parseFieldsSection(lines, i, indent, diagnostics)
 			for k, v := range fields.fields {
+				if k == domain.StatusField {
+					task.Status = v
+					continue
+				}
 				task.CustomFields[k] = v
 			}
+			task.FieldOrder = fields.order
+			task.FieldLines = fields.lines
 			i = fields.nextIdx
 		} else if strings.HasPrefix(trimmed, "## Acceptance Criteria") {
 			i++
+			underHeading = false
 			ac := p.parseAcceptanceCriteria(lines, i, indent)
 			task.AcceptanceCriteria = ac.criteria
 			i = ac.nextIdx
 		} else {
+			if strings.HasPrefix(trimmed, "#") {
+				// The lines under an unknown heading belong to it
+				diagnostics.checkHeading(lines, i, trimmed, true)
+				underHeading = true
+			} else if trimmed != "" && !comments.skip(trimmed) && !underHeading {
+				suggestion := listSuggestion(trimmed)
+				if strings.HasPrefix(line, indentStr) {
+					suggestion = fmt.Sprintf("put it under '%s## Description'", indentStr)
+				}
+				diagnostics.add(SeverityWarning, lines, i,
+					fmt.Sprintf("Line under task '%s' is not in a section and is ignored", task.Title), suggestion)
+			}
 			i++
 		}
 	}
 
 	return i
 }
+
+// listSuggestion returns how to turn a line into a task item
+func listSuggestion(trimmed string) string {
+	if strings.HasPrefix(trimmed, "* ") || strings.HasPrefix(trimmed, "+ ") {
+		return "start task items with '- '"
+	}
+	return "start task items with '- ' or indent task content by 2 spaces"
+}
@@ -1,7 +1,10 @@
 package parser
 
 import (
+	"strings"
 	"testing"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
 )
 
 func TestParser_RecognizesTicketBlock(t *testing.T) {
@@ -97,6 +100,129 @@ func TestParser_ParsesNestedTasks(t *testing.T) {
 	}
 }
 
+func TestParser_ParsesLinks(t *testing.T) {
+	parser := New()
+
+	tickets, err := parser.Parse("../../testdata/ticket_with_links.md")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+
+	if len(tickets) != 2 {
+		t.Fatalf("Expected 2 tickets, got %d", len(tickets))
+	}
+
+	expected := []domain.Link{
+		{Type: "blocks", Key: "PROJ-12"},
+		{Type: "is blocked by", Title: "Build payment processing system"},
+		{Type: "relates to", Key: "PROJ-7"},
+	}
+	links := tickets[0].Links
+	if len(links) != len(expected) {
+		t.Fatalf("Expected %d links, got %v", len(expected), links)
+	}
+	for i, link := range expected {
+		if links[i] != link {
+			t.Errorf("Link %d: expected %+v, got %+v", i, link, links[i])
+		}
+	}
+
+	// The sections after the links are still parsed
+	if len(tickets[0].Tasks) != 1 {
+		t.Errorf("Expected 1 task after the links, got %d", len(tickets[0].Tasks))
+	}
+	if len(tickets[1].Links) != 0 {
+		t.Errorf("Expected no links on the second ticket, got %v", tickets[1].Links)
+	}
+}
+
+func TestParser_ParsesStatus(t *testing.T) {
+	parser := New()
+
+	tickets, err := parser.Parse("../../testdata/ticket_with_status.md")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(tickets) != 1 || len(tickets[0].Tasks) != 2 {
+		t.Fatalf("Expected 1 ticket with 2 tasks, got %+v", tickets)
+	}
+
+	ticket := tickets[0]
+	if ticket.Status != "In Review" {
+		t.Errorf("Expected ticket status 'In Review', got %q", ticket.Status)
+	}
+	if _, ok := ticket.CustomFields["Status"]; ok {
+		t.Error("Status should not be kept as a custom field, where tasks would inherit it")
+	}
+	if ticket.CustomFields["Priority"] != "High" {
+		t.Errorf("Expected the other fields to be kept, got %v", ticket.CustomFields)
+	}
+
+	if ticket.Tasks[0].Status != "Done" {
+		t.Errorf("Expected task status 'Done', got %q", ticket.Tasks[0].Status)
+	}
+	if ticket.Tasks[1].Status != "" {
+		t.Errorf("Expected no status on the second task, got %q", ticket.Tasks[1].Status)
+	}
+}
+
+func TestParser_RecordsFieldLines(t *testing.T) {
+	lines := strings.Split(`# TICKET: Checkout
+
+## Fields
+Priority: High
+Sprnt: 12
+
+## Tasks
+- API
+  ## Fields
+  Story Points: 3
+`, "\n")
+
+	tickets, err := New().ParseLines(lines)
+	if err != nil {
+		t.Fatalf("ParseLines failed: %v", err)
+	}
+	if tickets[0].FieldLines["Priority"] != 4 || tickets[0].FieldLines["Sprnt"] != 5 {
+		t.Errorf("Expected ticket fields on lines 4 and 5, got %v", tickets[0].FieldLines)
+	}
+	if tickets[0].Tasks[0].FieldLines["Story Points"] != 10 {
+		t.Errorf("Expected the task field on line 10, got %v", tickets[0].Tasks[0].FieldLines)
+	}
+}
+
+func TestParser_ParsesComments(t *testing.T) {
+	parser := New()
+
+	tickets, err := parser.Parse("../../testdata/ticket_with_comments.md")
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if len(tickets) != 1 {
+		t.Fatalf("Expected 1 ticket, got %d", len(tickets))
+	}
+
+	expected := []domain.Comment{
+		{ID: "10001", Author: "Jane Doe", Created: "2024-01-02T10:00:00.000+0000", Body: "Should partial refunds be in scope?\n\nThey come up a lot."},
+		{ID: "10002", Author: "Sam Lee", Created: "2024-01-03T09:30:00.000+0000", Body: "Yes, partial refunds are in scope."},
+		{Body: "Agreed, adding them to the acceptance criteria."},
+	}
+	comments := tickets[0].Comments
+	if len(comments) != len(expected) {
+		t.Fatalf("Expected %d comments, got %+v", len(expected), comments)
+	}
+	for i, comment := range expected {
+		if comments[i] != comment {
+			t.Errorf("Comment %d: expected %+v, got %+v", i, comment, comments[i])
+		}
+	}
+
+	// The sections after the comments are still parsed
+	if len(tickets[0].Tasks) != 1 {
+		t.Errorf("Expected 1 task after the comments, got %d", len(tickets[0].Tasks))
+	}
+}
+
 func TestParser_RejectsStoryHeading(t *testing.T) {
 	parser := New()
 
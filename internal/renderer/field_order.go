@@ -0,0 +1,68 @@
+package renderer
+
+import (
+	"sort"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+)
+
+// FieldOrder is the canonical order of the lines in a ## Fields block: the
+// order the source file lists them in when known, then the Priority fields,
+// then the rest alphabetically
+type FieldOrder struct {
+	Priority []string // Field names to list first, e.g. from markdown.field_order in .ticketr.yaml
+}
+
+// DefaultFieldOrder lists Status first and sorts the other fields by name
+var DefaultFieldOrder = FieldOrder{Priority: []string{domain.StatusField}}
+
+// NewFieldOrder returns the order for a configured priority list, or the
+// default order when none is configured
+func NewFieldOrder(priority []string) FieldOrder {
+	if len(priority) == 0 {
+		return DefaultFieldOrder
+	}
+	return FieldOrder{Priority: priority}
+}
+
+// Sort returns names in canonical order. Names in source come first, in the
+// order source lists them; names source does not list follow by priority,
+// then alphabetically.
+func (o FieldOrder) Sort(names, source []string) []string {
+	rank := make(map[string]int, len(source)+len(o.Priority))
+	for _, name := range o.Priority {
+		if _, ok := rank[name]; !ok {
+			rank[name] = len(source) + len(rank)
+		}
+	}
+	for i, name := range source {
+		rank[name] = i
+	}
+
+	sorted := append([]string(nil), names...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		ri, iRanked := rank[sorted[i]]
+		rj, jRanked := rank[sorted[j]]
+		switch {
+		case iRanked && jRanked:
+			return ri < rj
+		case iRanked || jRanked:
+			return iRanked
+		}
+		return sorted[i] < sorted[j]
+	})
+	return sorted
+}
+
+// FieldNames returns the names of a ticket or task's fields in canonical
+// order, Status included when set
+func (o FieldOrder) FieldNames(status string, fields map[string]string, source []string) []string {
+	names := make([]string, 0, len(fields)+1)
+	if status != "" {
+		names = append(names, domain.StatusField)
+	}
+	for name := range fields {
+		names = append(names, name)
+	}
+	return o.Sort(names, source)
+}
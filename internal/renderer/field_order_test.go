@@ -0,0 +1,57 @@
+package renderer
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+)
+
+// TestFieldOrder_Sort verifies source order comes first, then priority, then names alphabetically
+func TestFieldOrder_Sort(t *testing.T) {
+	order := FieldOrder{Priority: []string{"Status", "Type", "Priority"}}
+	names := []string{"Sprint", "Priority", "Assignee", "Labels", "Type", "Status"}
+
+	tests := []struct {
+		name   string
+		source []string
+		want   []string
+	}{
+		{"no source", nil, []string{"Status", "Type", "Priority", "Assignee", "Labels", "Sprint"}},
+		{"source", []string{"Sprint", "Type"}, []string{"Sprint", "Type", "Status", "Priority", "Assignee", "Labels"}},
+	}
+	for _, tt := range tests {
+		if got := order.Sort(names, tt.source); !slices.Equal(got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+
+	if got := NewFieldOrder(nil).Sort(names, nil); got[0] != domain.StatusField || !slices.IsSorted(got[1:]) {
+		t.Errorf("Expected the default order to put Status first and sort the rest, got %v", got)
+	}
+}
+
+// TestRenderer_FieldsInCanonicalOrder verifies Render writes fields in the same order every time
+func TestRenderer_FieldsInCanonicalOrder(t *testing.T) {
+	ticket := domain.Ticket{
+		Title:        "Ordered",
+		Status:       "To Do",
+		CustomFields: map[string]string{"Sprint": "12", "Assignee": "jane", "Priority": "High", "Labels": "ui"},
+		Tasks: []domain.Task{{
+			Title:        "Task",
+			CustomFields: map[string]string{"Sprint": "12", "Assignee": "joe"},
+			FieldOrder:   []string{"Sprint"},
+		}},
+	}
+	renderer := NewRendererWithFieldOrder(nil, FieldOrder{Priority: []string{"Priority"}})
+
+	want := "## Fields\nPriority: High\nAssignee: jane\nLabels: ui\nSprint: 12\nStatus: To Do\n"
+	taskWant := "  ## Fields\n  Sprint: 12\n  Assignee: joe\n"
+	for i := 0; i < 10; i++ {
+		result := renderer.Render(ticket)
+		if !strings.Contains(result, want) || !strings.Contains(result, taskWant) {
+			t.Fatalf("Expected fields in canonical order, got:\n%s", result)
+		}
+	}
+}
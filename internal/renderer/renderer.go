@@ -7,18 +7,45 @@ import (
 	"github.com/karolswdev/ticktr/internal/core/domain"
 )
 
-// Renderer handles conversion of tickets to Markdown format
+// Section names, in the order the renderer writes them
+const (
+	SectionDescription        = "Description"
+	SectionFields             = "Fields"
+	SectionAcceptanceCriteria = "Acceptance Criteria"
+	SectionLinks              = "Links"
+	SectionComments           = "Comments"
+	SectionTasks              = "Tasks"
+)
+
+var (
+	// TicketSections are the sections of a ticket, in the order they are written
+	TicketSections = []string{SectionDescription, SectionFields, SectionAcceptanceCriteria, SectionLinks, SectionComments, SectionTasks}
+	// TaskSections are the sections of a task, in the order they are written
+	TaskSections = []string{SectionDescription, SectionFields, SectionAcceptanceCriteria}
+)
+
+// Renderer handles conversion of tickets to Markdown format. It writes the
+// grammar the parser reads, so parsing what it renders gives back the same
+// tickets.
 type Renderer struct {
 	fieldMappings map[string]interface{}
+	fieldOrder    FieldOrder
 }
 
 // NewRenderer creates a new Renderer instance
 func NewRenderer(fieldMappings map[string]interface{}) *Renderer {
+	return NewRendererWithFieldOrder(fieldMappings, DefaultFieldOrder)
+}
+
+// NewRendererWithFieldOrder creates a Renderer that writes ## Fields blocks in
+// the given order
+func NewRendererWithFieldOrder(fieldMappings map[string]interface{}, fieldOrder FieldOrder) *Renderer {
 	if fieldMappings == nil {
 		fieldMappings = getDefaultFieldMappings()
 	}
 	return &Renderer{
 		fieldMappings: fieldMappings,
+		fieldOrder:    fieldOrder,
 	}
 }
 
@@ -43,102 +70,196 @@ func getDefaultFieldMappings() map[string]interface{} {
 	}
 }
 
+// FieldOrder returns the order the renderer writes ## Fields blocks in
+func (r *Renderer) FieldOrder() FieldOrder {
+	return r.fieldOrder
+}
+
 // Render converts a domain.Ticket to Markdown format
 func (r *Renderer) Render(ticket domain.Ticket) string {
-	var sb strings.Builder
+	return strings.Join(r.TicketLines(ticket), "\n")
+}
 
-	// Title with JIRA ID if present
-	if ticket.JiraID != "" {
-		sb.WriteString(fmt.Sprintf("# TICKET: [%s] %s\n", ticket.JiraID, ticket.Title))
-	} else {
-		sb.WriteString(fmt.Sprintf("# TICKET: %s\n", ticket.Title))
-	}
-	sb.WriteString("\n")
-
-	// Custom fields section (excluding Type which is handled differently in some cases)
-	hasCustomFields := false
-	for fieldName, fieldValue := range ticket.CustomFields {
-		if fieldName != "Type" && fieldName != "Parent" && fieldValue != "" {
-			if !hasCustomFields {
-				sb.WriteString("## Fields\n")
-				hasCustomFields = true
-			}
-			sb.WriteString(fmt.Sprintf("- %s: %s\n", fieldName, fieldValue))
-		}
+// RenderMultiple renders multiple tickets to a single Markdown document
+func (r *Renderer) RenderMultiple(tickets []domain.Ticket) string {
+	lines := r.Lines(tickets)
+	if len(lines) == 0 {
+		return ""
 	}
-	if hasCustomFields {
-		sb.WriteString("\n")
+	return strings.Join(lines, "\n") + "\n"
+}
+
+// Lines renders tickets as the lines of a Markdown document
+func (r *Renderer) Lines(tickets []domain.Ticket) []string {
+	var lines []string
+	for i, ticket := range tickets {
+		if i > 0 {
+			// Add spacing between tickets
+			lines = append(lines, "")
+		}
+		lines = append(lines, r.TicketLines(ticket)...)
 	}
+	return lines
+}
 
-	// Description section
-	if ticket.Description != "" {
-		sb.WriteString("## Description\n")
-		sb.WriteString(ticket.Description)
-		sb.WriteString("\n\n")
+// TicketLines renders a ticket: its heading, then each non-empty section
+// followed by a blank line
+func (r *Renderer) TicketLines(ticket domain.Ticket) []string {
+	lines := []string{TicketHeading(ticket), ""}
+	for _, name := range TicketSections {
+		if body := r.TicketSection(ticket, name); len(body) > 0 {
+			lines = append(lines, "## "+name)
+			lines = append(lines, body...)
+			lines = append(lines, "")
+		}
 	}
+	return lines
+}
 
-	// Acceptance Criteria section
-	if len(ticket.AcceptanceCriteria) > 0 {
-		sb.WriteString("## Acceptance Criteria\n")
-		for _, criterion := range ticket.AcceptanceCriteria {
-			sb.WriteString(fmt.Sprintf("- %s\n", criterion))
+// TaskLines renders a task: its list item, then each non-empty section
+// indented under it and followed by a blank line
+func (r *Renderer) TaskLines(task domain.Task) []string {
+	lines := []string{TaskHeading(task)}
+	for _, name := range TaskSections {
+		if body := r.TaskSection(task, name); len(body) > 0 {
+			lines = append(lines, "  ## "+name)
+			lines = append(lines, body...)
+			lines = append(lines, "")
 		}
-		sb.WriteString("\n")
 	}
+	return lines
+}
+
+// TicketHeading returns the "# TICKET:" line, with the Jira ID if present
+func TicketHeading(ticket domain.Ticket) string {
+	if ticket.JiraID != "" {
+		return fmt.Sprintf("# TICKET: [%s] %s", ticket.JiraID, ticket.Title)
+	}
+	return fmt.Sprintf("# TICKET: %s", ticket.Title)
+}
 
-	// Tasks section
-	if len(ticket.Tasks) > 0 {
-		sb.WriteString("## Tasks\n")
+// TaskHeading returns a task's list item line, with the Jira ID if present
+func TaskHeading(task domain.Task) string {
+	if task.JiraID != "" {
+		return fmt.Sprintf("- [%s] %s", task.JiraID, task.Title)
+	}
+	return fmt.Sprintf("- %s", task.Title)
+}
+
+// TicketSection returns the lines under a ticket section's heading, or nil
+// when the section would be empty
+func (r *Renderer) TicketSection(ticket domain.Ticket, name string) []string {
+	switch name {
+	case SectionDescription:
+		return textLines(ticket.Description, "")
+	case SectionFields:
+		return r.FieldLines(ticket.Status, ticket.CustomFields, ticket.FieldOrder, "")
+	case SectionAcceptanceCriteria:
+		return criteriaLines(ticket.AcceptanceCriteria, "")
+	case SectionLinks:
+		return linkLines(ticket.Links)
+	case SectionComments:
+		return commentLines(ticket.Comments)
+	case SectionTasks:
+		var lines []string
 		for _, task := range ticket.Tasks {
-			if task.JiraID != "" {
-				sb.WriteString(fmt.Sprintf("- [%s] %s\n", task.JiraID, task.Title))
-			} else {
-				sb.WriteString(fmt.Sprintf("- %s\n", task.Title))
-			}
-
-			// Task custom fields (indented)
-			for fieldName, fieldValue := range task.CustomFields {
-				if fieldValue != "" {
-					sb.WriteString(fmt.Sprintf("  - %s: %s\n", fieldName, fieldValue))
-				}
-			}
-
-			// Task description (indented)
-			if task.Description != "" {
-				lines := strings.Split(task.Description, "\n")
-				for _, line := range lines {
-					if line != "" {
-						sb.WriteString(fmt.Sprintf("  %s\n", line))
-					}
-				}
-			}
-
-			// Task acceptance criteria (indented)
-			if len(task.AcceptanceCriteria) > 0 {
-				sb.WriteString("  ### Acceptance Criteria\n")
-				for _, criterion := range task.AcceptanceCriteria {
-					sb.WriteString(fmt.Sprintf("  - %s\n", criterion))
-				}
-			}
+			lines = append(lines, r.TaskLines(task)...)
 		}
-		sb.WriteString("\n")
+		return trimTrailingBlank(lines)
 	}
+	return nil
+}
 
-	return sb.String()
+// TaskSection returns the indented lines under a task section's heading, or
+// nil when the section would be empty
+func (r *Renderer) TaskSection(task domain.Task, name string) []string {
+	switch name {
+	case SectionDescription:
+		return textLines(task.Description, "  ")
+	case SectionFields:
+		return r.FieldLines(task.Status, task.CustomFields, task.FieldOrder, "  ")
+	case SectionAcceptanceCriteria:
+		return criteriaLines(task.AcceptanceCriteria, "  ")
+	}
+	return nil
 }
 
-// RenderMultiple renders multiple tickets to a single Markdown document
-func (r *Renderer) RenderMultiple(tickets []domain.Ticket) string {
-	var sb strings.Builder
+// FieldLines renders "Key: Value" lines in the renderer's field order,
+// keeping the order source lists them in
+func (r *Renderer) FieldLines(status string, fields map[string]string, source []string, indent string) []string {
+	var lines []string
+	for _, name := range r.fieldOrder.FieldNames(status, fields, source) {
+		lines = append(lines, fmt.Sprintf("%s%s: %s", indent, name, fieldValue(name, status, fields)))
+	}
+	return lines
+}
 
-	for i, ticket := range tickets {
-		sb.WriteString(r.Render(ticket))
+// fieldValue returns the value of a field listed by FieldOrder.FieldNames
+func fieldValue(name, status string, fields map[string]string) string {
+	if name == domain.StatusField && status != "" {
+		return status
+	}
+	return fields[name]
+}
 
-		// Add separator between tickets except for the last one
-		if i < len(tickets)-1 {
-			sb.WriteString("---\n\n")
+// textLines indents each non-blank line of a multi-line text
+func textLines(text, indent string) []string {
+	if text == "" {
+		return nil
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = indent + line
 		}
 	}
+	return lines
+}
 
-	return sb.String()
+// criteriaLines renders acceptance criteria as a list
+func criteriaLines(criteria []string, indent string) []string {
+	var lines []string
+	for _, ac := range criteria {
+		lines = append(lines, fmt.Sprintf("%s- %s", indent, ac))
+	}
+	return lines
+}
+
+// linkLines renders "- relationship: KEY" items, or "[Title]" for local targets
+func linkLines(links []domain.Link) []string {
+	var lines []string
+	for _, link := range links {
+		if link.Key != "" {
+			lines = append(lines, fmt.Sprintf("- %s: %s", link.Type, link.Key))
+		} else {
+			lines = append(lines, fmt.Sprintf("- %s: [%s]", link.Type, link.Title))
+		}
+	}
+	return lines
+}
+
+// commentLines renders pulled comments under a "[id] author (created):" item
+// and unsent local ones as plain items, with their bodies indented
+func commentLines(comments []domain.Comment) []string {
+	var lines []string
+	for _, comment := range comments {
+		body := strings.Split(comment.Body, "\n")
+		if comment.ID != "" {
+			lines = append(lines, fmt.Sprintf("- [%s] %s (%s):", comment.ID, comment.Author, comment.Created))
+		} else {
+			lines = append(lines, fmt.Sprintf("- %s", body[0]))
+			body = body[1:]
+		}
+		lines = append(lines, textLines(strings.Join(body, "\n"), "  ")...)
+	}
+	return lines
+}
+
+// trimTrailingBlank drops the blank lines at the end of lines
+func trimTrailingBlank(lines []string) []string {
+	end := len(lines)
+	for end > 0 && strings.TrimSpace(lines[end-1]) == "" {
+		end--
+	}
+	return lines[:end]
 }
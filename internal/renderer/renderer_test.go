@@ -25,6 +25,10 @@ func TestRenderer_GeneratesCorrectMarkdown(t *testing.T) {
 			"Session management is implemented",
 		},
 		JiraID: "PROJ-123",
+		Links: []domain.Link{
+			{Type: "blocks", Key: "PROJ-130"},
+			{Type: "relates to", Title: "Audit logging"},
+		},
 		Tasks: []domain.Task{
 			{
 				Title:       "Set up authentication database schema",
@@ -72,14 +76,18 @@ func TestRenderer_GeneratesCorrectMarkdown(t *testing.T) {
 		t.Errorf("Result does not contain Fields section")
 	}
 
-	if !strings.Contains(result, "- Priority: High") {
+	if !strings.Contains(result, "\nPriority: High\n") {
 		t.Errorf("Result does not contain Priority field")
 	}
 
-	if !strings.Contains(result, "- Story Points: 5") {
+	if !strings.Contains(result, "\nStory Points: 5\n") {
 		t.Errorf("Result does not contain Story Points field")
 	}
 
+	if !strings.Contains(result, "## Links\n- blocks: PROJ-130\n- relates to: [Audit logging]\n") {
+		t.Errorf("Result does not contain the Links section")
+	}
+
 	if !strings.Contains(result, "## Tasks") {
 		t.Errorf("Result does not contain Tasks section")
 	}
@@ -0,0 +1,207 @@
+package renderer
+
+import (
+	"fmt"
+	"math/rand"
+	"reflect"
+	"strings"
+	"testing"
+	"testing/quick"
+
+	"github.com/karolswdev/ticktr/internal/core/domain"
+	"github.com/karolswdev/ticktr/internal/parser"
+)
+
+// randomTickets generates tickets the Markdown grammar can hold: titles,
+// keys and single-line values are trimmed and free of the characters that
+// delimit them, titles do not start with "[", and text lines do not start
+// with "#".
+type randomTickets []domain.Ticket
+
+var (
+	words      = []string{"checkout", "basket", "pay", "**bold**", "`code`", "a:b", "(maybe)", "[link](https://example.com)", "50%", "don't", "-dash", "#7"}
+	fieldNames = []string{"Type", "Priority", "Story Points", "Sprint", "Assignee", "Labels", "Parent", "customfield_10042"}
+	linkTypes  = []string{"blocks", "is blocked by", "relates to", "duplicates"}
+)
+
+func (randomTickets) Generate(rnd *rand.Rand, size int) reflect.Value {
+	tickets := make(randomTickets, 1+rnd.Intn(3))
+	for i := range tickets {
+		tickets[i] = randomTicket(rnd)
+	}
+	return reflect.ValueOf(tickets)
+}
+
+func randomTicket(rnd *rand.Rand) domain.Ticket {
+	ticket := domain.Ticket{
+		Title:              title(rnd),
+		JiraID:             maybe(rnd, fmt.Sprintf("PROJ-%d", rnd.Intn(1000))),
+		Status:             maybe(rnd, "In Progress"),
+		Description:        text(rnd),
+		AcceptanceCriteria: items(rnd),
+	}
+	ticket.CustomFields, ticket.FieldOrder = fields(rnd, ticket.Status)
+
+	for n := rnd.Intn(3); n > 0; n-- {
+		link := domain.Link{Type: linkTypes[rnd.Intn(len(linkTypes))]}
+		if rnd.Intn(2) == 0 {
+			link.Key = fmt.Sprintf("PROJ-%d", rnd.Intn(1000))
+		} else {
+			link.Title = phrase(rnd, 1+rnd.Intn(4))
+		}
+		ticket.Links = append(ticket.Links, link)
+	}
+
+	for n := rnd.Intn(3); n > 0; n-- {
+		comment := domain.Comment{Body: phrase(rnd, 1+rnd.Intn(6))}
+		if rnd.Intn(2) == 0 {
+			comment.ID = fmt.Sprint(10000 + rnd.Intn(1000))
+			comment.Author = "Jane Doe"
+			comment.Created = "2024-01-02T10:00:00.000+0000"
+			comment.Body = text(rnd)
+		}
+		ticket.Comments = append(ticket.Comments, comment)
+	}
+
+	for n := rnd.Intn(4); n > 0; n-- {
+		task := domain.Task{
+			Title:              title(rnd),
+			JiraID:             maybe(rnd, fmt.Sprintf("PROJ-%d", rnd.Intn(1000))),
+			Status:             maybe(rnd, "Done"),
+			Description:        text(rnd),
+			AcceptanceCriteria: items(rnd),
+		}
+		task.CustomFields, task.FieldOrder = fields(rnd, task.Status)
+		ticket.Tasks = append(ticket.Tasks, task)
+	}
+	return ticket
+}
+
+// phrase returns n words joined by spaces
+func phrase(rnd *rand.Rand, n int) string {
+	parts := make([]string, n)
+	for i := range parts {
+		parts[i] = words[rnd.Intn(len(words))]
+	}
+	return strings.Join(parts, " ")
+}
+
+// title returns a phrase that does not start with "[", which would be read
+// as a Jira key
+func title(rnd *rand.Rand) string {
+	for {
+		if t := phrase(rnd, 1+rnd.Intn(5)); !strings.HasPrefix(t, "[") {
+			return t
+		}
+	}
+}
+
+// maybe returns s or, half the time, ""
+func maybe(rnd *rand.Rand, s string) string {
+	if rnd.Intn(2) == 0 {
+		return ""
+	}
+	return s
+}
+
+// text returns multi-line Markdown: paragraphs, lists and indented code
+// separated by blank lines, or ""
+func text(rnd *rand.Rand) string {
+	var lines []string
+	for n := rnd.Intn(4); n > 0; n-- {
+		if len(lines) > 0 {
+			lines = append(lines, "")
+		}
+		switch rnd.Intn(3) {
+		case 0:
+			lines = append(lines, phrase(rnd, 1+rnd.Intn(8)), phrase(rnd, 1+rnd.Intn(8)))
+		case 1:
+			lines = append(lines, "- "+phrase(rnd, 2), "  - "+phrase(rnd, 2), "- "+phrase(rnd, 2))
+		case 2:
+			lines = append(lines, "Example:", "    go test ./...")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// items returns a list of single-line items, or nil
+func items(rnd *rand.Rand) []string {
+	var list []string
+	for n := rnd.Intn(4); n > 0; n-- {
+		list = append(list, phrase(rnd, 1+rnd.Intn(6)))
+	}
+	return list
+}
+
+// fields returns random custom fields and the order to list them in,
+// Status included when set
+func fields(rnd *rand.Rand, status string) (map[string]string, []string) {
+	values := make(map[string]string)
+	var order []string
+	if status != "" {
+		order = append(order, domain.StatusField)
+	}
+	for _, name := range fieldNames {
+		if rnd.Intn(3) == 0 {
+			values[name] = phrase(rnd, 1+rnd.Intn(3))
+			order = append(order, name)
+		}
+	}
+	rnd.Shuffle(len(order), func(i, j int) { order[i], order[j] = order[j], order[i] })
+	return values, order
+}
+
+// TestRenderer_RoundTrip verifies parsing what the renderer writes gives back the same tickets
+func TestRenderer_RoundTrip(t *testing.T) {
+	renderer := NewRenderer(nil)
+	p := parser.New()
+
+	property := func(tickets randomTickets) bool {
+		rendered := renderer.RenderMultiple(tickets)
+		parsed, err := p.ParseLines(strings.Split(rendered, "\n"))
+		if err != nil {
+			t.Logf("Parse failed: %v", err)
+			return false
+		}
+		for i := range parsed {
+			parsed[i].SourceLine, parsed[i].FieldLines = 0, nil
+			for j := range parsed[i].Tasks {
+				parsed[i].Tasks[j].SourceLine, parsed[i].Tasks[j].FieldLines = 0, nil
+			}
+		}
+		if !reflect.DeepEqual([]domain.Ticket(tickets), parsed) {
+			t.Logf("Rendered:\n%s\nWant: %#v\nGot:  %#v", rendered, []domain.Ticket(tickets), parsed)
+			return false
+		}
+		return true
+	}
+
+	if err := quick.Check(property, &quick.Config{MaxCount: 500}); err != nil {
+		t.Error(err)
+	}
+}
+
+// TestRenderer_RoundTripSingle verifies Render writes one ticket the parser reads back
+func TestRenderer_RoundTripSingle(t *testing.T) {
+	ticket := domain.Ticket{
+		Title:        "Multi-line task text",
+		CustomFields: map[string]string{},
+		Tasks: []domain.Task{{
+			Title:        "Document the API",
+			Description:  "Cover:\n\n- auth\n- errors\n\nThen publish.",
+			CustomFields: map[string]string{"Type": "Sub-task"},
+			FieldOrder:   []string{"Type"},
+		}},
+	}
+
+	parsed, err := parser.New().ParseLines(strings.Split(NewRenderer(nil).Render(ticket), "\n"))
+	if err != nil || len(parsed) != 1 {
+		t.Fatalf("Expected one ticket, got %v, %v", parsed, err)
+	}
+	if got := parsed[0].Tasks[0].Description; got != ticket.Tasks[0].Description {
+		t.Errorf("Expected the task description to survive, got %q", got)
+	}
+	if got := parsed[0].Tasks[0].CustomFields["Type"]; got != "Sub-task" {
+		t.Errorf("Expected the Type field to be written, got %q", got)
+	}
+}
@@ -0,0 +1,55 @@
+package state
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// lockRetryInterval is how often Lock retries a lock held elsewhere
+const lockRetryInterval = 100 * time.Millisecond
+
+// Lock takes an advisory lock on the state file, waiting while another
+// process holds it, so two runs never load and save the state over each
+// other. The lock is on a ".lock" file next to the state file, since Save
+// replaces the state file itself. It gives up when ctx is done. The returned
+// function releases the lock; exiting the process releases it too.
+func (sm *StateManager) Lock(ctx context.Context) (func(), error) {
+	path := sm.stateFilePath + ".lock"
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return nil, fmt.Errorf("failed to create state directory: %w", err)
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR, 0644)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open state lock: %w", err)
+	}
+
+	waiting := false
+	for {
+		locked, err := tryLockFile(file)
+		if err != nil {
+			file.Close()
+			return nil, fmt.Errorf("failed to lock %s: %w", path, err)
+		}
+		if locked {
+			return func() {
+				unlockFile(file)
+				file.Close()
+			}, nil
+		}
+
+		if !waiting {
+			log.Printf("Waiting for another ticketr process to release %s", path)
+			waiting = true
+		}
+		select {
+		case <-ctx.Done():
+			file.Close()
+			return nil, fmt.Errorf("state file %s is locked by another ticketr process: %w", sm.stateFilePath, ctx.Err())
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
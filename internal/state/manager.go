@@ -3,25 +3,71 @@ package state
 import (
 	"crypto/sha256"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"sort"
+	"sync"
 
 	"github.com/karolswdev/ticktr/internal/core/domain"
 )
 
 // TicketState represents the state of a ticket with bidirectional hashes
 type TicketState struct {
-	LocalHash  string `json:"local_hash"`
-	RemoteHash string `json:"remote_hash"`
+	LocalHash  string    `json:"local_hash"`
+	RemoteHash string    `json:"remote_hash"`
+	Base       *Snapshot `json:"base,omitempty"`     // Content when last in sync, nil in state files from before snapshots
+	Conflict   *Conflict `json:"conflict,omitempty"` // Jira's version a pull could not merge, until resolved
 }
 
-// StateManager manages the state file for tracking ticket changes
+// Conflict is Jira's version of a ticket that a pull found changed on both
+// sides and could not merge. Resolving the conflict makes it the version the
+// ticket was last in sync with.
+type Conflict struct {
+	RemoteHash string    `json:"remote_hash"`
+	Remote     *Snapshot `json:"remote"`
+}
+
+// currentVersion is the version of the state file format Save writes.
+// Version 1 files, a flat map of ticket ID to state, are migrated on load.
+const currentVersion = 2
+
+// stateFile is the layout of the state file on disk
+type stateFile struct {
+	Version int                    `json:"version"`
+	Tickets map[string]TicketState `json:"tickets"`
+}
+
+// CorruptStateError is returned by Load when the state file cannot be
+// decoded. Backup names the copy of the state before the last save, or is
+// empty when there is none.
+type CorruptStateError struct {
+	Path   string
+	Backup string
+	Err    error
+}
+
+func (e *CorruptStateError) Error() string {
+	recovery := fmt.Sprintf("delete %s to start tracking afresh", e.Path)
+	if e.Backup != "" {
+		recovery = fmt.Sprintf("restore the previous state with \"mv %s %s\", or %s", e.Backup, e.Path, recovery)
+	}
+	return fmt.Sprintf("state file %s is corrupt (%v); %s", e.Path, e.Err, recovery)
+}
+
+func (e *CorruptStateError) Unwrap() error {
+	return e.Err
+}
+
+// StateManager manages the state file for tracking ticket changes. It is safe
+// for concurrent use, so files can be pushed in parallel against one state.
 type StateManager struct {
 	stateFilePath string
+	mu            sync.Mutex             // Guards state and unreadable
 	state         map[string]TicketState // Maps ticket ID to bidirectional state
+	unreadable    bool                   // The last Load could not read the file, so Save must not replace it
 }
 
 // NewStateManager creates a new state manager instance
@@ -36,28 +82,84 @@ func NewStateManager(stateFilePath string) *StateManager {
 	}
 }
 
-// Load reads the state file from disk
+// backupPath is where Save keeps the previous state file
+func (sm *StateManager) backupPath() string {
+	return sm.stateFilePath + ".bak"
+}
+
+// Load reads the state file from disk, migrating older formats. A file that
+// cannot be decoded gives a *CorruptStateError, and Save then refuses to
+// overwrite it until a later Load succeeds.
 func (sm *StateManager) Load() error {
-	// If state file doesn't exist, that's okay - we start with empty state
-	if _, err := os.Stat(sm.stateFilePath); os.IsNotExist(err) {
+	data, err := os.ReadFile(sm.stateFilePath)
+	if os.IsNotExist(err) {
+		// If state file doesn't exist, that's okay - we start with empty state
 		return nil
 	}
-
-	file, err := os.Open(sm.stateFilePath)
 	if err != nil {
-		return fmt.Errorf("failed to open state file: %w", err)
+		return fmt.Errorf("failed to read state file: %w", err)
 	}
-	defer file.Close()
 
-	decoder := json.NewDecoder(file)
-	if err := decoder.Decode(&sm.state); err != nil {
-		return fmt.Errorf("failed to decode state file: %w", err)
+	tickets, err := decodeState(data)
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	sm.unreadable = err != nil
+	if err != nil {
+		var versionErr *versionError
+		if errors.As(err, &versionErr) {
+			return fmt.Errorf("state file %s: %w", sm.stateFilePath, err)
+		}
+		corrupt := &CorruptStateError{Path: sm.stateFilePath, Err: err}
+		if _, err := os.Stat(sm.backupPath()); err == nil {
+			corrupt.Backup = sm.backupPath()
+		}
+		return corrupt
+	}
+	for id, state := range tickets {
+		sm.state[id] = state
 	}
 
 	return nil
 }
 
-// Save writes the current state to disk
+// versionError reports a state file written by a newer ticketr
+type versionError struct {
+	version int
+}
+
+func (e *versionError) Error() string {
+	return fmt.Sprintf("format version %d is newer than this ticketr supports (%d); upgrade ticketr", e.version, currentVersion)
+}
+
+// decodeState decodes a state file of any supported version
+func decodeState(data []byte) (map[string]TicketState, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+
+	// Version 1 has no version field: ticket IDs map straight to their state
+	if _, versioned := fields["version"]; !versioned {
+		var tickets map[string]TicketState
+		if err := json.Unmarshal(data, &tickets); err != nil {
+			return nil, err
+		}
+		return tickets, nil
+	}
+
+	var file stateFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, err
+	}
+	if file.Version > currentVersion {
+		return nil, &versionError{version: file.Version}
+	}
+	return file.Tickets, nil
+}
+
+// Save writes the current state to disk in the current format. The state is
+// written to a temporary file that then replaces the state file, so a crash
+// never leaves it half written, and the replaced file is kept as a backup.
 func (sm *StateManager) Save() error {
 	// Create directory if it doesn't exist
 	dir := filepath.Dir(sm.stateFilePath)
@@ -65,16 +167,43 @@ func (sm *StateManager) Save() error {
 		return fmt.Errorf("failed to create state directory: %w", err)
 	}
 
-	file, err := os.Create(sm.stateFilePath)
+	sm.mu.Lock()
+	if sm.unreadable {
+		sm.mu.Unlock()
+		return fmt.Errorf("state file %s could not be read, so it is left as is", sm.stateFilePath)
+	}
+	data, err := json.MarshalIndent(stateFile{Version: currentVersion, Tickets: sm.state}, "", "  ")
+	sm.mu.Unlock()
+	if err != nil {
+		return fmt.Errorf("failed to encode state: %w", err)
+	}
+
+	file, err := os.CreateTemp(dir, filepath.Base(sm.stateFilePath)+".tmp-*")
 	if err != nil {
 		return fmt.Errorf("failed to create state file: %w", err)
 	}
-	defer file.Close()
+	defer os.Remove(file.Name()) // Fails harmlessly once renamed
 
-	encoder := json.NewEncoder(file)
-	encoder.SetIndent("", "  ")
-	if err := encoder.Encode(sm.state); err != nil {
-		return fmt.Errorf("failed to encode state: %w", err)
+	_, err = file.Write(append(data, '\n'))
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Chmod(file.Name(), 0644)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write state file: %w", err)
+	}
+
+	// Keep the state being replaced; a missing one just leaves no backup
+	os.Remove(sm.backupPath())
+	os.Link(sm.stateFilePath, sm.backupPath())
+
+	if err := os.Rename(file.Name(), sm.stateFilePath); err != nil {
+		return fmt.Errorf("failed to replace state file: %w", err)
 	}
 
 	return nil
@@ -87,6 +216,7 @@ func (sm *StateManager) CalculateHash(ticket domain.Ticket) string {
 	// Include all relevant fields in the hash
 	io.WriteString(h, ticket.Title)
 	io.WriteString(h, ticket.Description)
+	io.WriteString(h, ticket.Status)
 
 	// Include acceptance criteria
 	for _, ac := range ticket.AcceptanceCriteria {
@@ -109,10 +239,24 @@ func (sm *StateManager) CalculateHash(ticket domain.Ticket) string {
 		io.WriteString(h, value)
 	}
 
+	// Include links to other issues
+	for _, link := range ticket.Links {
+		io.WriteString(h, link.Type)
+		io.WriteString(h, link.Key)
+		io.WriteString(h, link.Title)
+	}
+
+	// Include comments, so new local comments are pushed
+	for _, comment := range ticket.Comments {
+		io.WriteString(h, comment.ID)
+		io.WriteString(h, comment.Body)
+	}
+
 	// Include tasks
 	for _, task := range ticket.Tasks {
 		io.WriteString(h, task.Title)
 		io.WriteString(h, task.Description)
+		io.WriteString(h, task.Status)
 		for _, ac := range task.AcceptanceCriteria {
 			io.WriteString(h, ac)
 		}
@@ -144,6 +288,8 @@ func (sm *StateManager) HasChanged(ticket domain.Ticket) bool {
 	}
 
 	currentHash := sm.CalculateHash(ticket)
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
 	storedState, exists := sm.state[ticket.JiraID]
 
 	// If we don't have a stored state, consider it changed
@@ -154,41 +300,115 @@ func (sm *StateManager) HasChanged(ticket domain.Ticket) bool {
 	return currentHash != storedState.LocalHash
 }
 
-// UpdateHash updates the stored hash for a ticket (updates both local and remote)
+// UpdateHash updates the stored hash for a ticket (updates both local and
+// remote) and records it as the base of the next merge
 func (sm *StateManager) UpdateHash(ticket domain.Ticket) {
 	if ticket.JiraID != "" {
 		hash := sm.CalculateHash(ticket)
+		sm.mu.Lock()
+		defer sm.mu.Unlock()
 		sm.state[ticket.JiraID] = TicketState{
 			LocalHash:  hash,
 			RemoteHash: hash,
+			Base:       NewSnapshot(ticket),
 		}
 	}
 }
 
+// UpdateRemote records a ticket as Jira has it: its remote hash and the base
+// of the next merge, settling any conflict. The local hash is left alone, so
+// local changes merged with it are still pushed.
+func (sm *StateManager) UpdateRemote(ticket domain.Ticket) {
+	if ticket.JiraID != "" {
+		hash := sm.CalculateHash(ticket)
+		sm.mu.Lock()
+		defer sm.mu.Unlock()
+		state := sm.state[ticket.JiraID]
+		state.RemoteHash = hash
+		state.Base = NewSnapshot(ticket)
+		state.Conflict = nil
+		sm.state[ticket.JiraID] = state
+	}
+}
+
 // UpdateLocalHash updates only the local hash for a ticket
 func (sm *StateManager) UpdateLocalHash(ticket domain.Ticket) {
 	if ticket.JiraID != "" {
+		hash := sm.CalculateHash(ticket)
+		sm.mu.Lock()
+		defer sm.mu.Unlock()
 		state := sm.state[ticket.JiraID]
-		state.LocalHash = sm.CalculateHash(ticket)
+		state.LocalHash = hash
 		sm.state[ticket.JiraID] = state
 	}
 }
 
 // UpdateRemoteHash updates only the remote hash for a ticket
 func (sm *StateManager) UpdateRemoteHash(ticketID string, hash string) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
 	state := sm.state[ticketID]
 	state.RemoteHash = hash
 	sm.state[ticketID] = state
 }
 
+// MarkConflict records Jira's version of a ticket whose changes conflict with
+// local ones, leaving the hashes and base alone until it is resolved
+func (sm *StateManager) MarkConflict(remote domain.Ticket) {
+	if remote.JiraID != "" {
+		hash := sm.CalculateHash(remote)
+		sm.mu.Lock()
+		defer sm.mu.Unlock()
+		state := sm.state[remote.JiraID]
+		state.Conflict = &Conflict{RemoteHash: hash, Remote: NewSnapshot(remote)}
+		sm.state[remote.JiraID] = state
+	}
+}
+
+// Resolve marks a ticket's conflict resolved: the Jira version it conflicted
+// with becomes its remote hash and base, so the next pull only brings newer
+// Jira changes and the next push sends the resolved ticket. It reports false
+// when the ticket has no conflict.
+func (sm *StateManager) Resolve(ticketID string) bool {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	state, exists := sm.state[ticketID]
+	if !exists || state.Conflict == nil {
+		return false
+	}
+	state.RemoteHash = state.Conflict.RemoteHash
+	state.Base = state.Conflict.Remote
+	state.Conflict = nil
+	sm.state[ticketID] = state
+	return true
+}
+
+// Conflicts returns the IDs of the tickets with unresolved conflicts, sorted
+func (sm *StateManager) Conflicts() []string {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
+	var ids []string
+	for id, state := range sm.state {
+		if state.Conflict != nil {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
 // GetStoredState returns the stored state for a ticket ID
 func (sm *StateManager) GetStoredState(ticketID string) (TicketState, bool) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
 	state, exists := sm.state[ticketID]
 	return state, exists
 }
 
 // SetStoredState sets the state for a ticket ID (useful for testing)
 func (sm *StateManager) SetStoredState(ticketID string, state TicketState) {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
 	sm.state[ticketID] = state
 }
 
@@ -199,6 +419,8 @@ func (sm *StateManager) DetectConflict(ticket domain.Ticket) bool {
 	}
 
 	currentHash := sm.CalculateHash(ticket)
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
 	storedState, exists := sm.state[ticket.JiraID]
 
 	if !exists {
@@ -214,6 +436,8 @@ func (sm *StateManager) DetectConflict(ticket domain.Ticket) bool {
 
 // IsRemoteChanged checks if only the remote has changed
 func (sm *StateManager) IsRemoteChanged(ticketID string, remoteHash string) bool {
+	sm.mu.Lock()
+	defer sm.mu.Unlock()
 	storedState, exists := sm.state[ticketID]
 	if !exists {
 		return true // Consider new remote content as changed
@@ -1,9 +1,16 @@
 package state
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/karolswdev/ticktr/internal/core/domain"
 )
@@ -187,6 +194,41 @@ func TestStateManager_DeterministicHashing(t *testing.T) {
 	}
 }
 
+func TestStateManager_HashIncludesLinks(t *testing.T) {
+	sm := NewStateManager(filepath.Join(t.TempDir(), "test.state"))
+
+	ticket := domain.Ticket{JiraID: "TEST-1", Title: "Linked"}
+	unlinked := sm.CalculateHash(ticket)
+
+	ticket.Links = []domain.Link{{Type: "blocks", Title: "Other ticket"}}
+	local := sm.CalculateHash(ticket)
+
+	ticket.Links = []domain.Link{{Type: "blocks", Key: "TEST-2"}}
+	resolved := sm.CalculateHash(ticket)
+
+	if unlinked == local || local == resolved || unlinked == resolved {
+		t.Error("Expected adding a link and resolving its target to change the hash")
+	}
+}
+
+func TestStateManager_HashIncludesStatus(t *testing.T) {
+	sm := NewStateManager(filepath.Join(t.TempDir(), "test.state"))
+
+	ticket := domain.Ticket{JiraID: "TEST-1", Title: "Tracked", Tasks: []domain.Task{{Title: "Step"}}}
+	base := sm.CalculateHash(ticket)
+
+	ticket.Status = "Done"
+	closed := sm.CalculateHash(ticket)
+
+	ticket.Status = ""
+	ticket.Tasks[0].Status = "Done"
+	taskClosed := sm.CalculateHash(ticket)
+
+	if base == closed || base == taskClosed || closed == taskClosed {
+		t.Error("Expected ticket and task status changes to change the hash")
+	}
+}
+
 func TestStateManager_HashWithMapPermutations(t *testing.T) {
 	tmpDir := t.TempDir()
 	stateFile := filepath.Join(tmpDir, "test.state")
@@ -268,3 +310,230 @@ func TestStateManager_NestedCustomFieldsDeterminism(t *testing.T) {
 		t.Logf("Hash3: %s", hash3)
 	}
 }
+
+func TestStateManager_ConcurrentUpdates(t *testing.T) {
+	sm := NewStateManager(filepath.Join(t.TempDir(), "test.state"))
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ticket := domain.Ticket{Title: fmt.Sprintf("Ticket %d", i), JiraID: fmt.Sprintf("PROJ-%d", i)}
+			if sm.HasChanged(ticket) {
+				sm.UpdateHash(ticket)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if err := sm.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+	for i := 0; i < 50; i++ {
+		if _, ok := sm.GetStoredState(fmt.Sprintf("PROJ-%d", i)); !ok {
+			t.Errorf("Expected state for PROJ-%d", i)
+		}
+	}
+}
+
+func TestStateManager_BaseSnapshot(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "test.state")
+	sm := NewStateManager(stateFile)
+
+	ticket := domain.Ticket{
+		JiraID:             "PROJ-1",
+		Title:              "Login",
+		CustomFields:       map[string]string{"Priority": "High"},
+		AcceptanceCriteria: []string{"Email works"},
+		Tasks:              []domain.Task{{JiraID: "PROJ-2", Title: "Form"}},
+		Comments:           []domain.Comment{{ID: "10", Body: "Not part of the base"}},
+	}
+	sm.UpdateHash(ticket)
+	if err := sm.Save(); err != nil {
+		t.Fatalf("Save failed: %v", err)
+	}
+
+	loaded := NewStateManager(stateFile)
+	if err := loaded.Load(); err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	stored, _ := loaded.GetStoredState("PROJ-1")
+	if stored.Base == nil {
+		t.Fatal("Expected a base snapshot")
+	}
+	base := stored.Base.Ticket("PROJ-1")
+	if base.Title != "Login" || base.CustomFields["Priority"] != "High" || len(base.AcceptanceCriteria) != 1 ||
+		len(base.Tasks) != 1 || base.Tasks[0].JiraID != "PROJ-2" || len(base.Comments) != 0 {
+		t.Errorf("Unexpected base ticket: %+v", base)
+	}
+
+	// A new remote version moves the base but not the local hash
+	remote := ticket
+	remote.Title = "Sign in"
+	loaded.UpdateRemote(remote)
+	updated, _ := loaded.GetStoredState("PROJ-1")
+	if updated.LocalHash != stored.LocalHash {
+		t.Error("UpdateRemote should leave the local hash alone")
+	}
+	if updated.RemoteHash != loaded.CalculateHash(remote) || updated.Base.Title != "Sign in" {
+		t.Errorf("UpdateRemote should record Jira's version, got %+v", updated)
+	}
+}
+
+func TestStateManager_SaveWritesVersionedFileAtomically(t *testing.T) {
+	tmpDir := t.TempDir()
+	statePath := filepath.Join(tmpDir, "test.state")
+	sm := NewStateManager(statePath)
+	sm.UpdateHash(domain.Ticket{JiraID: "TEST-1", Title: "First"})
+	if err := sm.Save(); err != nil {
+		t.Fatalf("Failed to save state: %v", err)
+	}
+	first, _ := os.ReadFile(statePath)
+
+	sm.UpdateHash(domain.Ticket{JiraID: "TEST-2", Title: "Second"})
+	if err := sm.Save(); err != nil {
+		t.Fatalf("Failed to save state: %v", err)
+	}
+
+	var saved stateFile
+	data, _ := os.ReadFile(statePath)
+	if err := json.Unmarshal(data, &saved); err != nil {
+		t.Fatalf("State file is not valid JSON: %v", err)
+	}
+	if saved.Version != currentVersion || len(saved.Tickets) != 2 {
+		t.Errorf("Expected version %d with 2 tickets, got version %d with %d", currentVersion, saved.Version, len(saved.Tickets))
+	}
+	if backup, _ := os.ReadFile(statePath + ".bak"); string(backup) != string(first) {
+		t.Errorf("Expected the backup to hold the previous state, got:\n%s", backup)
+	}
+
+	entries, _ := os.ReadDir(tmpDir)
+	for _, entry := range entries {
+		if strings.Contains(entry.Name(), ".tmp-") {
+			t.Errorf("Temporary file %s left behind", entry.Name())
+		}
+	}
+}
+
+func TestStateManager_MigratesFlatFormat(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "test.state")
+	flat := `{"TEST-1": {"local_hash": "A", "remote_hash": "B"}}`
+	if err := os.WriteFile(stateFile, []byte(flat), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sm := NewStateManager(stateFile)
+	if err := sm.Load(); err != nil {
+		t.Fatalf("Failed to load version 1 state: %v", err)
+	}
+	if state, _ := sm.GetStoredState("TEST-1"); state.LocalHash != "A" || state.RemoteHash != "B" {
+		t.Errorf("Unexpected migrated state: %+v", state)
+	}
+	if err := sm.Save(); err != nil {
+		t.Fatalf("Failed to save state: %v", err)
+	}
+
+	data, _ := os.ReadFile(stateFile)
+	if !strings.Contains(string(data), `"version": 2`) {
+		t.Errorf("Expected the saved state to be version 2, got:\n%s", data)
+	}
+	sm2 := NewStateManager(stateFile)
+	if err := sm2.Load(); err != nil {
+		t.Fatalf("Failed to load migrated state: %v", err)
+	}
+	if state, _ := sm2.GetStoredState("TEST-1"); state.LocalHash != "A" || state.RemoteHash != "B" {
+		t.Errorf("Unexpected state after migration: %+v", state)
+	}
+}
+
+func TestStateManager_CorruptFile(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "test.state")
+	sm := NewStateManager(stateFile)
+	sm.UpdateHash(domain.Ticket{JiraID: "TEST-1", Title: "First"})
+	sm.Save()
+	sm.Save()
+	if err := os.WriteFile(stateFile, []byte(`{"version": 2, "tick`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sm2 := NewStateManager(stateFile)
+	err := sm2.Load()
+	var corrupt *CorruptStateError
+	if !errors.As(err, &corrupt) {
+		t.Fatalf("Expected a CorruptStateError, got %v", err)
+	}
+	if corrupt.Backup != stateFile+".bak" || !strings.Contains(err.Error(), "mv "+stateFile+".bak "+stateFile) {
+		t.Errorf("Expected the error to point at the backup, got %v", err)
+	}
+
+	sm2.UpdateHash(domain.Ticket{JiraID: "TEST-2", Title: "Second"})
+	if err := sm2.Save(); err == nil {
+		t.Error("Expected Save to refuse to overwrite the corrupt file")
+	}
+	if data, _ := os.ReadFile(stateFile); string(data) != `{"version": 2, "tick` {
+		t.Errorf("Corrupt state file was overwritten:\n%s", data)
+	}
+
+	// Restoring the backup recovers the previous state
+	if err := os.Rename(stateFile+".bak", stateFile); err != nil {
+		t.Fatal(err)
+	}
+	sm3 := NewStateManager(stateFile)
+	if err := sm3.Load(); err != nil {
+		t.Fatalf("Failed to load the restored state: %v", err)
+	}
+	if _, exists := sm3.GetStoredState("TEST-1"); !exists {
+		t.Error("Expected the restored state to hold TEST-1")
+	}
+}
+
+func TestStateManager_RefusesNewerVersion(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "test.state")
+	if err := os.WriteFile(stateFile, []byte(`{"version": 99, "tickets": {}}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sm := NewStateManager(stateFile)
+	if err := sm.Load(); err == nil || !strings.Contains(err.Error(), "version 99") {
+		t.Errorf("Expected a version error, got %v", err)
+	}
+	if err := sm.Save(); err == nil {
+		t.Error("Expected Save to refuse to overwrite a newer state file")
+	}
+}
+
+func TestStateManager_Lock(t *testing.T) {
+	stateFile := filepath.Join(t.TempDir(), "test.state")
+	first := NewStateManager(stateFile)
+	second := NewStateManager(stateFile)
+
+	unlock, err := first.Lock(context.Background())
+	if err != nil {
+		t.Fatalf("Failed to lock state: %v", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+	if _, err := second.Lock(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected the second lock to wait until the deadline, got %v", err)
+	}
+
+	acquired := make(chan error)
+	go func() {
+		unlock, err := second.Lock(context.Background())
+		if err == nil {
+			unlock()
+		}
+		acquired <- err
+	}()
+	unlock()
+	select {
+	case err := <-acquired:
+		if err != nil {
+			t.Errorf("Failed to lock state once released: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Error("Second lock was not acquired after the first was released")
+	}
+}
@@ -0,0 +1,82 @@
+package state
+
+import "github.com/karolswdev/ticktr/internal/core/domain"
+
+// Snapshot is a ticket's content when it was last in sync with Jira, the
+// base of the three-way merge on pull
+type Snapshot struct {
+	Title              string            `json:"title"`
+	Description        string            `json:"description,omitempty"`
+	Status             string            `json:"status,omitempty"`
+	Fields             map[string]string `json:"fields,omitempty"`
+	AcceptanceCriteria []string          `json:"acceptance_criteria,omitempty"`
+	Links              []domain.Link     `json:"links,omitempty"`
+	Tasks              []TaskSnapshot    `json:"tasks,omitempty"`
+}
+
+// TaskSnapshot is a task's content when it was last in sync with Jira
+type TaskSnapshot struct {
+	JiraID             string            `json:"key,omitempty"`
+	Title              string            `json:"title"`
+	Description        string            `json:"description,omitempty"`
+	Status             string            `json:"status,omitempty"`
+	Fields             map[string]string `json:"fields,omitempty"`
+	AcceptanceCriteria []string          `json:"acceptance_criteria,omitempty"`
+}
+
+// NewSnapshot captures the content of a ticket. Comments are left out: they
+// are merged by ID, not against a base.
+func NewSnapshot(ticket domain.Ticket) *Snapshot {
+	snapshot := &Snapshot{
+		Title:              ticket.Title,
+		Description:        ticket.Description,
+		Status:             ticket.Status,
+		Fields:             copyFields(ticket.CustomFields),
+		AcceptanceCriteria: append([]string(nil), ticket.AcceptanceCriteria...),
+		Links:              append([]domain.Link(nil), ticket.Links...),
+	}
+	for _, task := range ticket.Tasks {
+		snapshot.Tasks = append(snapshot.Tasks, TaskSnapshot{
+			JiraID:             task.JiraID,
+			Title:              task.Title,
+			Description:        task.Description,
+			Status:             task.Status,
+			Fields:             copyFields(task.CustomFields),
+			AcceptanceCriteria: append([]string(nil), task.AcceptanceCriteria...),
+		})
+	}
+	return snapshot
+}
+
+// Ticket returns the snapshot as the ticket with the given Jira key
+func (s *Snapshot) Ticket(jiraID string) domain.Ticket {
+	ticket := domain.Ticket{
+		JiraID:             jiraID,
+		Title:              s.Title,
+		Description:        s.Description,
+		Status:             s.Status,
+		CustomFields:       copyFields(s.Fields),
+		AcceptanceCriteria: append([]string(nil), s.AcceptanceCriteria...),
+		Links:              append([]domain.Link(nil), s.Links...),
+	}
+	for _, task := range s.Tasks {
+		ticket.Tasks = append(ticket.Tasks, domain.Task{
+			JiraID:             task.JiraID,
+			Title:              task.Title,
+			Description:        task.Description,
+			Status:             task.Status,
+			CustomFields:       copyFields(task.Fields),
+			AcceptanceCriteria: append([]string(nil), task.AcceptanceCriteria...),
+		})
+	}
+	return ticket
+}
+
+// copyFields copies a field map, never returning nil
+func copyFields(fields map[string]string) map[string]string {
+	copied := make(map[string]string, len(fields))
+	for name, value := range fields {
+		copied[name] = value
+	}
+	return copied
+}